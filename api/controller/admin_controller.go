@@ -0,0 +1,394 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/api/service"
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/schema"
+)
+
+type AdminController interface {
+	GetAllUsers(w http.ResponseWriter, r *http.Request)
+	DeactivateUser(w http.ResponseWriter, r *http.Request)
+	ReactivateUser(w http.ResponseWriter, r *http.Request)
+	ForcePasswordReset(w http.ResponseWriter, r *http.Request)
+	RevokeUserSessions(w http.ResponseWriter, r *http.Request)
+	GetTeam(w http.ResponseWriter, r *http.Request)
+	TransferTeamOwnership(w http.ResponseWriter, r *http.Request)
+	GetAuditLogs(w http.ResponseWriter, r *http.Request)
+}
+
+type adminController struct {
+	adminService service.AdminService
+}
+
+func NewAdminController(adminService service.AdminService) AdminController {
+	return adminController{
+		adminService: adminService,
+	}
+}
+
+// GetAllUsers fetches all users of the application for admin.
+// @Summary Get all users
+// @Description Admin can get all users including those who are not discoverable, deactivated or deleted, privacy settings of users are not applied.
+// @Produce json
+// @Tags admin
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param Limit query int false "Number of users to return per page (default 10)"
+// @Param Offset query int false "Offset for pagination (default 0)"
+// @Param Search query string false "Search term to filter users by name or email"
+// @Param Deactivated query bool false "Filter users by whether they are deactivated"
+// @Success 200 {object} []response.AdminUser "Users fetched successfully"
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only admin is allowed."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/admin/users [get]
+func (a adminController) GetAllUsers(w http.ResponseWriter, r *http.Request) {
+	var adminUserQueryParams request.AdminUserQueryParams
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&adminUserQueryParams, r.URL.Query())
+	if err != nil {
+		errorhandling.HandleSchemaDecodeError(r, w, err)
+		return
+	}
+
+	err = utils.Validate.Struct(adminUserQueryParams)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	if adminUserQueryParams.Limit == 0 {
+		adminUserQueryParams.Limit = 10
+	}
+
+	adminId := r.Context().Value(constant.UserIdKey).(int64)
+	users, err := a.adminService.GetAllUsers(adminId, adminUserQueryParams)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, users)
+}
+
+// DeactivateUser deactivates account of the user.
+// @Summary Deactivate user
+// @Description Admin can deactivate account of the user, user is logged out from all sessions and can't login or use any token until account is reactivated.
+// @Produce json
+// @Tags admin
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param UserID path int64 true "ID of user who is to be deactivated."
+// @Success 200 {object} response.SuccessResponse "User deactivated successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request or admin tried to deactivate own account."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only admin is allowed."
+// @Failure 404 {object} errorhandling.CustomError "User not found."
+// @Failure 409 {object} errorhandling.CustomError "User is already deactivated."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/admin/users/{UserID}/deactivate [put]
+func (a adminController) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.ParseInt(chi.URLParam(r, constant.USER_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	adminId := r.Context().Value(constant.UserIdKey).(int64)
+	err = a.adminService.DeactivateUser(adminId, userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.USER_DEACTIVATED,
+	}
+	config.LoggerInstance.Info(constant.USER_DEACTIVATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// ReactivateUser reactivates deactivated account of the user.
+// @Summary Reactivate user
+// @Description Admin can reactivate deactivated account of the user, user has to login again to use the application.
+// @Produce json
+// @Tags admin
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param UserID path int64 true "ID of user who is to be reactivated."
+// @Success 200 {object} response.SuccessResponse "User reactivated successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only admin is allowed."
+// @Failure 404 {object} errorhandling.CustomError "User not found."
+// @Failure 409 {object} errorhandling.CustomError "User is not deactivated."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/admin/users/{UserID}/reactivate [put]
+func (a adminController) ReactivateUser(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.ParseInt(chi.URLParam(r, constant.USER_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	adminId := r.Context().Value(constant.UserIdKey).(int64)
+	err = a.adminService.ReactivateUser(adminId, userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.USER_REACTIVATED,
+	}
+	config.LoggerInstance.Info(constant.USER_REACTIVATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// ForcePasswordReset forces the user to reset password.
+// @Summary Force password reset
+// @Description Admin can force the user to reset password, user is logged out from all sessions, current password stops working for login and password reset OTP is sent to email of the user.
+// @Produce json
+// @Tags admin
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param UserID path int64 true "ID of user whose password is to be reset."
+// @Success 200 {object} response.SuccessResponse "Password reset forced successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only admin is allowed."
+// @Failure 404 {object} errorhandling.CustomError "User not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/admin/users/{UserID}/password-reset [post]
+func (a adminController) ForcePasswordReset(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.ParseInt(chi.URLParam(r, constant.USER_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	adminId := r.Context().Value(constant.UserIdKey).(int64)
+	err = a.adminService.ForcePasswordReset(adminId, userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.PASSWORD_RESET_FORCED,
+	}
+	config.LoggerInstance.Info(constant.PASSWORD_RESET_FORCED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// RevokeUserSessions revokes all sessions of the user.
+// @Summary Revoke all sessions of user
+// @Description Admin can log the user out from all devices, refresh tokens of the user stop working and access tokens issued till now are rejected immediately.
+// @Produce json
+// @Tags admin
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param UserID path int64 true "ID of user whose sessions are to be revoked."
+// @Success 200 {object} response.SuccessResponse "All sessions of user revoked successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only admin is allowed."
+// @Failure 404 {object} errorhandling.CustomError "User not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/admin/users/{UserID}/sessions [delete]
+func (a adminController) RevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.ParseInt(chi.URLParam(r, constant.USER_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	adminId := r.Context().Value(constant.UserIdKey).(int64)
+	err = a.adminService.RevokeUserSessions(adminId, userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.USER_SESSIONS_REVOKED,
+	}
+	config.LoggerInstance.Info(constant.USER_SESSIONS_REVOKED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetTeam fetches any team along with its members for admin.
+// @Summary Get team
+// @Description Admin can view any team along with all of its members, privacy settings of members are not applied.
+// @Produce json
+// @Tags admin
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param TeamID path int64 true "Team ID"
+// @Success 200 {object} response.TeamDetails "Team fetched successfully"
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only admin is allowed."
+// @Failure 404 {object} errorhandling.CustomError "Team not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/admin/teams/{TeamID} [get]
+func (a adminController) GetTeam(w http.ResponseWriter, r *http.Request) {
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	adminId := r.Context().Value(constant.UserIdKey).(int64)
+	teamDetails, err := a.adminService.GetTeam(adminId, teamId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, teamDetails)
+}
+
+// TransferTeamOwnership transfers ownership of the team to another user.
+// @Summary Transfer team ownership
+// @Description Admin can make any active user owner of the team, user is added to the team if not already a member.
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param TeamID path int64 true "Team ID"
+// @Param ownerId body request.TeamOwnershipTransfer true "ID of the new owner of the team."
+// @Success 200 {object} response.SuccessResponse "Team ownership transferred successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request or new owner is deactivated."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only admin is allowed."
+// @Failure 404 {object} errorhandling.CustomError "Team or user not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/admin/teams/{TeamID}/owner [put]
+func (a adminController) TransferTeamOwnership(w http.ResponseWriter, r *http.Request) {
+	var ownershipTransfer request.TeamOwnershipTransfer
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &ownershipTransfer)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	ownershipTransfer.TeamID = teamId
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(ownershipTransfer)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	adminId := r.Context().Value(constant.UserIdKey).(int64)
+	err = a.adminService.TransferTeamOwnership(adminId, ownershipTransfer)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.TEAM_OWNER_TRANSFERRED,
+	}
+	config.LoggerInstance.Info(constant.TEAM_OWNER_TRANSFERRED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetAuditLogs fetches admin audit log.
+// @Summary Get admin audit log
+// @Description Admin can get audit log of all admin actions from latest to oldest, filtered by action, admin and target user.
+// @Produce json
+// @Tags admin
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param Limit query int false "Number of entries to return per page (default 10)"
+// @Param Offset query int false "Offset for pagination (default 0)"
+// @Param Action query string false "Filter by admin action"
+// @Param AdminID query int64 false "Filter by admin who performed the action"
+// @Param TargetUserID query int64 false "Filter by user on whom the action was performed"
+// @Success 200 {object} []response.AdminAuditLog "Audit log fetched successfully"
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only admin is allowed."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/admin/audit-logs [get]
+func (a adminController) GetAuditLogs(w http.ResponseWriter, r *http.Request) {
+	var auditLogQueryParams request.AdminAuditLogQueryParams
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&auditLogQueryParams, r.URL.Query())
+	if err != nil {
+		errorhandling.HandleSchemaDecodeError(r, w, err)
+		return
+	}
+
+	err = utils.Validate.Struct(auditLogQueryParams)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	if auditLogQueryParams.Limit == 0 {
+		auditLogQueryParams.Limit = 10
+	}
+
+	adminId := r.Context().Value(constant.UserIdKey).(int64)
+	auditLogs, err := a.adminService.GetAuditLogs(adminId, auditLogQueryParams)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, auditLogs)
+}
@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllUsers(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		QueryParams  request.AdminUserQueryParams
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "All Users Fetched Successfully",
+			QueryParams: request.AdminUserQueryParams{
+				Limit:  10,
+				Search: "gmail.com",
+			},
+			StatusCode: 200,
+		},
+		{
+			TestCaseName: "Limit Exceeded.",
+			QueryParams: request.AdminUserQueryParams{
+				Limit: 100,
+			},
+			StatusCode: 400,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Get("/api/v1/admin/users", NewAdminController(adminService).GetAllUsers)
+
+			req, err := http.NewRequest("GET", "/api/v1/admin/users", http.NoBody)
+			if err != nil {
+				log.Println(err)
+			}
+
+			ctx := context.WithValue(req.Context(), constant.UserIdKey, int64(954488202459119617))
+			req = req.WithContext(ctx)
+
+			q := req.URL.Query()
+			q.Add("limit", strconv.Itoa(v.QueryParams.Limit))
+			q.Add("search", v.QueryParams.Search)
+			req.URL.RawQuery = q.Encode()
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestDeactivateAndReactivateUser(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		Action       string
+		UserID       string
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "User Deactivated Successfully.",
+			Action:       "deactivate",
+			UserID:       strconv.FormatInt(954497896847212548, 10),
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "User Already Deactivated.",
+			Action:       "deactivate",
+			UserID:       strconv.FormatInt(954497896847212548, 10),
+			StatusCode:   409,
+		},
+		{
+			TestCaseName: "Admin Deactivating Self.",
+			Action:       "deactivate",
+			UserID:       strconv.FormatInt(954488202459119617, 10),
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "User Reactivated Successfully.",
+			Action:       "reactivate",
+			UserID:       strconv.FormatInt(954497896847212548, 10),
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Invalid User ID.",
+			Action:       "reactivate",
+			UserID:       "user",
+			StatusCode:   400,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			if v.Action == "deactivate" {
+				r.Put("/api/v1/admin/users/:UserID/"+v.Action, NewAdminController(adminService).DeactivateUser)
+			} else {
+				r.Put("/api/v1/admin/users/:UserID/"+v.Action, NewAdminController(adminService).ReactivateUser)
+			}
+
+			req, _ := http.NewRequest("PUT", "/api/v1/admin/users/:UserID/"+v.Action, http.NoBody)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("UserID", v.UserID)
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, constant.UserIdKey, int64(954488202459119617))
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestTransferTeamOwnership(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		TeamID       int64
+		OwnerID      int64
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Team Ownership Transferred Successfully.",
+			TeamID:       954507580144451586,
+			OwnerID:      954497896847212545,
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Team Ownership Transferred Back Successfully.",
+			TeamID:       954507580144451586,
+			OwnerID:      954488202459119617,
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Owner ID is Required.",
+			TeamID:       954507580144451586,
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "Team Not Found.",
+			TeamID:       1,
+			OwnerID:      954497896847212545,
+			StatusCode:   404,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Put("/api/v1/admin/teams/:TeamID/owner", NewAdminController(adminService).TransferTeamOwnership)
+
+			jsonValue, err := json.Marshal(request.TeamOwnershipTransfer{OwnerID: v.OwnerID})
+			if err != nil {
+				log.Println(err)
+			}
+			req, err := http.NewRequest("PUT", "/api/v1/admin/teams/:TeamID/owner", bytes.NewBuffer(jsonValue))
+			if err != nil {
+				log.Println(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("TeamID", strconv.FormatInt(v.TeamID, 10))
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, constant.UserIdKey, int64(954488202459119617))
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
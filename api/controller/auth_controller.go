@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/chirag1807/task-management-system/api/model/dto"
 	"github.com/chirag1807/task-management-system/api/model/request"
 	"github.com/chirag1807/task-management-system/api/model/response"
 	"github.com/chirag1807/task-management-system/api/service"
@@ -14,6 +17,8 @@ import (
 	"github.com/chirag1807/task-management-system/constant"
 	errorhandling "github.com/chirag1807/task-management-system/error"
 	"github.com/chirag1807/task-management-system/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/schema"
 )
 
 func init() {
@@ -23,7 +28,18 @@ func init() {
 type AuthController interface {
 	UserRegistration(w http.ResponseWriter, r *http.Request)
 	UserLogin(w http.ResponseWriter, r *http.Request)
+	VerifyTwoFactorLogin(w http.ResponseWriter, r *http.Request)
 	RefreshToken(w http.ResponseWriter, r *http.Request)
+	Logout(w http.ResponseWriter, r *http.Request)
+	LogoutAll(w http.ResponseWriter, r *http.Request)
+	GetActiveSessions(w http.ResponseWriter, r *http.Request)
+	RevokeSession(w http.ResponseWriter, r *http.Request)
+	CreatePersonalAccessToken(w http.ResponseWriter, r *http.Request)
+	GetPersonalAccessTokens(w http.ResponseWriter, r *http.Request)
+	RevokePersonalAccessToken(w http.ResponseWriter, r *http.Request)
+	OIDCLogin(w http.ResponseWriter, r *http.Request)
+	OIDCCallback(w http.ResponseWriter, r *http.Request)
+	GetJWKS(w http.ResponseWriter, r *http.Request)
 }
 
 type authController struct {
@@ -38,7 +54,7 @@ func NewAuthController(authService service.AuthService) AuthController {
 
 // UserRegistration registers a new user in the task manager application.
 // @Summary Register User
-// @Description UserRegistration API is made for registering a new user in the task manager application.
+// @Description UserRegistration API is made for registering a new user in the task manager application, verification code is sent to email of the user which is to be verified by VerifyEmail API.
 // @Accept json
 // @Produce json
 // @Tags auth
@@ -47,7 +63,7 @@ func NewAuthController(authService service.AuthService) AuthController {
 // @Param bio formData string true "Bio of the user"
 // @Param email formData string true "Email of the user"
 // @Param password formData string true "Password of the user"
-// @Param privacy formData string true "privacy of the user (PUBLIC, PRIVATE)"
+// @Param privacy formData string false "Privacy settings of the user with emailVisibility, bioVisibility, teamsVisibility and assignableBy (EVERYONE, TEAMMATES, NOBODY) and discoverable, everyone is default"
 // @Success 200 {object} response.SuccessResponse "User created successfully."
 // @Failure 400 {object} errorhandling.CustomError "Bad request."
 // @Failure 409 {object} errorhandling.CustomError "Duplicate email found."
@@ -82,6 +98,12 @@ func (a authController) UserRegistration(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	err = utils.ValidatePasswordPolicy("password", userRequest.Password)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
 	hashedPassword, err := utils.HashPassword(userRequest.Password)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
@@ -106,16 +128,18 @@ func (a authController) UserRegistration(w http.ResponseWriter, r *http.Request)
 
 // UserLogin login the user in task manager application.
 // @Summary Login User
-// @Description UserLogin API is made for login the user in task manager application.
+// @Description UserLogin API is made for login the user in task manager application. If user has two factor authentication enabled then only challenge token is returned, which is to be sent to /api/v1/auth/login/2fa with a code.
 // @Accept json
 // @Produce json
 // @Tags auth
 // @Param email formData string true "Email of the user"
 // @Param password formData string true "Password of the user"
 // @Success 200 {object} response.UserWithTokens "User login done successfully."
+// @Success 200 {object} response.TwoFactorChallenge "Two factor authentication is required."
 // @Failure 400 {object} errorhandling.CustomError "Bad request."
 // @Failure 401 {object} errorhandling.CustomError "Password not matched."
 // @Failure 404 {object} errorhandling.CustomError "User not found."
+// @Failure 429 {object} errorhandling.CustomError "Either too many requests or account is locked because of too many failed attempts, see Retry-After header."
 // @Failure 500 {object} errorhandling.CustomError "Internal server error."
 // @Router /api/v1/auth/login [post]
 func (a authController) UserLogin(w http.ResponseWriter, r *http.Request) {
@@ -143,14 +167,90 @@ func (a authController) UserLogin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var user response.User
-	var refreshToken string
-	user, refreshToken, err = a.authService.UserLogin(userLoginRequest)
+	var sessionToken dto.SessionToken
+	user, sessionToken, err = a.authService.UserLogin(userLoginRequest, utils.GetClientInfo(r))
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	sendLoginResponse(w, r, user, sessionToken)
+}
+
+// sendLoginResponse sends access and refresh token of newly created session,
+// or only challenge token if user has to complete login with two factor authentication code.
+func sendLoginResponse(w http.ResponseWriter, r *http.Request, user response.User, sessionToken dto.SessionToken) {
+	if sessionToken.ChallengeToken != constant.EMPTY_STRING {
+		response := response.TwoFactorChallenge{
+			Code:           http.StatusText(http.StatusOK),
+			Message:        constant.TWO_FACTOR_REQUIRED,
+			ChallengeToken: sessionToken.ChallengeToken,
+		}
+		config.LoggerInstance.Info(constant.TWO_FACTOR_REQUIRED)
+		utils.SendSuccessResponse(w, http.StatusOK, response)
+		return
+	}
+
+	accessToken, err := utils.CreateJWTToken(constant.JWT_TYPE_ACCESS, time.Now().Add(constant.ACCESS_TOKEN_LIFETIME), user.ID, sessionToken.SessionID)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.UserWithTokens{
+		Code:         http.StatusText(http.StatusOK),
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: sessionToken.RefreshToken,
+	}
+	config.LoggerInstance.Info(constant.USER_LOGIN_SUCCEED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// VerifyTwoFactorLogin completes login of the user having two factor authentication enabled.
+// @Summary Login User with Two Factor Authentication
+// @Description VerifyTwoFactorLogin API is made for exchanging challenge token returned by login and code of authenticator app or a recovery code for access and refresh tokens.
+// @Accept json
+// @Produce json
+// @Tags auth
+// @Param challengeToken formData string true "Challenge token returned by login"
+// @Param code formData string true "Code generated by authenticator app or one of the recovery codes"
+// @Success 200 {object} response.UserWithTokens "User login done successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Either code is invalid or challenge is expired."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/auth/login/2fa [post]
+func (a authController) VerifyTwoFactorLogin(w http.ResponseWriter, r *http.Request) {
+	var twoFactorLoginRequest request.TwoFactorLogin
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &twoFactorLoginRequest)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(twoFactorLoginRequest)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	user, sessionToken, err := a.authService.VerifyTwoFactorLogin(twoFactorLoginRequest, utils.GetClientInfo(r))
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
 	}
 
-	accessToken, err := utils.CreateJWTToken(time.Now().Add(time.Hour*5), user.ID)
+	accessToken, err := utils.CreateJWTToken(constant.JWT_TYPE_ACCESS, time.Now().Add(constant.ACCESS_TOKEN_LIFETIME), user.ID, sessionToken.SessionID)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
@@ -160,7 +260,7 @@ func (a authController) UserLogin(w http.ResponseWriter, r *http.Request) {
 		Code:         http.StatusText(http.StatusOK),
 		User:         user,
 		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		RefreshToken: sessionToken.RefreshToken,
 	}
 	config.LoggerInstance.Info(constant.USER_LOGIN_SUCCEED)
 	utils.SendSuccessResponse(w, http.StatusOK, response)
@@ -168,24 +268,25 @@ func (a authController) UserLogin(w http.ResponseWriter, r *http.Request) {
 
 // ResetToken reset the access token of user.
 // @Summary Reset Access Token
-// @Description ResetToken API is made for reset the user's access token.
+// @Description ResetToken API is made for reset the user's access token. Refresh token is rotated, so given refresh token can't be used again, if it is used again then all sessions of that login are revoked.
 // @Produce json
 // @Tags auth
 // @Param Authorization header string true "Refresh Token" default(Bearer <refresh_token>)
 // @Success 200 {object} response.Tokens "Token refresh done successfully."
-// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token is expired, revoked or already used."
+// @Failure 404 {object} errorhandling.CustomError "Refresh token not found."
 // @Failure 500 {object} errorhandling.CustomError "Internal server error."
 // @Router /api/v1/auth/reset-token [post]
 func (a authController) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	token := r.Context().Value(constant.TokenKey).(string)
 
-	userId, refreshToken, err := a.authService.RefreshToken(token)
+	sessionToken, err := a.authService.RefreshToken(token, utils.GetClientInfo(r))
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
 	}
 
-	accessToken, err := utils.CreateJWTToken(time.Now().Add(time.Hour*5), userId)
+	accessToken, err := utils.CreateJWTToken(constant.JWT_TYPE_ACCESS, time.Now().Add(constant.ACCESS_TOKEN_LIFETIME), sessionToken.UserID, sessionToken.SessionID)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, errorhandling.RefreshTokenError, utils.CreateErrorMessage())
 		return
@@ -194,8 +295,344 @@ func (a authController) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	response := response.Tokens{
 		Code:         http.StatusText(http.StatusOK),
 		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		RefreshToken: sessionToken.RefreshToken,
 	}
 	config.LoggerInstance.Info(constant.TOKEN_RESET_SUCCEED)
 	utils.SendSuccessResponse(w, http.StatusOK, response)
 }
+
+// Logout logout the user from current session.
+// @Summary Logout User
+// @Description Logout API is made for revoking the refresh token of current session and access token used for this request.
+// @Accept json
+// @Produce json
+// @Tags auth
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param refreshToken formData string true "Refresh token of current session"
+// @Success 200 {object} response.SuccessResponse "User logout done successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Either access token is expired or it is revoked."
+// @Failure 404 {object} errorhandling.CustomError "Refresh token not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/auth/logout [post]
+func (a authController) Logout(w http.ResponseWriter, r *http.Request) {
+	var refreshTokenRequest request.RefreshToken
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	accessToken := r.Context().Value(constant.TokenClaimsKey).(utils.JWTTokenClaims)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &refreshTokenRequest)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(refreshTokenRequest)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	err = a.authService.Logout(userId, refreshTokenRequest.RefreshToken, accessToken)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.USER_LOGOUT_SUCCEED,
+	}
+	config.LoggerInstance.Info(constant.USER_LOGOUT_SUCCEED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// LogoutAll logout the user from all sessions.
+// @Summary Logout User from All Sessions
+// @Description LogoutAll API is made for revoking every refresh token of the user and all access tokens issued to the user till now.
+// @Produce json
+// @Tags auth
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Success 200 {object} response.SuccessResponse "User logged out from all sessions successfully."
+// @Failure 401 {object} errorhandling.CustomError "Either access token is expired or it is revoked."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/auth/logout-all [post]
+func (a authController) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+
+	err := a.authService.LogoutAll(userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.USER_LOGOUT_ALL_SUCCEED,
+	}
+	config.LoggerInstance.Info(constant.USER_LOGOUT_ALL_SUCCEED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetActiveSessions fetches active login sessions of the user.
+// @Summary Get Active Sessions
+// @Description GetActiveSessions API is made for listing devices where user is logged in, session of the requesting access token is flagged as current.
+// @Produce json
+// @Tags auth
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Success 200 {object} []response.Session "Active sessions fetched successfully."
+// @Failure 401 {object} errorhandling.CustomError "Either access token is expired or it is revoked."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/auth/sessions [get]
+func (a authController) GetActiveSessions(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	accessToken := r.Context().Value(constant.TokenClaimsKey).(utils.JWTTokenClaims)
+
+	sessions, err := a.authService.GetActiveSessions(userId, accessToken.SessionID)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, sessions)
+}
+
+// RevokeSession revokes single login session of the user.
+// @Summary Revoke Session
+// @Description RevokeSession API is made for logging out the user from single device, refresh token of that session stops working and its access tokens are rejected immediately.
+// @Produce json
+// @Tags auth
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param SessionID path int64 true "ID of session which you want to revoke."
+// @Success 200 {object} response.SuccessResponse "Session revoked successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Either access token is expired or it is revoked."
+// @Failure 404 {object} errorhandling.CustomError "No active session found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/auth/sessions/{SessionID} [delete]
+func (a authController) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	sessionId, err := strconv.ParseInt(chi.URLParam(r, constant.SESSION_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	err = a.authService.RevokeSession(userId, sessionId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.SESSION_REVOKED,
+	}
+	config.LoggerInstance.Info(constant.SESSION_REVOKED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// CreatePersonalAccessToken creates new personal access token for the user.
+// @Summary Create Personal Access Token
+// @Description CreatePersonalAccessToken API is made for creating long lived token with limited scopes for scripts and integrations, token is returned only once.
+// @Accept json
+// @Produce json
+// @Tags auth
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param name formData string true "Name of the token"
+// @Param scopes formData []string true "Scopes granted to the token (tasks:read, tasks:write, teams:read, teams:write, users:read, users:write)"
+// @Param expiresAt formData string false "Expiry time of the token, token never expires if it is not provided"
+// @Success 200 {object} response.CreatedPersonalAccessToken "Personal access token created successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Either access token is expired or it is revoked."
+// @Failure 403 {object} errorhandling.CustomError "Personal access token is not allowed."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/auth/tokens [post]
+func (a authController) CreatePersonalAccessToken(w http.ResponseWriter, r *http.Request) {
+	var personalAccessToken request.PersonalAccessToken
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &personalAccessToken)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(personalAccessToken)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	tokenId, token, err := a.authService.CreatePersonalAccessToken(userId, personalAccessToken)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.CreatedPersonalAccessToken{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.PAT_CREATED,
+		ID:      tokenId,
+		Token:   token,
+	}
+	config.LoggerInstance.Info(constant.PAT_CREATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetPersonalAccessTokens fetches personal access tokens of the user.
+// @Summary Get Personal Access Tokens
+// @Description GetPersonalAccessTokens API is made for listing personal access tokens of the user which are not revoked.
+// @Produce json
+// @Tags auth
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Success 200 {object} []response.PersonalAccessToken "Personal access tokens fetched successfully."
+// @Failure 401 {object} errorhandling.CustomError "Either access token is expired or it is revoked."
+// @Failure 403 {object} errorhandling.CustomError "Personal access token is not allowed."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/auth/tokens [get]
+func (a authController) GetPersonalAccessTokens(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+
+	personalAccessTokens, err := a.authService.GetPersonalAccessTokens(userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, personalAccessTokens)
+}
+
+// RevokePersonalAccessToken revokes personal access token of the user.
+// @Summary Revoke Personal Access Token
+// @Description RevokePersonalAccessToken API is made for revoking personal access token, it is rejected immediately after revocation.
+// @Produce json
+// @Tags auth
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param TokenID path int64 true "ID of personal access token which you want to revoke."
+// @Success 200 {object} response.SuccessResponse "Personal access token revoked successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Either access token is expired or it is revoked."
+// @Failure 403 {object} errorhandling.CustomError "Personal access token is not allowed."
+// @Failure 404 {object} errorhandling.CustomError "No personal access token found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/auth/tokens/{TokenID} [delete]
+func (a authController) RevokePersonalAccessToken(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	tokenId, err := strconv.ParseInt(chi.URLParam(r, constant.TOKEN_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	err = a.authService.RevokePersonalAccessToken(userId, tokenId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.PAT_REVOKED,
+	}
+	config.LoggerInstance.Info(constant.PAT_REVOKED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// OIDCLogin starts single sign-on login of the user.
+// @Summary Single Sign-On Login
+// @Description OIDCLogin API is made for redirecting the user to login page of company's identity provider, authorization code flow with PKCE is used.
+// @Tags auth
+// @Success 302 "Redirect to identity provider."
+// @Failure 404 {object} errorhandling.CustomError "Single sign-on login is not configured."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/auth/oidc/login [get]
+func (a authController) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	authorizationURL, err := a.authService.StartOIDCLogin()
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	http.Redirect(w, r, authorizationURL, http.StatusFound)
+}
+
+// OIDCCallback completes single sign-on login of the user.
+// @Summary Single Sign-On Login Callback
+// @Description OIDCCallback API is called by identity provider after login, user is matched with existing account by verified email or new account is created, then same tokens as login are returned.
+// @Produce json
+// @Tags auth
+// @Param code query string true "Authorization code issued by identity provider"
+// @Param state query string true "State of the login"
+// @Success 200 {object} response.UserWithTokens "User login done successfully."
+// @Success 200 {object} response.TwoFactorChallenge "Two factor authentication is required."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Either login state is expired or identity provider rejected the login."
+// @Failure 403 {object} errorhandling.CustomError "Email is not verified by identity provider."
+// @Failure 404 {object} errorhandling.CustomError "Single sign-on login is not configured."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/auth/oidc/callback [get]
+func (a authController) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	var oidcCallback request.OIDCCallback
+
+	decoder := schema.NewDecoder()
+	decoder.IgnoreUnknownKeys(true)
+	err := decoder.Decode(&oidcCallback, r.URL.Query())
+	if err != nil {
+		errorhandling.HandleSchemaDecodeError(r, w, err)
+		return
+	}
+
+	if oidcCallback.Error != constant.EMPTY_STRING {
+		config.LoggerInstance.Warning(oidcCallback.Error + ": " + oidcCallback.ErrorDescription)
+		errorhandling.SendErrorResponse(r, w, errorhandling.OIDCLoginFailed, constant.EMPTY_STRING)
+		return
+	}
+
+	err = utils.Validate.Struct(oidcCallback)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	user, sessionToken, err := a.authService.CompleteOIDCLogin(oidcCallback, utils.GetClientInfo(r))
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	sendLoginResponse(w, r, user, sessionToken)
+}
+
+// GetJWKS returns public keys which are used for verifying tokens.
+// @Summary Get JSON Web Key Set
+// @Description GetJWKS API is made for other services so that they can verify tokens issued by task manager, retired keys stay here until tokens signed by them are expired.
+// @Produce json
+// @Tags auth
+// @Success 200 {object} response.JWKS "JSON web key set fetched successfully."
+// @Router /.well-known/jwks.json [get]
+func (a authController) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	utils.SendSuccessResponse(w, http.StatusOK, utils.CreateJWKS())
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/api/service"
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
+)
+
+type DashboardController interface {
+	GetDashboard(w http.ResponseWriter, r *http.Request)
+	MarkNotificationsRead(w http.ResponseWriter, r *http.Request)
+}
+
+type dashboardController struct {
+	dashboardService service.DashboardService
+}
+
+func NewDashboardController(dashboardService service.DashboardService) DashboardController {
+	return dashboardController{
+		dashboardService: dashboardService,
+	}
+}
+
+// GetDashboard fetches home page of the user.
+// @Summary Get my dashboard
+// @Description Get counts of my open tasks by status, my open tasks due today, due this week and overdue, recently updated tasks which I created or are assigned to me or my teams, my teams with most open tasks and number of unread notifications. Today and week are taken in my timezone and week start.
+// @Produce json
+// @Tags users
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Success 200 {object} response.Dashboard "Dashboard fetched successfully."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/me/dashboard [get]
+func (d dashboardController) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	dashboard, err := d.dashboardService.GetDashboard(organizationId, userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, dashboard)
+}
+
+// MarkNotificationsRead marks notifications of the user as read.
+// @Summary Mark my notifications as read
+// @Description Resets number of unread notifications shown in dashboard, task notifications received over socket are counted as unread.
+// @Produce json
+// @Tags users
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Success 200 {object} response.SuccessResponse "Notifications marked as read successfully."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/me/notifications/read [post]
+func (d dashboardController) MarkNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	err := d.dashboardService.MarkNotificationsRead(userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.NOTIFICATIONS_READ,
+	}
+	config.LoggerInstance.Info(constant.NOTIFICATIONS_READ)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
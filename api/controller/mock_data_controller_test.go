@@ -30,6 +30,7 @@ var authService service.AuthService
 var taskService service.TaskService
 var teamService service.TeamService
 var userService service.UserService
+var adminService service.AdminService
 
 func init() {
 	config.LoadConfig("../../.config/", "../../.config/secret.json")
@@ -42,17 +43,20 @@ func init() {
 	slogLoggerInstance = loggerInstance.(logease.SlogLoggerInstance)
 	r = chi.NewRouter()
 
-	authRepository := repository.NewAuthRepo(dbConn)
+	authRepository := repository.NewAuthRepo(dbConn, redisClient, rabbitmqConn)
 	authService = service.NewAuthService(authRepository)
 
-	taskRepository := repository.NewTaskRepo(dbConn, redisClient, socketServer)
+	taskRepository := repository.NewTaskRepo(dbConn, redisClient, rabbitmqConn, socketServer)
 	taskService = service.NewTaskService(taskRepository)
 
-	teamRepository := repository.NewTeamRepo(dbConn, redisClient)
+	teamRepository := repository.NewTeamRepo(dbConn, redisClient, socketServer)
 	teamService = service.NewTeamService(teamRepository)
 
-	userRepository := repository.NewUserRepo(dbConn, rabbitmqConn)
+	userRepository := repository.NewUserRepo(dbConn, redisClient, rabbitmqConn)
 	userService = service.NewUserService(userRepository)
+
+	adminRepository := repository.NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer)
+	adminService = service.NewAdminService(adminRepository)
 }
 
 func TestMain(m *testing.M) {
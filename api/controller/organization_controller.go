@@ -0,0 +1,255 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/api/service"
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/schema"
+)
+
+type OrganizationController interface {
+	CreateOrganization(w http.ResponseWriter, r *http.Request)
+	GetMyOrganizations(w http.ResponseWriter, r *http.Request)
+	GetOrganizationMembers(w http.ResponseWriter, r *http.Request)
+	AddMemberToOrganization(w http.ResponseWriter, r *http.Request)
+	RemoveMemberFromOrganization(w http.ResponseWriter, r *http.Request)
+}
+
+type organizationController struct {
+	organizationService service.OrganizationService
+}
+
+func NewOrganizationController(organizationService service.OrganizationService) OrganizationController {
+	return organizationController{
+		organizationService: organizationService,
+	}
+}
+
+// CreateOrganization creates a new organization.
+// @Summary Create New Organization
+// @Description CreateOrganization API creates a new organization (workspace), user who creates it becomes it's owner. Teams and tasks are created within the organization sent in X-Organization-ID header.
+// @Accept json
+// @Produce json
+// @Tags organizations
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param name formData string true "Name of the organization (min length: 3, max length: 48)"
+// @Success 200 {object} response.SuccessResponse "Organization created successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/organizations [post]
+func (o organizationController) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var organization request.Organization
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &organization)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(organization)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	organization.CreatedBy = r.Context().Value(constant.UserIdKey).(int64)
+	organizationId, err := o.organizationService.CreateOrganization(organization)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.ORGANIZATION_CREATED,
+		ID:      &organizationId,
+	}
+	config.LoggerInstance.Info(constant.ORGANIZATION_CREATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetMyOrganizations fetches organizations of the user.
+// @Summary Get my organizations
+// @Description Get all organizations which user is member of along with role of the user in each of them.
+// @Produce json
+// @Tags organizations
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Success 200 {object} []response.Organization "Organizations fetched successfully."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/organizations [get]
+func (o organizationController) GetMyOrganizations(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizations, err := o.organizationService.GetMyOrganizations(userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, organizations)
+}
+
+// GetOrganizationMembers fetches members of the organization.
+// @Summary Get organization members
+// @Description Get members of the organization along with their role, email of each member is included only if member has made it visible to the requester.
+// @Produce json
+// @Tags organizations
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param OrganizationID path int64 true "ID of organization whose members you want."
+// @Param Limit query int false "Number of members to return per page (default 10)"
+// @Param Offset query int false "Offset for pagination (default 0)"
+// @Param Search query string false "Search term to filter members"
+// @Success 200 {object} []response.OrganizationMember "Organization members fetched successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 404 {object} errorhandling.CustomError "Organization not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/organizations/{OrganizationID}/members [get]
+func (o organizationController) GetOrganizationMembers(w http.ResponseWriter, r *http.Request) {
+	var userQueryParams request.UserQueryParams
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&userQueryParams, r.URL.Query())
+	if err != nil {
+		errorhandling.HandleSchemaDecodeError(r, w, err)
+		return
+	}
+
+	err = utils.Validate.Struct(userQueryParams)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	if userQueryParams.Limit == 0 {
+		userQueryParams.Limit = 10
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	members, err := o.organizationService.GetOrganizationMembers(userId, organizationId, userQueryParams)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, members)
+}
+
+// AddMemberToOrganization adds user to the organization.
+// @Summary Add member to organization
+// @Description Owners and admins of organization can add user having given email to it with given role, only owners can add other owners.
+// @Accept json
+// @Produce json
+// @Tags organizations
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param OrganizationID path int64 true "ID of organization to which user is to be added."
+// @Param email formData string true "Email of the user"
+// @Param role formData string false "Role of the user (OWNER, ADMIN or MEMBER), defaults to MEMBER"
+// @Success 200 {object} response.SuccessResponse "Member added to organization."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only owners and admins are allowed."
+// @Failure 404 {object} errorhandling.CustomError "Either organization or user not found."
+// @Failure 409 {object} errorhandling.CustomError "User is already member of organization."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/organizations/{OrganizationID}/members [post]
+func (o organizationController) AddMemberToOrganization(w http.ResponseWriter, r *http.Request) {
+	var memberToAdd request.OrganizationMember
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &memberToAdd)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(memberToAdd)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	memberToAdd.OrganizationID = r.Context().Value(constant.OrganizationIdKey).(int64)
+	memberId, err := o.organizationService.AddMemberToOrganization(userId, memberToAdd)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.ORGANIZATION_MEMBER_ADDED,
+		ID:      &memberId,
+	}
+	config.LoggerInstance.Info(constant.ORGANIZATION_MEMBER_ADDED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// RemoveMemberFromOrganization removes member from the organization.
+// @Summary Remove member from organization
+// @Description Removes member from the organization and all of it's teams. Members can remove themselves, others can be removed by owners and admins, owners can be removed only by owners. Last owner and member who owns teams or projects of the organization can't be removed.
+// @Produce json
+// @Tags organizations
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param OrganizationID path int64 true "ID of organization from which member is to be removed."
+// @Param UserID path int64 true "ID of member who is to be removed."
+// @Success 200 {object} response.SuccessResponse "Member removed from organization."
+// @Failure 400 {object} errorhandling.CustomError "Bad request, either user is not member, is last owner or owns teams or projects."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only owners and admins are allowed."
+// @Failure 404 {object} errorhandling.CustomError "Organization not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/organizations/{OrganizationID}/members/{UserID} [delete]
+func (o organizationController) RemoveMemberFromOrganization(w http.ResponseWriter, r *http.Request) {
+	memberId, err := strconv.ParseInt(chi.URLParam(r, constant.USER_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err = o.organizationService.RemoveMemberFromOrganization(userId, organizationId, memberId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.REMOVED_FROM_ORGANIZATION,
+	}
+	config.LoggerInstance.Info(constant.REMOVED_FROM_ORGANIZATION)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
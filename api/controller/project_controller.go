@@ -0,0 +1,597 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/api/service"
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/schema"
+)
+
+type ProjectController interface {
+	CreateProject(w http.ResponseWriter, r *http.Request)
+	GetAllProjects(w http.ResponseWriter, r *http.Request)
+	GetProject(w http.ResponseWriter, r *http.Request)
+	UpdateProject(w http.ResponseWriter, r *http.Request)
+	DeleteProject(w http.ResponseWriter, r *http.Request)
+	GetTasksOfProject(w http.ResponseWriter, r *http.Request)
+	CreateMilestone(w http.ResponseWriter, r *http.Request)
+	GetMilestonesOfProject(w http.ResponseWriter, r *http.Request)
+	UpdateMilestone(w http.ResponseWriter, r *http.Request)
+	DeleteMilestone(w http.ResponseWriter, r *http.Request)
+}
+
+type projectController struct {
+	projectService service.ProjectService
+}
+
+func NewProjectController(projectService service.ProjectService) ProjectController {
+	return projectController{
+		projectService: projectService,
+	}
+}
+
+// CreateProject creates a new project.
+// @Summary Create New Project
+// @Description CreateProject API creates a new project in the organization, user who creates it becomes it's owner. Given members and teams of the organization can access the project and add tasks to it.
+// @Accept json
+// @Produce json
+// @Tags projects
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param name formData string true "Name of the project (min length: 3, max length: 48)"
+// @Param description formData string false "Description of the project (max length: 1000)"
+// @Param status formData string false "Status of the project (PLANNED, ACTIVE, ON-HOLD, COMPLETED or ARCHIVED), defaults to ACTIVE"
+// @Param memberIds formData []int64 false "IDs of members who can access the project"
+// @Param teamIds formData []int64 false "IDs of teams whose members can access the project"
+// @Success 200 {object} response.SuccessResponse "Project created successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 404 {object} errorhandling.CustomError "Team not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/projects [post]
+func (p projectController) CreateProject(w http.ResponseWriter, r *http.Request) {
+	var project request.Project
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &project)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(project)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	project.OwnerID = r.Context().Value(constant.UserIdKey).(int64)
+	project.OrganizationID = r.Context().Value(constant.OrganizationIdKey).(int64)
+	projectId, err := p.projectService.CreateProject(project)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.PROJECT_CREATED,
+		ID:      &projectId,
+	}
+	config.LoggerInstance.Info(constant.PROJECT_CREATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetAllProjects fetches projects which user can access.
+// @Summary Get all projects
+// @Description Get projects of the organization which user owns, is member of or whose teams user is member of.
+// @Produce json
+// @Tags projects
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param limit query int false "Number of projects to return per page (default 10)"
+// @Param offset query int false "Offset for pagination (default 0)"
+// @Param search query string false "Search term to filter projects by name"
+// @Param status query string false "Filter projects by status (PLANNED, ACTIVE, ON-HOLD, COMPLETED, ARCHIVED)"
+// @Success 200 {object} []response.Project "Projects fetched successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/projects [get]
+func (p projectController) GetAllProjects(w http.ResponseWriter, r *http.Request) {
+	var projectQueryParams request.ProjectQueryParams
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&projectQueryParams, r.URL.Query())
+	if err != nil {
+		errorhandling.HandleSchemaDecodeError(r, w, err)
+		return
+	}
+
+	err = utils.Validate.Struct(projectQueryParams)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	if projectQueryParams.Limit == 0 {
+		projectQueryParams.Limit = 10
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	projects, err := p.projectService.GetAllProjects(organizationId, userId, projectQueryParams)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, projects)
+}
+
+// GetProject fetches details of the project.
+// @Summary Get project
+// @Description Get details of the project along with members and teams who can access it.
+// @Produce json
+// @Tags projects
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param ProjectID path int64 true "Project ID"
+// @Success 200 {object} response.Project "Project fetched successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Not allowed to access project."
+// @Failure 404 {object} errorhandling.CustomError "Project not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/projects/{ProjectID} [get]
+func (p projectController) GetProject(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.ParseInt(chi.URLParam(r, constant.PROJECT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	project, err := p.projectService.GetProject(organizationId, userId, projectId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, project)
+}
+
+// UpdateProject updates the project.
+// @Summary Update project
+// @Description UpdateProject API updates fields of the project which are sent, members and teams who can access the project are replaced when their lists are sent. Only owner of the project can update it.
+// @Accept json
+// @Produce json
+// @Tags projects
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param ProjectID path int64 true "Project ID"
+// @Param name formData string false "Name of the project (min length: 3, max length: 48)"
+// @Param description formData string false "Description of the project (max length: 1000)"
+// @Param status formData string false "Status of the project (PLANNED, ACTIVE, ON-HOLD, COMPLETED or ARCHIVED)"
+// @Param memberIds formData []int64 false "IDs of members who can access the project"
+// @Param teamIds formData []int64 false "IDs of teams whose members can access the project"
+// @Success 200 {object} response.SuccessResponse "Project updated successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only owner of project is allowed."
+// @Failure 404 {object} errorhandling.CustomError "Either project or team not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/projects/{ProjectID} [put]
+func (p projectController) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	var projectToUpdate request.UpdateProject
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &projectToUpdate)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	projectId, err := strconv.ParseInt(chi.URLParam(r, constant.PROJECT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	projectToUpdate.ID = projectId
+
+	err = utils.Validate.Struct(projectToUpdate)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	projectToUpdate.UpdatedAt = new(time.Time)
+	*projectToUpdate.UpdatedAt = time.Now().UTC()
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err = p.projectService.UpdateProject(organizationId, userId, projectToUpdate)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.PROJECT_UPDATED,
+	}
+	config.LoggerInstance.Info(constant.PROJECT_UPDATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// DeleteProject deletes the project.
+// @Summary Delete project
+// @Description DeleteProject API deletes the project along with it's milestones, tasks of the project are kept without project and milestone. Only owner of the project can delete it.
+// @Produce json
+// @Tags projects
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param ProjectID path int64 true "Project ID"
+// @Success 200 {object} response.SuccessResponse "Project deleted successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only owner of project is allowed."
+// @Failure 404 {object} errorhandling.CustomError "Project not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/projects/{ProjectID} [delete]
+func (p projectController) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.ParseInt(chi.URLParam(r, constant.PROJECT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err = p.projectService.DeleteProject(organizationId, userId, projectId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.PROJECT_DELETED,
+	}
+	config.LoggerInstance.Info(constant.PROJECT_DELETED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetTasksOfProject fetches tasks of the project.
+// @Summary Get all tasks of a project
+// @Description Get tasks of the project across all teams based on query parameters, anyone who can access the project can see them.
+// @Produce json
+// @Tags projects
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param ProjectID path int64 true "Project ID"
+// @Param limit query int false "Number of tasks to return per page (default 10)"
+// @Param offset query int false "Offset for pagination (default 0)"
+// @Param search query string false "Search term to filter tasks"
+// @Param status query string false "Filter tasks by status (TO-DO, In-PROGRESS, COMPLETED, CLOSED)"
+// @Param sortByFilter query bool false "Sort tasks by create time (true for ascending, false for descending)"
+// @Success 200 {object} []response.Task "Tasks fetched successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Not allowed to access project."
+// @Failure 404 {object} errorhandling.CustomError "Project not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/projects/{ProjectID}/tasks [get]
+func (p projectController) GetTasksOfProject(w http.ResponseWriter, r *http.Request) {
+	var taskQueryParams request.TaskQueryParams
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&taskQueryParams, r.URL.Query())
+	if err != nil {
+		errorhandling.HandleSchemaDecodeError(r, w, err)
+		return
+	}
+
+	err = utils.Validate.Struct(taskQueryParams)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	if taskQueryParams.Limit == 0 {
+		taskQueryParams.Limit = 10
+	}
+
+	projectId, err := strconv.ParseInt(chi.URLParam(r, constant.PROJECT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	tasks, err := p.projectService.GetTasksOfProject(organizationId, userId, projectId, taskQueryParams)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, tasks)
+}
+
+// CreateMilestone creates a milestone in the project.
+// @Summary Create milestone
+// @Description CreateMilestone API creates a milestone with target date in the project, only owner of the project can create it.
+// @Accept json
+// @Produce json
+// @Tags projects
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param ProjectID path int64 true "Project ID"
+// @Param name formData string true "Name of the milestone (min length: 3, max length: 48)"
+// @Param description formData string false "Description of the milestone (max length: 1000)"
+// @Param targetDate formData string true "Target date of the milestone"
+// @Success 200 {object} response.SuccessResponse "Milestone created successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only owner of project is allowed."
+// @Failure 404 {object} errorhandling.CustomError "Project not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/projects/{ProjectID}/milestones [post]
+func (p projectController) CreateMilestone(w http.ResponseWriter, r *http.Request) {
+	var milestone request.Milestone
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &milestone)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	projectId, err := strconv.ParseInt(chi.URLParam(r, constant.PROJECT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	milestone.ProjectID = projectId
+
+	err = utils.Validate.Struct(milestone)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	milestoneId, err := p.projectService.CreateMilestone(organizationId, userId, milestone)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.MILESTONE_CREATED,
+		ID:      &milestoneId,
+	}
+	config.LoggerInstance.Info(constant.MILESTONE_CREATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetMilestonesOfProject fetches milestones of the project along with their progress.
+// @Summary Get milestones of a project
+// @Description Get milestones of the project in order of target date with number of total and completed tasks, milestone is at risk when any of it's open tasks is due after target date.
+// @Produce json
+// @Tags projects
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param ProjectID path int64 true "Project ID"
+// @Success 200 {object} []response.Milestone "Milestones fetched successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Not allowed to access project."
+// @Failure 404 {object} errorhandling.CustomError "Project not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/projects/{ProjectID}/milestones [get]
+func (p projectController) GetMilestonesOfProject(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.ParseInt(chi.URLParam(r, constant.PROJECT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	milestones, err := p.projectService.GetMilestonesOfProject(organizationId, userId, projectId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, milestones)
+}
+
+// UpdateMilestone updates milestone of the project.
+// @Summary Update milestone
+// @Description UpdateMilestone API updates fields of the milestone which are sent, only owner of the project can update it.
+// @Accept json
+// @Produce json
+// @Tags projects
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param ProjectID path int64 true "Project ID"
+// @Param MilestoneID path int64 true "Milestone ID"
+// @Param name formData string false "Name of the milestone (min length: 3, max length: 48)"
+// @Param description formData string false "Description of the milestone (max length: 1000)"
+// @Param targetDate formData string false "Target date of the milestone"
+// @Success 200 {object} response.SuccessResponse "Milestone updated successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only owner of project is allowed."
+// @Failure 404 {object} errorhandling.CustomError "Either project or milestone not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/projects/{ProjectID}/milestones/{MilestoneID} [put]
+func (p projectController) UpdateMilestone(w http.ResponseWriter, r *http.Request) {
+	var milestoneToUpdate request.UpdateMilestone
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &milestoneToUpdate)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	projectId, err := strconv.ParseInt(chi.URLParam(r, constant.PROJECT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	milestoneId, err := strconv.ParseInt(chi.URLParam(r, constant.MILESTONE_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	milestoneToUpdate.ProjectID = projectId
+	milestoneToUpdate.ID = milestoneId
+
+	err = utils.Validate.Struct(milestoneToUpdate)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err = p.projectService.UpdateMilestone(organizationId, userId, milestoneToUpdate)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.MILESTONE_UPDATED,
+	}
+	config.LoggerInstance.Info(constant.MILESTONE_UPDATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// DeleteMilestone deletes milestone of the project.
+// @Summary Delete milestone
+// @Description DeleteMilestone API deletes milestone of the project, it's tasks stay in the project without milestone. Only owner of the project can delete it.
+// @Produce json
+// @Tags projects
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param ProjectID path int64 true "Project ID"
+// @Param MilestoneID path int64 true "Milestone ID"
+// @Success 200 {object} response.SuccessResponse "Milestone deleted successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only owner of project is allowed."
+// @Failure 404 {object} errorhandling.CustomError "Either project or milestone not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/projects/{ProjectID}/milestones/{MilestoneID} [delete]
+func (p projectController) DeleteMilestone(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.ParseInt(chi.URLParam(r, constant.PROJECT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	milestoneId, err := strconv.ParseInt(chi.URLParam(r, constant.MILESTONE_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err = p.projectService.DeleteMilestone(organizationId, userId, projectId, milestoneId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.MILESTONE_DELETED,
+	}
+	config.LoggerInstance.Info(constant.MILESTONE_DELETED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
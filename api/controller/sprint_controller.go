@@ -0,0 +1,633 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/api/service"
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/schema"
+)
+
+type SprintController interface {
+	CreateSprint(w http.ResponseWriter, r *http.Request)
+	GetSprintsOfTeam(w http.ResponseWriter, r *http.Request)
+	UpdateSprint(w http.ResponseWriter, r *http.Request)
+	AddTasksToSprint(w http.ResponseWriter, r *http.Request)
+	RemoveTasksFromSprint(w http.ResponseWriter, r *http.Request)
+	GetTasksOfSprint(w http.ResponseWriter, r *http.Request)
+	CompleteSprint(w http.ResponseWriter, r *http.Request)
+	GetSprintBurndown(w http.ResponseWriter, r *http.Request)
+	GetSprintVelocity(w http.ResponseWriter, r *http.Request)
+}
+
+type sprintController struct {
+	sprintService service.SprintService
+}
+
+func NewSprintController(sprintService service.SprintService) SprintController {
+	return sprintController{
+		sprintService: sprintService,
+	}
+}
+
+// CreateSprint creates a sprint of the team.
+// @Summary Create sprint
+// @Description CreateSprint API creates a sprint of the team in PLANNED status, only owner of the team can create it.
+// @Accept json
+// @Produce json
+// @Tags sprints
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param TeamID path int64 true "Team ID"
+// @Param name formData string true "Name of the sprint (min length: 3, max length: 48)"
+// @Param goal formData string false "Goal of the sprint (max length: 1000)"
+// @Param startDate formData string true "Start date of the sprint"
+// @Param endDate formData string true "End date of the sprint, must be after start date"
+// @Success 200 {object} response.SuccessResponse "Sprint created successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only owner of team is allowed."
+// @Failure 404 {object} errorhandling.CustomError "Team not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/teams/{TeamID}/sprints [post]
+func (s sprintController) CreateSprint(w http.ResponseWriter, r *http.Request) {
+	var sprint request.Sprint
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &sprint)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	sprint.TeamID = teamId
+
+	err = utils.Validate.Struct(sprint)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	sprint.CreatedBy = r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	sprintId, err := s.sprintService.CreateSprint(organizationId, sprint)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.SPRINT_CREATED,
+		ID:      &sprintId,
+	}
+	config.LoggerInstance.Info(constant.SPRINT_CREATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetSprintsOfTeam fetches sprints of the team.
+// @Summary Get sprints of a team
+// @Description Get sprints of the team from latest to oldest start date along with number of planned and completed tasks, only members of the team can see them.
+// @Produce json
+// @Tags sprints
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param TeamID path int64 true "Team ID"
+// @Param limit query int false "Number of sprints to return per page (default 10)"
+// @Param offset query int false "Offset for pagination (default 0)"
+// @Param status query string false "Filter sprints by status (PLANNED, ACTIVE, COMPLETED)"
+// @Success 200 {object} []response.Sprint "Sprints fetched successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only members of team are allowed."
+// @Failure 404 {object} errorhandling.CustomError "Team not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/teams/{TeamID}/sprints [get]
+func (s sprintController) GetSprintsOfTeam(w http.ResponseWriter, r *http.Request) {
+	var sprintQueryParams request.SprintQueryParams
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&sprintQueryParams, r.URL.Query())
+	if err != nil {
+		errorhandling.HandleSchemaDecodeError(r, w, err)
+		return
+	}
+
+	err = utils.Validate.Struct(sprintQueryParams)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	if sprintQueryParams.Limit == 0 {
+		sprintQueryParams.Limit = 10
+	}
+
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	sprints, err := s.sprintService.GetSprintsOfTeam(organizationId, userId, teamId, sprintQueryParams)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, sprints)
+}
+
+// UpdateSprint updates sprint of the team.
+// @Summary Update sprint
+// @Description UpdateSprint API updates fields of the sprint which are sent, status ACTIVE starts the sprint. Completed sprint can't be updated and team can have only one active sprint. Only owner of the team can update it.
+// @Accept json
+// @Produce json
+// @Tags sprints
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param TeamID path int64 true "Team ID"
+// @Param SprintID path int64 true "Sprint ID"
+// @Param name formData string false "Name of the sprint (min length: 3, max length: 48)"
+// @Param goal formData string false "Goal of the sprint (max length: 1000)"
+// @Param startDate formData string false "Start date of the sprint"
+// @Param endDate formData string false "End date of the sprint"
+// @Param status formData string false "Status of the sprint (PLANNED or ACTIVE)"
+// @Success 200 {object} response.SuccessResponse "Sprint updated successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only owner of team is allowed."
+// @Failure 404 {object} errorhandling.CustomError "Either team or sprint not found."
+// @Failure 409 {object} errorhandling.CustomError "Team already has an active sprint."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/teams/{TeamID}/sprints/{SprintID} [put]
+func (s sprintController) UpdateSprint(w http.ResponseWriter, r *http.Request) {
+	var sprintToUpdate request.UpdateSprint
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &sprintToUpdate)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	sprintId, err := strconv.ParseInt(chi.URLParam(r, constant.SPRINT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	sprintToUpdate.TeamID = teamId
+	sprintToUpdate.ID = sprintId
+
+	err = utils.Validate.Struct(sprintToUpdate)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err = s.sprintService.UpdateSprint(organizationId, userId, sprintToUpdate)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.SPRINT_UPDATED,
+	}
+	config.LoggerInstance.Info(constant.SPRINT_UPDATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// AddTasksToSprint plans tasks into sprint of the team.
+// @Summary Plan tasks into sprint
+// @Description AddTasksToSprint API plans open tasks assigned to the team into it's sprint, tasks are taken out of other sprints of the team which are not completed yet. Any member of the team can plan tasks.
+// @Accept json
+// @Produce json
+// @Tags sprints
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param TeamID path int64 true "Team ID"
+// @Param SprintID path int64 true "Sprint ID"
+// @Param taskIds formData []int64 true "IDs of tasks to plan into sprint"
+// @Success 200 {object} response.SuccessResponse "Tasks planned into sprint successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request, either sprint is completed or task is not an open task of the team."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only members of team are allowed."
+// @Failure 404 {object} errorhandling.CustomError "Either team or sprint not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/teams/{TeamID}/sprints/{SprintID}/tasks [post]
+func (s sprintController) AddTasksToSprint(w http.ResponseWriter, r *http.Request) {
+	sprintTasks, ok := decodeSprintTasks(w, r)
+	if !ok {
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err := s.sprintService.AddTasksToSprint(organizationId, userId, sprintTasks)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.SPRINT_TASKS_ADDED,
+	}
+	config.LoggerInstance.Info(constant.SPRINT_TASKS_ADDED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// RemoveTasksFromSprint moves tasks of the sprint back to backlog.
+// @Summary Move tasks to backlog
+// @Description RemoveTasksFromSprint API moves tasks of the sprint back to backlog of the team, any member of the team can do it.
+// @Accept json
+// @Produce json
+// @Tags sprints
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param TeamID path int64 true "Team ID"
+// @Param SprintID path int64 true "Sprint ID"
+// @Param taskIds formData []int64 true "IDs of tasks to move to backlog"
+// @Success 200 {object} response.SuccessResponse "Tasks moved to backlog successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only members of team are allowed."
+// @Failure 404 {object} errorhandling.CustomError "Either team, sprint or task not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/teams/{TeamID}/sprints/{SprintID}/tasks [delete]
+func (s sprintController) RemoveTasksFromSprint(w http.ResponseWriter, r *http.Request) {
+	sprintTasks, ok := decodeSprintTasks(w, r)
+	if !ok {
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err := s.sprintService.RemoveTasksFromSprint(organizationId, userId, sprintTasks)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.SPRINT_TASKS_REMOVED,
+	}
+	config.LoggerInstance.Info(constant.SPRINT_TASKS_REMOVED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// decodeSprintTasks reads task ids from body and team and sprint ids from url, error response is sent if any of them is invalid.
+func decodeSprintTasks(w http.ResponseWriter, r *http.Request) (request.SprintTasks, bool) {
+	var sprintTasks request.SprintTasks
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return sprintTasks, false
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &sprintTasks)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return sprintTasks, false
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return sprintTasks, false
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return sprintTasks, false
+	}
+	sprintId, err := strconv.ParseInt(chi.URLParam(r, constant.SPRINT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return sprintTasks, false
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return sprintTasks, false
+	}
+	sprintTasks.TeamID = teamId
+	sprintTasks.SprintID = sprintId
+
+	err = utils.Validate.Struct(sprintTasks)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return sprintTasks, false
+	}
+	return sprintTasks, true
+}
+
+// GetTasksOfSprint fetches tasks of the sprint.
+// @Summary Get tasks of a sprint
+// @Description Get tasks which are currently in the sprint based on query parameters, only members of the team can see them.
+// @Produce json
+// @Tags sprints
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param TeamID path int64 true "Team ID"
+// @Param SprintID path int64 true "Sprint ID"
+// @Param limit query int false "Number of tasks to return per page (default 10)"
+// @Param offset query int false "Offset for pagination (default 0)"
+// @Param search query string false "Search term to filter tasks"
+// @Param status query string false "Filter tasks by status (TO-DO, In-PROGRESS, COMPLETED, CLOSED)"
+// @Param sortByFilter query bool false "Sort tasks by create time (true for ascending, false for descending)"
+// @Success 200 {object} []response.Task "Tasks fetched successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only members of team are allowed."
+// @Failure 404 {object} errorhandling.CustomError "Either team or sprint not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/teams/{TeamID}/sprints/{SprintID}/tasks [get]
+func (s sprintController) GetTasksOfSprint(w http.ResponseWriter, r *http.Request) {
+	var taskQueryParams request.TaskQueryParams
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&taskQueryParams, r.URL.Query())
+	if err != nil {
+		errorhandling.HandleSchemaDecodeError(r, w, err)
+		return
+	}
+
+	err = utils.Validate.Struct(taskQueryParams)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	if taskQueryParams.Limit == 0 {
+		taskQueryParams.Limit = 10
+	}
+
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	sprintId, err := strconv.ParseInt(chi.URLParam(r, constant.SPRINT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	tasks, err := s.sprintService.GetTasksOfSprint(organizationId, userId, teamId, sprintId, taskQueryParams)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, tasks)
+}
+
+// CompleteSprint completes sprint of the team.
+// @Summary Complete sprint
+// @Description CompleteSprint API completes the sprint and moves it's unfinished tasks to given next sprint of the team, or to backlog if next sprint is not sent. Only owner of the team can complete it.
+// @Accept json
+// @Produce json
+// @Tags sprints
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param TeamID path int64 true "Team ID"
+// @Param SprintID path int64 true "Sprint ID"
+// @Param nextSprintId formData int64 false "ID of sprint to which unfinished tasks are moved"
+// @Success 200 {object} response.SuccessResponse "Sprint completed successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request, either sprint or next sprint is already completed."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only owner of team is allowed."
+// @Failure 404 {object} errorhandling.CustomError "Either team or sprint not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/teams/{TeamID}/sprints/{SprintID}/complete [post]
+func (s sprintController) CompleteSprint(w http.ResponseWriter, r *http.Request) {
+	var sprintToComplete request.CompleteSprint
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	// body is optional, unfinished tasks are moved to backlog without it.
+	if len(body) != 0 {
+		err = json.Unmarshal(body, &sprintToComplete)
+		if err != nil {
+			errorhandling.HandleJSONUnmarshlError(r, w, err)
+			return
+		}
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	sprintId, err := strconv.ParseInt(chi.URLParam(r, constant.SPRINT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	sprintToComplete.TeamID = teamId
+	sprintToComplete.SprintID = sprintId
+
+	err = utils.Validate.Struct(sprintToComplete)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err = s.sprintService.CompleteSprint(organizationId, userId, sprintToComplete)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.SPRINT_COMPLETED,
+	}
+	config.LoggerInstance.Info(constant.SPRINT_COMPLETED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetSprintBurndown fetches burndown of the sprint.
+// @Summary Get sprint burndown
+// @Description Get number of total, remaining and completed tasks of the sprint at the end of each day of the sprint up to today, reconstructed from status history of it's tasks, along with ideal remaining tasks. Only members of the team can see it.
+// @Produce json
+// @Tags sprints
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param TeamID path int64 true "Team ID"
+// @Param SprintID path int64 true "Sprint ID"
+// @Success 200 {object} response.SprintBurndown "Burndown fetched successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only members of team are allowed."
+// @Failure 404 {object} errorhandling.CustomError "Either team or sprint not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/teams/{TeamID}/sprints/{SprintID}/burndown [get]
+func (s sprintController) GetSprintBurndown(w http.ResponseWriter, r *http.Request) {
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	sprintId, err := strconv.ParseInt(chi.URLParam(r, constant.SPRINT_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	burndown, err := s.sprintService.GetSprintBurndown(organizationId, userId, teamId, sprintId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, burndown)
+}
+
+// GetSprintVelocity fetches velocity of the team.
+// @Summary Get sprint velocity
+// @Description Get planned and completed tasks of last completed sprints of the team along with average number of tasks completed per sprint, only members of the team can see it.
+// @Produce json
+// @Tags sprints
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param TeamID path int64 true "Team ID"
+// @Param limit query int false "Number of last completed sprints to consider (default 5)"
+// @Success 200 {object} response.SprintVelocity "Velocity fetched successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only members of team are allowed."
+// @Failure 404 {object} errorhandling.CustomError "Team not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/teams/{TeamID}/sprints/velocity [get]
+func (s sprintController) GetSprintVelocity(w http.ResponseWriter, r *http.Request) {
+	var velocityQueryParams request.SprintVelocityQueryParams
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&velocityQueryParams, r.URL.Query())
+	if err != nil {
+		errorhandling.HandleSchemaDecodeError(r, w, err)
+		return
+	}
+
+	err = utils.Validate.Struct(velocityQueryParams)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	if velocityQueryParams.Limit == 0 {
+		velocityQueryParams.Limit = constant.SPRINT_VELOCITY_DEFAULT_LIMIT
+	}
+
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	velocity, err := s.sprintService.GetSprintVelocity(organizationId, userId, teamId, velocityQueryParams)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, velocity)
+}
@@ -25,6 +25,7 @@ type TaskController interface {
 	GetAllTasks(w http.ResponseWriter, r *http.Request)
 	GetTasksofTeam(w http.ResponseWriter, r *http.Request)
 	UpdateTask(w http.ResponseWriter, r *http.Request)
+	AddCommentToTask(w http.ResponseWriter, r *http.Request)
 }
 
 type taskController struct {
@@ -39,11 +40,12 @@ func NewTaskController(taskService service.TaskService) TaskController {
 
 // CreateTask creates a new task.
 // @Summary Create New Task
-// @Description CreateTask API is made for creating a new task in the task manager application.
+// @Description CreateTask API is made for creating a new task in the task manager application. If assignee team has an auto assign strategy, one of it's available members is recorded as owner of the task.
 // @Accept json
 // @Produce json
 // @Tags tasks
 // @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
 // @Param title formData string true "Title of the task (min length: 4, max length: 48)"
 // @Param description formData string true "Description of the task (min length: 12, max length: 196)"
 // @Param deadline formData time true "Deadline to Complete the task"
@@ -52,7 +54,7 @@ func NewTaskController(taskService service.TaskService) TaskController {
 // @Param status formData string true "Status of the task (TO-DO, In-PROGRESS, COMPLETED, CLOSED)"
 // @Param priority formData string true "Priority of the task (LOW, MEDIUM, HIGH, VERY HIGH)"
 // @Success 200 {object} response.SuccessResponse "Task created successfully."
-// @Failure 400 {object} errorhandling.CustomError "Bad request, either data is not valid or assignee privacy is Private."
+// @Failure 400 {object} errorhandling.CustomError "Bad request, either data is not valid or assignee does not allow requester to assign tasks."
 // @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
 // @Failure 500 {object} errorhandling.CustomError "Internal server error."
 // @Router /api/v1/tasks [post]
@@ -87,6 +89,7 @@ func (t taskController) CreateTask(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value(constant.UserIdKey).(int64)
 	taskToCreate.CreatedAt = time.Now().UTC()
 	taskToCreate.CreatedBy = userId
+	taskToCreate.OrganizationID = r.Context().Value(constant.OrganizationIdKey).(int64)
 	taskId, err := t.taskService.CreateTask(taskToCreate)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
@@ -108,6 +111,7 @@ func (t taskController) CreateTask(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Tags tasks
 // @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
 // @Param createdByMe query bool true "return tasks created by you if createdByMe set to true otherwise false."
 // @Param limit query int false "Number of tasks to return per page (default 10)"
 // @Param offset query int false "Offset for pagination (default 0)"
@@ -140,7 +144,8 @@ func (t taskController) GetAllTasks(w http.ResponseWriter, r *http.Request) {
 	}
 
 	userId := r.Context().Value(constant.UserIdKey).(int64)
-	tasks, err := t.taskService.GetAllTasks(userId, taskQueryParams)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	tasks, err := t.taskService.GetAllTasks(organizationId, userId, taskQueryParams)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
@@ -153,6 +158,7 @@ func (t taskController) GetAllTasks(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Tags tasks
 // @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
 // @Param TeamID path int64 true "Team ID"
 // @Param limit query int false "Number of tasks to return per page (default 10)"
 // @Param offset query int false "Offset for pagination (default 0)"
@@ -195,7 +201,8 @@ func (t taskController) GetTasksofTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tasks, err := t.taskService.GetTasksofTeam(teamId, taskQueryParams)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	tasks, err := t.taskService.GetTasksofTeam(organizationId, teamId, taskQueryParams)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
@@ -211,6 +218,7 @@ func (t taskController) GetTasksofTeam(w http.ResponseWriter, r *http.Request) {
 // @Tags tasks
 // @Param TaskID path int64 true "Task ID"
 // @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
 // @Param title formData string false "Title of the task (min length: 4, max length: 48)"
 // @Param description formData string false "Description of the task (min length: 12, max length: 196)"
 // @Param assigneeIndividual formData int64 false "ID of the individual assignee"
@@ -264,7 +272,8 @@ func (t taskController) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	taskToUpdate.UpdatedAt = new(time.Time)
 	*taskToUpdate.UpdatedAt = time.Now().UTC()
 
-	err = t.taskService.UpdateTask(taskToUpdate)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err = t.taskService.UpdateTask(organizationId, taskToUpdate)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
@@ -277,3 +286,75 @@ func (t taskController) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	config.LoggerInstance.Info(constant.TASK_UPDATED)
 	utils.SendSuccessResponse(w, http.StatusOK, response)
 }
+
+// AddCommentToTask adds a comment to the task.
+// @Summary Add comment to a task
+// @Description AddCommentToTask API is made for adding a comment to the task by it's creator or assignee.
+// @Accept json
+// @Produce json
+// @Tags tasks
+// @Param TaskID path int64 true "Task ID"
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param comment formData string true "Comment on the task (max length: 1000)"
+// @Success 200 {object} response.SuccessResponse "Comment added successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Not allowed to comment on task"
+// @Failure 404 {object} errorhandling.CustomError "Task not found"
+// @Failure 500 {object} errorhandling.CustomError "Internal server error"
+// @Router /api/v1/tasks/{TaskID}/comments [post]
+func (t taskController) AddCommentToTask(w http.ResponseWriter, r *http.Request) {
+	var commentToAdd request.TaskComment
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &commentToAdd)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	taskId, err := strconv.ParseInt(chi.URLParam(r, constant.TASK_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	commentToAdd.TaskID = taskId
+
+	err = utils.Validate.Struct(commentToAdd)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	commentToAdd.CreatedBy = userId
+	commentToAdd.CreatedAt = time.Now().UTC()
+
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	commentId, err := t.taskService.AddCommentToTask(organizationId, commentToAdd)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.TASK_COMMENT_ADDED,
+		ID:      &commentId,
+	}
+	config.LoggerInstance.Info(constant.TASK_COMMENT_ADDED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
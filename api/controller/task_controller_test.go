@@ -109,6 +109,7 @@ func TestCreateTask(t *testing.T) {
 			}
 			req.Header.Set("Content-Type", "application/json")
 			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.CreatedBy)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
 			req = req.WithContext(ctx)
 
 			w := httptest.NewRecorder()
@@ -178,6 +179,7 @@ func TestGetAllTasks(t *testing.T) {
 			}
 
 			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserId)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
 			req = req.WithContext(ctx)
 
 			q := req.URL.Query()
@@ -359,6 +361,70 @@ func TestUpdateTask(t *testing.T) {
 			rctx.URLParams.Add("TaskID", strconv.Itoa(int(v.ID)))
 			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
 			ctx = context.WithValue(ctx, constant.UserIdKey, v.CreatedBy)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestAddCommentToTask(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		TaskID       int64
+		Comment      string
+		CreatedBy    int64
+		Expected     interface{}
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Comment Added Successfully",
+			TaskID:       954511608047501313,
+			Comment:      "Started working on this task.",
+			CreatedBy:    954488202459119617,
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Field Must be Required",
+			TaskID:       954511608047501313,
+			CreatedBy:    954488202459119617,
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "No Task Found",
+			TaskID:       1,
+			Comment:      "Started working on this task.",
+			CreatedBy:    954488202459119617,
+			StatusCode:   404,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Post("/api/v1/tasks/:TaskID/comments", NewTaskController(taskService).AddCommentToTask)
+
+			comment := request.TaskComment{
+				Comment: v.Comment,
+			}
+			jsonValue, err := json.Marshal(comment)
+			if err != nil {
+				log.Println(err)
+			}
+			req, err := http.NewRequest("POST", "/api/v1/tasks/:TaskID/comments", bytes.NewBuffer(jsonValue))
+			if err != nil {
+				log.Println(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("TaskID", strconv.FormatInt(v.TaskID, 10))
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, constant.UserIdKey, v.CreatedBy)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
 			req = req.WithContext(ctx)
 
 			w := httptest.NewRecorder()
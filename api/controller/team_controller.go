@@ -26,6 +26,10 @@ type TeamController interface {
 	GetAllTeams(w http.ResponseWriter, r *http.Request)
 	GetTeamMembers(w http.ResponseWriter, r *http.Request)
 	LeaveTeam(w http.ResponseWriter, r *http.Request)
+	GetTeamActivity(w http.ResponseWriter, r *http.Request)
+	UpdateTeamAutoAssignStrategy(w http.ResponseWriter, r *http.Request)
+	UpdateTeamMemberAvailability(w http.ResponseWriter, r *http.Request)
+	GetTeamAnalytics(w http.ResponseWriter, r *http.Request)
 }
 
 type teamController struct {
@@ -45,6 +49,7 @@ func NewTeamController(teamService service.TeamService) TeamController {
 // @Produce json
 // @Tags teams
 // @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
 // @Param details formData request.Team true "Team name and profile"
 // @Param members formData []int64 true "Ids of user who will be added to the team."
 // @Success 200 {object} response.SuccessResponse "Team created successfully."
@@ -78,6 +83,7 @@ func (t teamController) CreateTeam(w http.ResponseWriter, r *http.Request) {
 
 	userId := r.Context().Value(constant.UserIdKey).(int64)
 	team.Details.CreatedBy = userId
+	team.Details.OrganizationID = r.Context().Value(constant.OrganizationIdKey).(int64)
 	if team.Details.Privacy == nil {
 		defaultTeamPrivacy := "PUBLIC"
 		team.Details.Privacy = &defaultTeamPrivacy
@@ -106,6 +112,7 @@ func (t teamController) CreateTeam(w http.ResponseWriter, r *http.Request) {
 // @Tags teams
 // @Param TeamID path int64 true "Team ID"
 // @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
 // @Param memberIds formData []int64 true "Array of member IDs to add to the team"
 // @Success 200 {object} response.SuccessResponse "Members added successfully"
 // @Failure 400 {object} errorhandling.CustomError "Bad request"
@@ -150,7 +157,8 @@ func (t teamController) AddMembersToTeam(w http.ResponseWriter, r *http.Request)
 	}
 
 	userId := r.Context().Value(constant.UserIdKey).(int64)
-	err = t.teamService.AddMembersToTeam(userId, teamMembersToAdd)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err = t.teamService.AddMembersToTeam(organizationId, userId, teamMembersToAdd)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
@@ -171,6 +179,7 @@ func (t teamController) AddMembersToTeam(w http.ResponseWriter, r *http.Request)
 // @Tags teams
 // @Param TeamID path int64 true "Team ID"
 // @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
 // @Param memberIds formData []int64 true "Array of member IDs to add to the team"
 // @Success 200 {object} response.SuccessResponse "Members Removed successfully"
 // @Failure 400 {object} errorhandling.CustomError "Bad request"
@@ -214,7 +223,8 @@ func (t teamController) RemoveMembersFromTeam(w http.ResponseWriter, r *http.Req
 	}
 
 	userId := r.Context().Value(constant.UserIdKey).(int64)
-	err = t.teamService.RemoveMembersFromTeam(userId, teamMembersToRemove)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err = t.teamService.RemoveMembersFromTeam(organizationId, userId, teamMembersToRemove)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
@@ -233,6 +243,7 @@ func (t teamController) RemoveMembersFromTeam(w http.ResponseWriter, r *http.Req
 // @Produce json
 // @Tags teams
 // @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
 // @Param createdByMe query bool true "return teams created by you if createdByMe set to true otherwise false."
 // @Param limit query int false "Number of tasks to return per page (default 10)"
 // @Param offset query int false "Offset for pagination (default 0)"
@@ -264,7 +275,8 @@ func (t teamController) GetAllTeams(w http.ResponseWriter, r *http.Request) {
 	}
 
 	userId := r.Context().Value(constant.UserIdKey).(int64)
-	teams, err := t.teamService.GetAllTeams(userId, teamQueryParams)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	teams, err := t.teamService.GetAllTeams(organizationId, userId, teamQueryParams)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
@@ -278,6 +290,7 @@ func (t teamController) GetAllTeams(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Tags teams
 // @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
 // @Param TeamID path int64 true "ID of team whose members you want."
 // @Param limit query int false "Number of tasks to return per page (default 10)"
 // @Param offset query int false "Offset for pagination (default 0)"
@@ -315,7 +328,9 @@ func (t teamController) GetTeamMembers(w http.ResponseWriter, r *http.Request) {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
 	}
-	teamMembers, err := t.teamService.GetTeamMembers(teamId, teamQueryParams)
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	teamMembers, err := t.teamService.GetTeamMembers(organizationId, userId, teamId, teamQueryParams)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
@@ -329,6 +344,7 @@ func (t teamController) GetTeamMembers(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Tags teams
 // @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
 // @Param TeamID path int64 true "ID of team whose members you want."
 // @Success 200 {object} response.SuccessResponse "Team left successfully."
 // @Failure 400 {object} errorhandling.CustomError "you are not a member of that team."
@@ -337,6 +353,7 @@ func (t teamController) GetTeamMembers(w http.ResponseWriter, r *http.Request) {
 // @Router /api/v1/teams/leave/{TeamID} [delete]
 func (t teamController) LeaveTeam(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
 	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
 	if err != nil {
 		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
@@ -346,7 +363,7 @@ func (t teamController) LeaveTeam(w http.ResponseWriter, r *http.Request) {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
 	}
-	err = t.teamService.LeaveTeam(userId, teamId)
+	err = t.teamService.LeaveTeam(organizationId, userId, teamId)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
@@ -358,3 +375,251 @@ func (t teamController) LeaveTeam(w http.ResponseWriter, r *http.Request) {
 	config.LoggerInstance.Info(constant.LEAVE_TEAM)
 	utils.SendSuccessResponse(w, http.StatusOK, response)
 }
+
+// GetTeamActivity fetches activity feed of the team.
+// @Summary Get team activity feed
+// @Description Get chronological activity feed of team like members added, removed or left, tasks created, reassigned or status changed and comments, latest first.
+// @Produce json
+// @Tags teams
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param TeamID path int64 true "ID of team whose activity feed you want."
+// @Param limit query int false "Number of activities to return per page (default 10)"
+// @Param offset query int false "Offset for pagination (default 0)"
+// @Param type query string false "Filter activities by type (MEMBER-ADDED, MEMBER-REMOVED, MEMBER-LEFT, TASK-CREATED, TASK-REASSIGNED, TASK-STATUS-CHANGED, COMMENT-ADDED)"
+// @Param actorId query int64 false "Filter activities by id of user who performed them"
+// @Success 200 {object} []response.TeamActivity "Team activity fetched successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Not a member of the team."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error"
+// @Router /api/v1/teams/{TeamID}/activity [get]
+func (t teamController) GetTeamActivity(w http.ResponseWriter, r *http.Request) {
+	var teamActivityQueryParams request.TeamActivityQueryParams
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&teamActivityQueryParams, r.URL.Query())
+	if err != nil {
+		errorhandling.HandleSchemaDecodeError(r, w, err)
+		return
+	}
+
+	err = utils.Validate.Struct(teamActivityQueryParams)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	if teamActivityQueryParams.Limit == 0 {
+		teamActivityQueryParams.Limit = 10
+	}
+
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	teamActivity, err := t.teamService.GetTeamActivity(organizationId, userId, teamId, teamActivityQueryParams)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, teamActivity)
+}
+
+// UpdateTeamAutoAssignStrategy updates auto assign strategy of the team.
+// @Summary Update team auto assign strategy
+// @Description Team owner can set how a member is picked as owner of tasks assigned to the team: NONE, ROUND-ROBIN or LEAST-LOADED (least open tasks).
+// @Accept json
+// @Produce json
+// @Tags teams
+// @Param TeamID path int64 true "Team ID"
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param strategy formData string true "Auto assign strategy (NONE, ROUND-ROBIN, LEAST-LOADED)"
+// @Success 200 {object} response.SuccessResponse "Auto assign strategy updated successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Not allowed to update strategy."
+// @Failure 404 {object} errorhandling.CustomError "Team not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/teams/{TeamID}/auto-assign [put]
+func (t teamController) UpdateTeamAutoAssignStrategy(w http.ResponseWriter, r *http.Request) {
+	var autoAssignStrategy request.TeamAutoAssignStrategy
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &autoAssignStrategy)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	autoAssignStrategy.TeamID = teamId
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(autoAssignStrategy)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err = t.teamService.UpdateTeamAutoAssignStrategy(organizationId, userId, autoAssignStrategy)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.TEAM_AUTO_ASSIGN_UPDATED,
+	}
+	config.LoggerInstance.Info(constant.TEAM_AUTO_ASSIGN_UPDATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// UpdateTeamMemberAvailability marks user unavailable in the team until given time.
+// @Summary Update my availability in team
+// @Description Member can mark themselves unavailable until a date, so that auto assignment skips them. Send null to become available again.
+// @Accept json
+// @Produce json
+// @Tags teams
+// @Param TeamID path int64 true "Team ID"
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param unavailableUntil formData time false "Time until which you are unavailable"
+// @Success 200 {object} response.SuccessResponse "Availability updated successfully."
+// @Failure 400 {object} errorhandling.CustomError "Either bad request or you are not a member of that team."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/teams/{TeamID}/availability [put]
+func (t teamController) UpdateTeamMemberAvailability(w http.ResponseWriter, r *http.Request) {
+	var memberAvailability request.TeamMemberAvailability
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &memberAvailability)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	memberAvailability.TeamID = teamId
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(memberAvailability)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	err = t.teamService.UpdateTeamMemberAvailability(organizationId, userId, memberAvailability)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.TEAM_AVAILABILITY_UPDATED,
+	}
+	config.LoggerInstance.Info(constant.TEAM_AVAILABILITY_UPDATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetTeamAnalytics fetches analytics of the team.
+// @Summary Get team analytics
+// @Description Get tasks created vs completed per week, median and 85th percentile cycle time from IN-PROGRESS to COMPLETED, overdue percentage and distribution of tasks by priority and owner for a date range. Only owner of the team and admins can see it, result is cached for few minutes.
+// @Produce json
+// @Tags teams
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
+// @Param TeamID path int64 true "Team ID"
+// @Param from query string false "Start date of the range in YYYY-MM-DD format (default 12 weeks before to date)"
+// @Param to query string false "End date of the range in YYYY-MM-DD format, inclusive (default today)"
+// @Success 200 {object} response.TeamAnalytics "Team analytics fetched successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request"
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 403 {object} errorhandling.CustomError "Only owner of team and admins are allowed."
+// @Failure 404 {object} errorhandling.CustomError "Team not found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/teams/{TeamID}/analytics [get]
+func (t teamController) GetTeamAnalytics(w http.ResponseWriter, r *http.Request) {
+	var teamAnalyticsQueryParams request.TeamAnalyticsQueryParams
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&teamAnalyticsQueryParams, r.URL.Query())
+	if err != nil {
+		errorhandling.HandleSchemaDecodeError(r, w, err)
+		return
+	}
+
+	err = utils.Validate.Struct(teamAnalyticsQueryParams)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	teamId, err := strconv.ParseInt(chi.URLParam(r, constant.TEAM_ID), 10, 64)
+	if err != nil {
+		if strings.Contains(err.Error(), constant.URL_PARAM_CONVERT_ERROR) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.ProvideValidParams, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	// system administrators and owners and admins of the organization can see analytics of any team of it.
+	isAdmin, _ := r.Context().Value(constant.IsAdminKey).(bool)
+	organizationRole, _ := r.Context().Value(constant.OrganizationRoleKey).(string)
+	isAdmin = isAdmin || organizationRole == constant.ORGANIZATION_ROLE_OWNER || organizationRole == constant.ORGANIZATION_ROLE_ADMIN
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	teamAnalytics, err := t.teamService.GetTeamAnalytics(organizationId, userId, isAdmin, teamId, teamAnalyticsQueryParams)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, teamAnalytics)
+}
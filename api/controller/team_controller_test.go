@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/chirag1807/task-management-system/api/model/request"
 	"github.com/chirag1807/task-management-system/constant"
@@ -74,6 +75,7 @@ func TestCreateTeam(t *testing.T) {
 			}
 			req.Header.Set("Content-Type", "application/json")
 			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.CreatedBy)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
 			req = req.WithContext(ctx)
 
 			w := httptest.NewRecorder()
@@ -136,6 +138,7 @@ func TestAddMembersToTeam(t *testing.T) {
 			rctx.URLParams.Add("TeamID", strconv.Itoa(int(v.TeamID)))
 			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
 			ctx = context.WithValue(ctx, constant.UserIdKey, v.GroupCreatedByID)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
 			req = req.WithContext(ctx)
 
 			w := httptest.NewRecorder()
@@ -191,6 +194,7 @@ func TestRemoveMembersFromTeam(t *testing.T) {
 			rctx.URLParams.Add("TeamID", strconv.Itoa(int(v.TeamID)))
 			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
 			ctx = context.WithValue(ctx, constant.UserIdKey, v.GroupCreatedByID)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
 			req = req.WithContext(ctx)
 
 			w := httptest.NewRecorder()
@@ -245,6 +249,7 @@ func TestGetAllTeams(t *testing.T) {
 			}
 
 			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserId)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
 			req = req.WithContext(ctx)
 
 			q := req.URL.Query()
@@ -266,6 +271,7 @@ func TestGetAllTeams(t *testing.T) {
 func TestGetTeamMembers(t *testing.T) {
 	testCases := []struct {
 		TestCaseName string
+		UserID       int64
 		TeamID       int64
 		QueryParams  request.TeamQueryParams
 		Expected     interface{}
@@ -273,6 +279,7 @@ func TestGetTeamMembers(t *testing.T) {
 	}{
 		{
 			TestCaseName: "Team Members Fetched Successfully",
+			UserID:       954488202459119617,
 			TeamID:       954507580144451585,
 			QueryParams: request.TeamQueryParams{
 				Limit:  1,
@@ -294,6 +301,8 @@ func TestGetTeamMembers(t *testing.T) {
 			rctx := chi.NewRouteContext()
 			rctx.URLParams.Add("TeamID", strconv.FormatInt(v.TeamID, 10))
 			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, constant.UserIdKey, v.UserID)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
 			req = req.WithContext(ctx)
 
 			q := req.URL.Query()
@@ -344,8 +353,260 @@ func TestLeftTeam(t *testing.T) {
 			rctx.URLParams.Add("TeamID", strconv.FormatInt(v.TeamID, 10))
 			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
 			ctx = context.WithValue(ctx, constant.UserIdKey, v.UserID)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestGetTeamActivity(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		TeamID       int64
+		UserID       int64
+		Type         string
+		Expected     interface{}
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Team Activity Fetched Successfully",
+			TeamID:       954507580144451586,
+			UserID:       954488202459119617,
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Value Must be in Enum Values.",
+			TeamID:       954507580144451586,
+			UserID:       954488202459119617,
+			Type:         "TEAM-DELETED",
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "Not a Member of Team",
+			TeamID:       954507580144451586,
+			UserID:       954497896847212546,
+			StatusCode:   403,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Get("/api/v1/teams/:TeamID/activity", NewTeamController(teamService).GetTeamActivity)
+
+			req, err := http.NewRequest("GET", "/api/v1/teams/:TeamID/activity", http.NoBody)
+			if err != nil {
+				log.Println(err)
+			}
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("TeamID", strconv.FormatInt(v.TeamID, 10))
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, constant.UserIdKey, v.UserID)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
 			req = req.WithContext(ctx)
 
+			q := req.URL.Query()
+			if v.Type != constant.EMPTY_STRING {
+				q.Add("type", v.Type)
+			}
+			req.URL.RawQuery = q.Encode()
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestUpdateTeamAutoAssignStrategy(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		TeamID       int64
+		Strategy     string
+		UserID       int64
+		Expected     interface{}
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Auto Assign Strategy Updated Successfully",
+			TeamID:       954507580144451586,
+			Strategy:     "LEAST-LOADED",
+			UserID:       954488202459119617,
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Value Must be in Enum Values.",
+			TeamID:       954507580144451586,
+			Strategy:     "RANDOM",
+			UserID:       954488202459119617,
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "Not Allowed to Update Auto Assign Strategy",
+			TeamID:       954507580144451586,
+			Strategy:     "ROUND-ROBIN",
+			UserID:       954497896847212545,
+			StatusCode:   403,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Put("/api/v1/teams/:TeamID/auto-assign", NewTeamController(teamService).UpdateTeamAutoAssignStrategy)
+
+			autoAssignStrategy := request.TeamAutoAssignStrategy{
+				Strategy: v.Strategy,
+			}
+			jsonValue, err := json.Marshal(autoAssignStrategy)
+			if err != nil {
+				log.Println(err)
+			}
+			req, err := http.NewRequest("PUT", "/api/v1/teams/:TeamID/auto-assign", bytes.NewBuffer(jsonValue))
+			if err != nil {
+				log.Println(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("TeamID", strconv.FormatInt(v.TeamID, 10))
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, constant.UserIdKey, v.UserID)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestUpdateTeamMemberAvailability(t *testing.T) {
+	testCases := []struct {
+		TestCaseName     string
+		TeamID           int64
+		UnavailableUntil *time.Time
+		UserID           int64
+		Expected         interface{}
+		StatusCode       int
+	}{
+		{
+			TestCaseName:     "Availability Updated Successfully",
+			TeamID:           954507580144451586,
+			UnavailableUntil: func() *time.Time { until := time.Now().Add(2 * 24 * time.Hour); return &until }(),
+			UserID:           954488202459119617,
+			StatusCode:       200,
+		},
+		{
+			TestCaseName:     "Time Must be Greater than Now.",
+			TeamID:           954507580144451586,
+			UnavailableUntil: func() *time.Time { until := time.Now().Add(-2 * 24 * time.Hour); return &until }(),
+			UserID:           954488202459119617,
+			StatusCode:       400,
+		},
+		{
+			TestCaseName: "Not a Member of Team",
+			TeamID:       954507580144451586,
+			UserID:       954497896847212546,
+			StatusCode:   400,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Put("/api/v1/teams/:TeamID/availability", NewTeamController(teamService).UpdateTeamMemberAvailability)
+
+			memberAvailability := request.TeamMemberAvailability{
+				UnavailableUntil: v.UnavailableUntil,
+			}
+			jsonValue, err := json.Marshal(memberAvailability)
+			if err != nil {
+				log.Println(err)
+			}
+			req, err := http.NewRequest("PUT", "/api/v1/teams/:TeamID/availability", bytes.NewBuffer(jsonValue))
+			if err != nil {
+				log.Println(err)
+			}
+
+			req.Header.Set("Content-Type", "application/json")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("TeamID", strconv.FormatInt(v.TeamID, 10))
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, constant.UserIdKey, v.UserID)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestGetTeamAnalytics(t *testing.T) {
+	testCases := []struct {
+		TestCaseName     string
+		TeamID           int64
+		UserID           int64
+		OrganizationRole string
+		From             string
+		StatusCode       int
+	}{
+		{
+			TestCaseName:     "Analytics Fetched Successfully.",
+			TeamID:           954507580144451585,
+			UserID:           954488202459119617,
+			OrganizationRole: constant.ORGANIZATION_ROLE_OWNER,
+			StatusCode:       200,
+		},
+		{
+			TestCaseName:     "Date Must be in Given Format.",
+			TeamID:           954507580144451585,
+			UserID:           954488202459119617,
+			OrganizationRole: constant.ORGANIZATION_ROLE_OWNER,
+			From:             "01-02-2024",
+			StatusCode:       400,
+		},
+		{
+			TestCaseName:     "Member of Team is Not Allowed.",
+			TeamID:           954507580144451585,
+			UserID:           954497896847212547,
+			OrganizationRole: constant.ORGANIZATION_ROLE_MEMBER,
+			StatusCode:       403,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Get("/api/v1/teams/:TeamID/analytics", NewTeamController(teamService).GetTeamAnalytics)
+
+			req, err := http.NewRequest("GET", "/api/v1/teams/:TeamID/analytics", http.NoBody)
+			if err != nil {
+				log.Println(err)
+			}
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("TeamID", strconv.FormatInt(v.TeamID, 10))
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, constant.UserIdKey, v.UserID)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
+			ctx = context.WithValue(ctx, constant.OrganizationRoleKey, v.OrganizationRole)
+			req = req.WithContext(ctx)
+
+			q := req.URL.Query()
+			if v.From != constant.EMPTY_STRING {
+				q.Add("from", v.From)
+			}
+			req.URL.RawQuery = q.Encode()
+
 			w := httptest.NewRecorder()
 			r.ServeHTTP(w, req)
 
@@ -1,15 +1,13 @@
 package controller
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
-	"math/rand"
 	"net/http"
-	"time"
 
-	"github.com/chirag1807/task-management-system/api/model/dto"
 	"github.com/chirag1807/task-management-system/api/model/request"
 	"github.com/chirag1807/task-management-system/api/model/response"
 	"github.com/chirag1807/task-management-system/api/service"
@@ -27,6 +25,17 @@ type UserController interface {
 	SendOTPToUser(w http.ResponseWriter, r *http.Request)
 	VerifyOTP(w http.ResponseWriter, r *http.Request)
 	ResetUserPassword(w http.ResponseWriter, r *http.Request)
+	VerifyEmail(w http.ResponseWriter, r *http.Request)
+	ResendVerificationEmail(w http.ResponseWriter, r *http.Request)
+	ConfirmEmailChange(w http.ResponseWriter, r *http.Request)
+	EnrollTwoFactor(w http.ResponseWriter, r *http.Request)
+	ConfirmTwoFactor(w http.ResponseWriter, r *http.Request)
+	DisableTwoFactor(w http.ResponseWriter, r *http.Request)
+	UpdateUserAvatar(w http.ResponseWriter, r *http.Request)
+	ExportUserData(w http.ResponseWriter, r *http.Request)
+	DeleteAccount(w http.ResponseWriter, r *http.Request)
+	GetUserPreferences(w http.ResponseWriter, r *http.Request)
+	UpdateUserPreferences(w http.ResponseWriter, r *http.Request)
 }
 
 type userController struct {
@@ -39,12 +48,13 @@ func NewUserController(userService service.UserService) UserController {
 	}
 }
 
-// GetAllPublicPrivacyUsers fetches all public privacy users.
+// GetAllPublicPrivacyUsers fetches all users who are discoverable in search.
 // @Summary Get all public privacy users
-// @Description Get all public privacy users based on query parameters
+// @Description Get all users of active organization who are discoverable in search based on query parameters, email and bio of each user are included only if user has made them visible to the requester.
 // @Produce json
 // @Tags users
 // @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param X-Organization-ID header int64 false "ID of active organization, required if user is member of more than one organization."
 // @Param Limit query int false "Number of users to return per page (default 10)"
 // @Param Offset query int false "Offset for pagination (default 0)"
 // @Param Search query string false "Search term to filter users"
@@ -73,7 +83,9 @@ func (u userController) GetAllPublicPrivacyUsers(w http.ResponseWriter, r *http.
 		userQueryParams.Limit = 10
 	}
 
-	publicPrivacyUsers, err := u.userService.GetAllPublicPrivacyUsers(userQueryParams)
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	organizationId := r.Context().Value(constant.OrganizationIdKey).(int64)
+	publicPrivacyUsers, err := u.userService.GetAllPublicPrivacyUsers(organizationId, userId, userQueryParams)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
@@ -104,7 +116,7 @@ func (u userController) GetMyDetails(w http.ResponseWriter, r *http.Request) {
 
 // UpdateUserProfile updates a user's profile.
 // @Summary Update User Profile
-// @Description UpdateUserProfile API is made for updating a user's profile.
+// @Description UpdateUserProfile API is made for updating a user's profile, new email is switched only after it is verified by ConfirmEmailChange API. current password is required for changing email or password and email can't be changed by personal access token.
 // @Accept json
 // @Produce json
 // @Tags users
@@ -112,12 +124,13 @@ func (u userController) GetMyDetails(w http.ResponseWriter, r *http.Request) {
 // @Param firstName formData string false "First name of the user"
 // @Param lastName formData string false "Last name of the user"
 // @Param bio formData string false "Bio of the user"
-// @Param email formData string false "Email of the user"
-// @Param password formData string false "Password of the user"
-// @Param privacy formData string false "Privacy of the user (PUBLIC, PRIVATE)"
+// @Param email formData string false "New email of the user, verification code is sent to it"
+// @Param password formData string false "Current password of the user, required if email or newPassword is given"
+// @Param privacy formData string false "Privacy settings of the user with emailVisibility, bioVisibility, teamsVisibility and assignableBy (EVERYONE, TEAMMATES, NOBODY) and discoverable, only given settings are updated"
 // @Success 200 {object} response.SuccessResponse "User Updated successfully."
 // @Failure 400 {object} errorhandling.CustomError "Bad request."
 // @Failure 401 {object} errorhandling.CustomError "Either password not matched or token expired."
+// @Failure 403 {object} errorhandling.CustomError "Email can't be changed by personal access token."
 // @Failure 404 {object} errorhandling.CustomError "No user found."
 // @Failure 409 {object} errorhandling.CustomError "Duplicate email found."
 // @Failure 500 {object} errorhandling.CustomError "Internal server error."
@@ -147,18 +160,24 @@ func (u userController) UpdateUserProfile(w http.ResponseWriter, r *http.Request
 	}
 
 	userId := r.Context().Value(constant.UserIdKey).(int64)
-	if userToUpdate.Password != constant.EMPTY_STRING {
+	// email can be changed only from login session, as personal access token is not meant for taking over the account.
+	if _, ok := r.Context().Value(constant.ScopesKey).([]string); ok && userToUpdate.Email != constant.EMPTY_STRING {
+		errorhandling.SendErrorResponse(r, w, errorhandling.PersonalAccessTokenNotAllowed, constant.EMPTY_STRING)
+		return
+	}
+	if userToUpdate.NewPassword != constant.EMPTY_STRING || userToUpdate.Email != constant.EMPTY_STRING {
 		err := u.userService.VerifyUserPassword(userToUpdate.Password, userId)
 		if err != nil {
 			errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 			return
 		}
-		hashedPassword, err := utils.HashPassword(userToUpdate.NewPassword)
+	}
+	if userToUpdate.NewPassword != constant.EMPTY_STRING {
+		err = utils.ValidatePasswordPolicy("newPassword", userToUpdate.NewPassword)
 		if err != nil {
 			errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 			return
 		}
-		userToUpdate.Password = hashedPassword
 	}
 
 	err = u.userService.UpdateUserProfile(userId, userToUpdate)
@@ -167,24 +186,28 @@ func (u userController) UpdateUserProfile(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	message := constant.USER_PROFILE_UPDATED
+	if userToUpdate.Email != constant.EMPTY_STRING {
+		message = constant.EMAIL_CHANGE_REQUESTED
+	}
 	response := response.SuccessResponse{
-		Code: http.StatusText(http.StatusOK),
-		Message: constant.USER_PROFILE_UPDATED,
+		Code:    http.StatusText(http.StatusOK),
+		Message: message,
 	}
-	config.LoggerInstance.Info(constant.USER_PROFILE_UPDATED)
+	config.LoggerInstance.Info(message)
 	utils.SendSuccessResponse(w, http.StatusOK, response)
 }
 
-// SendOTPToUser sends an otp to user's email address.
+// SendOTPToUser sends a password reset otp to user's email address.
 // @Summary Sends an OTP
-// @Description SendOTPToUser API is made for sending an otp to user's email address.
+// @Description SendOTPToUser API is made for sending a password reset otp to user's email address, response is same whether or not any user is registered with the email.
 // @Accept json
 // @Produce json
 // @Tags users
 // @Param email formData string true "Email of the user"
 // @Success 200 {object} response.SuccessResponse "OTP sent successfully."
 // @Failure 400 {object} errorhandling.CustomError "Bad request."
-// @Failure 404 {object} errorhandling.CustomError "No Email found."
+// @Failure 429 {object} errorhandling.CustomError "OTP was sent recently or too many otps are sent, see Retry-After header."
 // @Failure 500 {object} errorhandling.CustomError "Internal server error."
 // @Router /api/v1/users/send-otp [post]
 func (u userController) SendOTPToUser(w http.ResponseWriter, r *http.Request) {
@@ -211,45 +234,32 @@ func (u userController) SendOTPToUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	min, max := 1001, 9999
-	OTP := rand.Intn(max-min) + min
-	fmt.Println(OTP)
-
-	emailBody := utils.PrepareEmailBody(OTP)
-	email := dto.Email{
-		To:      userEmail.Email,
-		Subject: "OTP Verification",
-		Body:    emailBody,
-	}
-
-	otpExpireTime := time.Now().UTC().Add(time.Minute * 5)
-	id, err := u.userService.SendOTPToUser(email, OTP, otpExpireTime)
+	err = u.userService.SendOTPToUser(userEmail.Email)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
 	}
 
-	config.LoggerInstance.Info(string(rune(OTP)))
 	response := response.SuccessResponse{
-		Code: http.StatusText(http.StatusOK),
+		Code:    http.StatusText(http.StatusOK),
 		Message: constant.OTP_SENT,
-		ID:      &id,
 	}
+	config.LoggerInstance.Info(constant.OTP_SENT)
 	utils.SendSuccessResponse(w, http.StatusOK, response)
 }
 
 // VerifyOTP verifies an otp from user.
 // @Summary Verifies an OTP
-// @Description VerifyOTP API is made for verifying an otp from user.
+// @Description VerifyOTP API is made for verifying an otp from user, otp can be used only once and short lived reset token is returned for resetting password.
 // @Accept json
 // @Produce json
 // @Tags users
-// @Param id formData int64 true "ID which you've received in response of SendOTPToUser API"
-// @Param otp formData int true "OTP which user has entered"
-// @Success 200 {object} response.SuccessResponse "OTP Verifies successfully."
+// @Param email formData string true "Email to which otp is sent"
+// @Param otp formData string true "OTP which user has entered"
+// @Success 200 {object} response.PasswordResetToken "OTP Verifies successfully."
 // @Failure 400 {object} errorhandling.CustomError "Bad request."
-// @Failure 401 {object} errorhandling.CustomError "OTP not matched."
-// @Failure 410 {object} errorhandling.CustomError "OTP verification time expired."
+// @Failure 401 {object} errorhandling.CustomError "OTP not matched, expired or already used."
+// @Failure 429 {object} errorhandling.CustomError "Too many wrong attempts, otp is invalidated."
 // @Failure 500 {object} errorhandling.CustomError "Internal server error."
 // @Router /api/v1/users/verify-otp [post]
 func (u userController) VerifyOTP(w http.ResponseWriter, r *http.Request) {
@@ -276,33 +286,330 @@ func (u userController) VerifyOTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = u.userService.VerifyOTP(otp)
+	resetToken, err := u.userService.VerifyOTP(otp)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
 	}
-	response := response.SuccessResponse{
-		Code: http.StatusText(http.StatusOK),
-		Message: constant.OTP_VERIFICATION_SUCCEED,
+	response := response.PasswordResetToken{
+		Code:       http.StatusText(http.StatusOK),
+		Message:    constant.OTP_VERIFICATION_SUCCEED,
+		ResetToken: resetToken,
 	}
+	config.LoggerInstance.Info(constant.OTP_VERIFICATION_SUCCEED)
 	utils.SendSuccessResponse(w, http.StatusOK, response)
 }
 
 // ResetUserPassword reset user's password to our database.
 // @Summary reset user password
-// @Description ResetUserPassword API is made for reset user password.
+// @Description ResetUserPassword API is made for reset user password, user is logged out from all sessions.
 // @Accept json
 // @Produce json
 // @Tags users
-// @Param id formData int64 true "ID which you've received in response of SendOTPToUser API"
+// @Param resetToken formData string true "Reset token which you've received in response of VerifyOTP API"
 // @Param password formData string true "New password of the user"
 // @Success 200 {object} response.SuccessResponse "Password reset done successfully."
 // @Failure 400 {object} errorhandling.CustomError "Bad request."
-// @Failure 401 {object} errorhandling.CustomError "OTP not verified with our system."
+// @Failure 401 {object} errorhandling.CustomError "Reset token is invalid, expired or already used."
 // @Failure 500 {object} errorhandling.CustomError "Internal server error."
 // @Router /api/v1/users/reset-password [put]
 func (u userController) ResetUserPassword(w http.ResponseWriter, r *http.Request) {
-	var userPasswordWithOTPId request.UserPasswordWithOTPID
+	var resetPassword request.ResetPassword
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &resetPassword)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(resetPassword)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	err = utils.ValidatePasswordPolicy("password", resetPassword.Password)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	err = u.userService.ResetUserPassword(resetPassword)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.PASSWORD_RESET_SUCCEED,
+	}
+	config.LoggerInstance.Info(constant.PASSWORD_RESET_SUCCEED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// VerifyEmail verifies email of the user by code sent at the time of registration.
+// @Summary Verify Email
+// @Description VerifyEmail API is made for verifying email of the user by code which is sent to it at the time of registration or by ResendVerificationEmail API.
+// @Accept json
+// @Produce json
+// @Tags users
+// @Param email formData string true "Email of the user"
+// @Param otp formData string true "Code which is sent to the email"
+// @Success 200 {object} response.SuccessResponse "Email verified successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "OTP not matched."
+// @Failure 410 {object} errorhandling.CustomError "OTP verification time expired or otp is already used."
+// @Failure 429 {object} errorhandling.CustomError "Too many wrong attempts, otp is invalidated."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/users/verify-email [post]
+func (u userController) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var otp request.OTP
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &otp)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(otp)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	err = u.userService.VerifyEmail(otp)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.EMAIL_VERIFIED,
+	}
+	config.LoggerInstance.Info(constant.EMAIL_VERIFIED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// ResendVerificationEmail sends new email verification code to user's email address.
+// @Summary Resend Verification Email
+// @Description ResendVerificationEmail API is made for sending new email verification code, response is same whether or not any unverified user is registered with the email.
+// @Accept json
+// @Produce json
+// @Tags users
+// @Param email formData string true "Email of the user"
+// @Success 200 {object} response.SuccessResponse "Verification code sent successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 429 {object} errorhandling.CustomError "Code was sent recently or too many codes are sent, see Retry-After header."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/users/verify-email/resend [post]
+func (u userController) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	var userEmail request.UserEmail
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &userEmail)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(userEmail)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	err = u.userService.ResendVerificationEmail(userEmail.Email)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.EMAIL_VERIFICATION_SENT,
+	}
+	config.LoggerInstance.Info(constant.EMAIL_VERIFICATION_SENT)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// ConfirmEmailChange switches email of the user to new email requested by UpdateUserProfile.
+// @Summary Confirm Email Change
+// @Description ConfirmEmailChange API is made for verifying new email of the user by code sent to it, email is switched only after that.
+// @Accept json
+// @Produce json
+// @Tags users
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param otp formData string true "Code which is sent to new email"
+// @Success 200 {object} response.SuccessResponse "Email changed successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request or no email change is pending."
+// @Failure 401 {object} errorhandling.CustomError "OTP not matched."
+// @Failure 409 {object} errorhandling.CustomError "Duplicate email found."
+// @Failure 410 {object} errorhandling.CustomError "OTP verification time expired or otp is already used."
+// @Failure 429 {object} errorhandling.CustomError "Too many wrong attempts, otp is invalidated."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/users/profile/email/verify [post]
+func (u userController) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	var emailChangeOTP request.EmailChangeOTP
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &emailChangeOTP)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(emailChangeOTP)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	err = u.userService.ConfirmEmailChange(userId, emailChangeOTP.OTP)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.EMAIL_CHANGED,
+	}
+	config.LoggerInstance.Info(constant.EMAIL_CHANGED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// EnrollTwoFactor starts two factor authentication enrollment of the user.
+// @Summary Enroll Two Factor Authentication
+// @Description EnrollTwoFactor API is made for generating totp secret and otpauth uri for authenticator app, two factor authentication is enabled only after confirming it with a code.
+// @Produce json
+// @Tags users
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Success 200 {object} response.TwoFactorEnrollment "Two factor authentication enrolled successfully."
+// @Failure 401 {object} errorhandling.CustomError "Either access token is expired or it is revoked."
+// @Failure 409 {object} errorhandling.CustomError "Two factor authentication is already enabled."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/users/profile/2fa [post]
+func (u userController) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+
+	secret, uri, err := u.userService.EnrollTwoFactor(userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.TwoFactorEnrollment{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.TWO_FACTOR_ENROLLED,
+		Secret:  secret,
+		URI:     uri,
+	}
+	config.LoggerInstance.Info(constant.TWO_FACTOR_ENROLLED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// ConfirmTwoFactor confirms two factor authentication enrollment of the user.
+// @Summary Confirm Two Factor Authentication
+// @Description ConfirmTwoFactor API is made for enabling two factor authentication by verifying first code of authenticator app, it returns one time recovery codes which are shown only once.
+// @Accept json
+// @Produce json
+// @Tags users
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param code formData string true "Code generated by authenticator app"
+// @Success 200 {object} response.RecoveryCodes "Two factor authentication enabled successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Code is invalid."
+// @Failure 409 {object} errorhandling.CustomError "Two factor authentication is already enabled."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/users/profile/2fa/confirm [post]
+func (u userController) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var twoFactorCode request.TwoFactorCode
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &twoFactorCode)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(twoFactorCode)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	recoveryCodes, err := u.userService.ConfirmTwoFactor(userId, twoFactorCode.Code)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.RecoveryCodes{
+		Code:          http.StatusText(http.StatusOK),
+		Message:       constant.TWO_FACTOR_ENABLED,
+		RecoveryCodes: recoveryCodes,
+	}
+	config.LoggerInstance.Info(constant.TWO_FACTOR_ENABLED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// DisableTwoFactor disables two factor authentication of the user.
+// @Summary Disable Two Factor Authentication
+// @Description DisableTwoFactor API is made for disabling two factor authentication, current password of the user is required for it.
+// @Accept json
+// @Produce json
+// @Tags users
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param password formData string true "Current password of the user"
+// @Success 200 {object} response.SuccessResponse "Two factor authentication disabled successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request or two factor authentication is not enabled."
+// @Failure 401 {object} errorhandling.CustomError "Password not matched."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/users/profile/2fa [delete]
+func (u userController) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var userPassword request.UserPassword
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -311,7 +618,7 @@ func (u userController) ResetUserPassword(w http.ResponseWriter, r *http.Request
 	}
 	defer r.Body.Close()
 
-	err = json.Unmarshal(body, &userPasswordWithOTPId)
+	err = json.Unmarshal(body, &userPassword)
 	if err != nil {
 		errorhandling.HandleJSONUnmarshlError(r, w, err)
 		return
@@ -319,19 +626,296 @@ func (u userController) ResetUserPassword(w http.ResponseWriter, r *http.Request
 
 	r.Body = io.NopCloser(bytes.NewReader(body))
 
-	err = utils.Validate.Struct(userPasswordWithOTPId)
+	err = utils.Validate.Struct(userPassword)
 	if err != nil {
 		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
 		return
 	}
 
-	err = u.userService.ResetUserPassword(userPasswordWithOTPId)
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	err = u.userService.VerifyUserPassword(userPassword.Password, userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	err = u.userService.DisableTwoFactor(userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.SuccessResponse{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.TWO_FACTOR_DISABLED,
+	}
+	config.LoggerInstance.Info(constant.TWO_FACTOR_DISABLED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// UpdateUserAvatar uploads new avatar of the authenticated user.
+// @Summary Update Avatar
+// @Description UpdateUserAvatar API is made for uploading avatar of the user, JPEG, PNG or GIF image of at most 5 MB is accepted. image is cropped to square and stored as thumbnails of 64px, 128px and 256px without any metadata of uploaded file.
+// @Accept multipart/form-data
+// @Produce json
+// @Tags users
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param avatar formData file true "Avatar image of the user"
+// @Success 200 {object} response.UserAvatar "Avatar updated successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request or image is invalid."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 413 {object} errorhandling.CustomError "Image is larger than 5 MB."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/users/profile/avatar [put]
+func (u userController) UpdateUserAvatar(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, constant.AVATAR_MAX_UPLOAD_SIZE+1<<20)
+	err := r.ParseMultipartForm(constant.AVATAR_MAX_UPLOAD_SIZE)
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			errorhandling.SendErrorResponse(r, w, errorhandling.AvatarTooLarge, constant.EMPTY_STRING)
+			return
+		}
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, fileHeader, err := r.FormFile(constant.AVATAR_FORM_FIELD)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.InvalidAvatarImage, constant.EMPTY_STRING)
+		return
+	}
+	defer file.Close()
+	if fileHeader.Size > constant.AVATAR_MAX_UPLOAD_SIZE {
+		errorhandling.SendErrorResponse(r, w, errorhandling.AvatarTooLarge, constant.EMPTY_STRING)
+		return
+	}
+	avatarImage, err := io.ReadAll(file)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+
+	thumbnails, err := utils.CreateAvatarThumbnails(avatarImage)
 	if err != nil {
 		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
 		return
 	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	avatar, err := u.userService.UpdateUserAvatar(userId, thumbnails)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.UserAvatar{
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.AVATAR_UPDATED,
+		Avatar:  avatar,
+	}
+	config.LoggerInstance.Info(constant.AVATAR_UPDATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetUserPreferences fetches preferences of the authenticated user.
+// @Summary Get Preferences
+// @Description GetUserPreferences API is made for fetching timezone, locale, date format, first day of week and notification channels of the user, defaults are returned if user has not saved any.
+// @Produce json
+// @Tags users
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Success 200 {object} response.UserPreferences "Preferences fetched successfully."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 404 {object} errorhandling.CustomError "No user found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/users/profile/preferences [get]
+func (u userController) GetUserPreferences(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	preferences, err := u.userService.GetUserPreferences(userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	utils.SendSuccessResponse(w, http.StatusOK, preferences)
+}
+
+// UpdateUserPreferences updates preferences of the authenticated user.
+// @Summary Update Preferences
+// @Description UpdateUserPreferences API is made for updating preferences of the user, only given fields are updated. dates in emails are shown in timezone and date format of the user and notifications are sent over chosen channel (EMAIL, SOCKET or NONE) of each event.
+// @Accept json
+// @Produce json
+// @Tags users
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param preferences body request.UserPreferences true "Preferences to update"
+// @Success 200 {object} response.UserPreferencesUpdated "Preferences updated successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 404 {object} errorhandling.CustomError "No user found."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/users/profile/preferences [put]
+func (u userController) UpdateUserPreferences(w http.ResponseWriter, r *http.Request) {
+	var preferencesToUpdate request.UserPreferences
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &preferencesToUpdate)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(preferencesToUpdate)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	preferences, err := u.userService.UpdateUserPreferences(userId, preferencesToUpdate)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	response := response.UserPreferencesUpdated{
+		Code:        http.StatusText(http.StatusOK),
+		Message:     constant.PREFERENCES_UPDATED,
+		Preferences: preferences,
+	}
+	config.LoggerInstance.Info(constant.PREFERENCES_UPDATED)
+	utils.SendSuccessResponse(w, http.StatusOK, response)
+}
+
+// ExportUserData exports personal data of the authenticated user.
+// @Summary Export Personal Data
+// @Description ExportUserData API is made for downloading copy of personal data of the user with profile, preferences, teams, tasks created and assigned, sessions and comments, either as json file or as zip archive having it.
+// @Produce json
+// @Produce application/zip
+// @Tags users
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param Format query string false "Format of export (json, zip), default is json"
+// @Success 200 {object} response.UserDataExport "Personal data exported successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Either refresh token not found or token is expired."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/users/me/export [get]
+func (u userController) ExportUserData(w http.ResponseWriter, r *http.Request) {
+	var exportParams request.UserDataExportParams
+
+	decoder := schema.NewDecoder()
+	err := decoder.Decode(&exportParams, r.URL.Query())
+	if err != nil {
+		errorhandling.HandleSchemaDecodeError(r, w, err)
+		return
+	}
+
+	err = utils.Validate.Struct(exportParams)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	userData, err := u.userService.ExportUserData(userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	userDataJSON, err := json.MarshalIndent(userData, "", "  ")
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	if exportParams.Format != constant.USER_DATA_EXPORT_ZIP {
+		w.Header().Set("Content-Disposition", "attachment; filename="+constant.USER_DATA_EXPORT_FILE_NAME+".json")
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write(userDataJSON)
+		return
+	}
+
+	var archive bytes.Buffer
+	zipWriter := zip.NewWriter(&archive)
+	file, err := zipWriter.Create(constant.USER_DATA_EXPORT_FILE_NAME + ".json")
+	if err == nil {
+		_, err = file.Write(userDataJSON)
+	}
+	if err == nil {
+		err = zipWriter.Close()
+	}
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+	w.Header().Set("Content-Disposition", "attachment; filename="+constant.USER_DATA_EXPORT_FILE_NAME+".zip")
+	w.Header().Set("Content-Type", "application/zip")
+	w.WriteHeader(http.StatusOK)
+	w.Write(archive.Bytes())
+}
+
+// DeleteAccount schedules deletion of the authenticated user's account.
+// @Summary Delete Account
+// @Description DeleteAccount API is made for deleting account of the user, current password of the user is required for it. user is logged out of every session and account is deleted after grace period, logging in again within it cancels the deletion. on deletion personal data is removed, user is shown as anonymous where it is creator or updater, ownership of teams created by the user is given to another member and teams having no other member are deleted.
+// @Accept json
+// @Produce json
+// @Tags users
+// @Param Authorization header string true "Access Token" default(Bearer <access_token>)
+// @Param password formData string true "Current password of the user"
+// @Success 200 {object} response.SuccessResponse "Account deletion scheduled successfully."
+// @Failure 400 {object} errorhandling.CustomError "Bad request."
+// @Failure 401 {object} errorhandling.CustomError "Password not matched."
+// @Failure 500 {object} errorhandling.CustomError "Internal server error."
+// @Router /api/v1/users/me [delete]
+func (u userController) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	var userPassword request.UserPassword
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, errorhandling.ReadBodyError, constant.EMPTY_STRING)
+		return
+	}
+	defer r.Body.Close()
+
+	err = json.Unmarshal(body, &userPassword)
+	if err != nil {
+		errorhandling.HandleJSONUnmarshlError(r, w, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = utils.Validate.Struct(userPassword)
+	if err != nil {
+		errorhandling.HandleInvalidRequestData(w, r, err, utils.Translator)
+		return
+	}
+
+	userId := r.Context().Value(constant.UserIdKey).(int64)
+	err = u.userService.VerifyUserPassword(userPassword.Password, userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
+	err = u.userService.ScheduleAccountDeletion(userId)
+	if err != nil {
+		errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+		return
+	}
+
 	response := response.SuccessResponse{
-		Message: "Password Reset Done Successfully.",
+		Code:    http.StatusText(http.StatusOK),
+		Message: constant.ACCOUNT_DELETE_SCHEDULED,
 	}
+	config.LoggerInstance.Info(constant.ACCOUNT_DELETE_SCHEDULED)
 	utils.SendSuccessResponse(w, http.StatusOK, response)
 }
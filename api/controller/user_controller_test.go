@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"image"
+	"image/png"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -12,18 +15,22 @@ import (
 
 	"github.com/chirag1807/task-management-system/api/model/request"
 	"github.com/chirag1807/task-management-system/constant"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/chirag1807/task-management-system/utils/storage"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGetAllPublicPrivacyUsers(t *testing.T) {
 	testCases := []struct {
 		TestCaseName string
+		UserID       int64
 		QueryParams  request.UserQueryParams
 		Expected     interface{}
 		StatusCode   int
 	}{
 		{
 			TestCaseName: "All Public Privacy Users Fetched Successfully",
+			UserID:       954488202459119617,
 			QueryParams: request.UserQueryParams{
 				Limit:  1,
 				Offset: 0,
@@ -42,6 +49,10 @@ func TestGetAllPublicPrivacyUsers(t *testing.T) {
 				log.Println(err)
 			}
 
+			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserID)
+			ctx = context.WithValue(ctx, constant.OrganizationIdKey, int64(954560000000000001))
+			req = req.WithContext(ctx)
+
 			q := req.URL.Query()
 			q.Add("limit", strconv.Itoa(v.QueryParams.Limit))
 			q.Add("offset", strconv.Itoa(v.QueryParams.Offset))
@@ -98,7 +109,9 @@ func TestUpdateUserProfile(t *testing.T) {
 		Bio          string
 		Email        string
 		Password     string
-		Privacy      string
+		NewPassword  string
+		Privacy      request.PrivacySettings
+		Scopes       []string
 		UserID       int64
 		Expected     interface{}
 		StatusCode   int
@@ -129,17 +142,42 @@ func TestUpdateUserProfile(t *testing.T) {
 		{
 			TestCaseName: "Duplicate Email",
 			Email:        "ridham@gmail.com",
+			Password:     "Dhyey123$",
 			UserID:       954488202459119617,
 			Expected:     "Duplicate Email Found.",
 			StatusCode:   409,
 		},
+		{
+			TestCaseName: "Current Password Required for Email Change.",
+			Email:        "dhyey.panchal@gmail.com",
+			UserID:       954488202459119617,
+			Expected:     "Password is Incorrect.",
+			StatusCode:   401,
+		},
+		{
+			TestCaseName: "Email Can't be Changed by Personal Access Token.",
+			Email:        "dhyey.panchal@gmail.com",
+			Password:     "Dhyey123$",
+			Scopes:       []string{constant.SCOPE_USERS_WRITE},
+			UserID:       954488202459119617,
+			Expected:     "Personal Access Token can't be Used for This Request, Please Use Access Token of Login.",
+			StatusCode:   403,
+		},
 		{
 			TestCaseName: "Value Must be in Enum Values.",
-			Privacy:      "public",
+			Privacy:      request.PrivacySettings{AssignableBy: "public"},
 			UserID:       954488202459119617,
-			Expected:     "profile value must be in the enum [PUBLIC PRIVATE]",
+			Expected:     "assignableBy must be one of EVERYONE TEAMMATES NOBODY",
 			StatusCode:   400,
 		},
+		{
+			TestCaseName: "Current Password Not Matched.",
+			Password:     "Wrong123$",
+			NewPassword:  "Dhyey@Secure2024",
+			UserID:       954488202459119617,
+			Expected:     "Password is Incorrect.",
+			StatusCode:   401,
+		},
 	}
 
 	for _, v := range testCases {
@@ -147,12 +185,13 @@ func TestUpdateUserProfile(t *testing.T) {
 			r.Put("/api/v1/users/profile", NewUserController(userService).UpdateUserProfile)
 
 			user := request.UpdateUser{
-				FirstName: v.FirstName,
-				LastName:  v.LastName,
-				Bio:       v.Bio,
-				Email:     v.Email,
-				Password:  v.Password,
-				Privacy:   v.Privacy,
+				FirstName:       v.FirstName,
+				LastName:        v.LastName,
+				Bio:             v.Bio,
+				Email:           v.Email,
+				Password:        v.Password,
+				NewPassword:     v.NewPassword,
+				PrivacySettings: v.Privacy,
 			}
 			jsonValue, err := json.Marshal(user)
 			if err != nil {
@@ -166,6 +205,9 @@ func TestUpdateUserProfile(t *testing.T) {
 
 			req.Header.Set("Content-Type", "application/json")
 			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserID)
+			if v.Scopes != nil {
+				ctx = context.WithValue(ctx, constant.ScopesKey, v.Scopes)
+			}
 			req = req.WithContext(ctx)
 
 			w := httptest.NewRecorder()
@@ -177,6 +219,8 @@ func TestUpdateUserProfile(t *testing.T) {
 }
 
 func TestSendOTPToUser(t *testing.T) {
+	redisClient.Del(context.Background(), "otp_send:guptaaahutosh354@gmail.com", "otp_send_count:guptaaahutosh354@gmail.com",
+		"otp_send:dhyey123@gmail.com", "otp_send_count:dhyey123@gmail.com")
 	testCases := []struct {
 		TestCaseName string
 		Email        string
@@ -185,10 +229,22 @@ func TestSendOTPToUser(t *testing.T) {
 	}{
 		{
 			TestCaseName: "OTP Sent Successfully.",
-			Email:        "dhyey@gmail.com",
-			Expected:     "User Registration Done Successfully.",
+			Email:        "guptaaahutosh354@gmail.com",
+			Expected:     constant.OTP_SENT,
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "No Email Found, Answered Same Way.",
+			Email:        "dhyey123@gmail.com",
+			Expected:     constant.OTP_SENT,
 			StatusCode:   200,
 		},
+		{
+			TestCaseName: "OTP Sent Too Soon",
+			Email:        "guptaaahutosh354@gmail.com",
+			Expected:     "OTP was Sent Recently, Please Wait Before Requesting New OTP.",
+			StatusCode:   429,
+		},
 		{
 			TestCaseName: "Field Must be Required",
 			Expected:     "Email is required field.",
@@ -230,32 +286,38 @@ func TestSendOTPToUser(t *testing.T) {
 func TestVerifyOTP(t *testing.T) {
 	testCases := []struct {
 		TestCaseName string
-		OTPID        int64
-		OTP          int
+		Email        string
+		OTP          string
 		Expected     interface{}
 		StatusCode   int
 	}{
 		{
 			TestCaseName: "OTP Verified Successfully.",
-			OTPID:        954537852771565569,
-			OTP:          1099,
+			Email:        "dhyey@gmail.com",
+			OTP:          "109900",
 			StatusCode:   200,
 		},
+		{
+			TestCaseName: "OTP Already Used.",
+			Email:        "dhyey@gmail.com",
+			OTP:          "109900",
+			StatusCode:   401,
+		},
 		{
 			TestCaseName: "Field Must be Required",
-			OTP:          1,
+			OTP:          "109900",
 			StatusCode:   400,
 		},
 		{
-			TestCaseName: "Field Violates Minimum Value Constraint",
-			OTPID:        1,
-			OTP:          1,
+			TestCaseName: "Field Violates Minimum Length Constraint",
+			Email:        "dhyey@gmail.com",
+			OTP:          "1099",
 			StatusCode:   400,
 		},
 		{
-			TestCaseName: "Field Violates Maximum Value Constraint",
-			OTPID:        1,
-			OTP:          10000,
+			TestCaseName: "Field Must be Numeric",
+			Email:        "dhyey@gmail.com",
+			OTP:          "1099ab",
 			StatusCode:   400,
 		},
 	}
@@ -265,8 +327,8 @@ func TestVerifyOTP(t *testing.T) {
 			r.Post("/api/v1/users/verify-otp", NewUserController(userService).VerifyOTP)
 
 			user := request.OTP{
-				ID:  v.OTPID,
-				OTP: v.OTP,
+				Email: v.Email,
+				OTP:   v.OTP,
 			}
 			jsonValue, err := json.Marshal(user)
 			if err != nil {
@@ -287,31 +349,46 @@ func TestVerifyOTP(t *testing.T) {
 }
 
 func TestResetUserPassword(t *testing.T) {
+	resetToken, _ := utils.CreatePurposeToken(constant.OTP_PURPOSE_PASSWORD_RESET, 954488202459119617, constant.PASSWORD_RESET_TOKEN_LIFETIME)
 	testCases := []struct {
 		TestCaseName string
-		OTPID        int64
+		ResetToken   string
 		Password     string
 		Expected     interface{}
 		StatusCode   int
 	}{
 		{
 			TestCaseName: "Password Reset Done Successfully.",
-			OTPID:        954537852771565569,
-			Password:     "Dhyey123$",
-			Expected:     "User Registration Done Successfully.",
+			ResetToken:   resetToken,
+			Password:     "Dhyey@Secure2024",
+			Expected:     constant.PASSWORD_RESET_SUCCEED,
 			StatusCode:   200,
 		},
+		{
+			TestCaseName: "Reset Token Already Used.",
+			ResetToken:   resetToken,
+			Password:     "Dhyey@Secure2024",
+			Expected:     "Password Reset Token is Invalid, Expired or Already Used, Please Verify OTP Again.",
+			StatusCode:   401,
+		},
 		{
 			TestCaseName: "Field Must be Required",
-			OTPID:        954537852771565569,
+			ResetToken:   resetToken,
 			Expected:     "password is required field.",
 			StatusCode:   400,
 		},
 		{
 			TestCaseName: "Field Must be of Minimum Length",
-			OTPID:        954537852771565569,
+			ResetToken:   resetToken,
 			Password:     "Dhyey",
-			Expected:     "lastName violates minimum length constraint.",
+			Expected:     "password must be at least 8 characters long.",
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "Common Password",
+			ResetToken:   resetToken,
+			Password:     "Password123",
+			Expected:     "password is too common or found in a data breach, please choose another one.",
 			StatusCode:   400,
 		},
 	}
@@ -320,9 +397,9 @@ func TestResetUserPassword(t *testing.T) {
 		t.Run(v.TestCaseName, func(t *testing.T) {
 			r.Put("/api/v1/users/reset-password", NewUserController(userService).ResetUserPassword)
 
-			user := request.UserPasswordWithOTPID{
-				ID: v.OTPID,
-				Password: v.Password,
+			user := request.ResetPassword{
+				ResetToken: v.ResetToken,
+				Password:   v.Password,
 			}
 			jsonValue, err := json.Marshal(user)
 			if err != nil {
@@ -341,3 +418,509 @@ func TestResetUserPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestEnrollTwoFactor(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Two Factor Enrolled Successfully.",
+			UserID:       954488202459119617,
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Two Factor Already Enabled.",
+			UserID:       954497896847212546,
+			StatusCode:   409,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Post("/api/v1/users/profile/2fa", NewUserController(userService).EnrollTwoFactor)
+
+			req, _ := http.NewRequest("POST", "/api/v1/users/profile/2fa", http.NoBody)
+			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserID)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestConfirmTwoFactor(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		Code         string
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Invalid Code.",
+			UserID:       954488202459119617,
+			Code:         "000000",
+			StatusCode:   401,
+		},
+		{
+			TestCaseName: "Code Must be Numeric.",
+			UserID:       954488202459119617,
+			Code:         "abcdef",
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "Two Factor Not Enrolled.",
+			UserID:       954497896847212545,
+			Code:         "123456",
+			StatusCode:   400,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Post("/api/v1/users/profile/2fa/confirm", NewUserController(userService).ConfirmTwoFactor)
+
+			twoFactorCode := request.TwoFactorCode{
+				Code: v.Code,
+			}
+			jsonValue, _ := json.Marshal(twoFactorCode)
+			req, _ := http.NewRequest("POST", "/api/v1/users/profile/2fa/confirm", bytes.NewBuffer(jsonValue))
+			req.Header.Set("Content-Type", "application/json")
+			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserID)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		Password     string
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Wrong Password.",
+			UserID:       954497896847212546,
+			Password:     "Chirag123$",
+			StatusCode:   401,
+		},
+		{
+			TestCaseName: "Two Factor Disabled Successfully.",
+			UserID:       954497896847212546,
+			Password:     "Aashutosh1234$",
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Two Factor Not Enabled.",
+			UserID:       954497896847212546,
+			Password:     "Aashutosh1234$",
+			StatusCode:   400,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Delete("/api/v1/users/profile/2fa", NewUserController(userService).DisableTwoFactor)
+
+			userPassword := request.UserPassword{
+				Password: v.Password,
+			}
+			jsonValue, _ := json.Marshal(userPassword)
+			req, _ := http.NewRequest("DELETE", "/api/v1/users/profile/2fa", bytes.NewBuffer(jsonValue))
+			req.Header.Set("Content-Type", "application/json")
+			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserID)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		Email        string
+		OTP          string
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Email Verified Successfully.",
+			Email:        "guptaaahutosh354@gmail.com",
+			OTP:          "354354",
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "OTP Already Used.",
+			Email:        "guptaaahutosh354@gmail.com",
+			OTP:          "354354",
+			StatusCode:   410,
+		},
+		{
+			TestCaseName: "Field Must be Required",
+			OTP:          "354354",
+			StatusCode:   400,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Post("/api/v1/users/verify-email", NewUserController(userService).VerifyEmail)
+
+			otp := request.OTP{
+				Email: v.Email,
+				OTP:   v.OTP,
+			}
+			jsonValue, _ := json.Marshal(otp)
+			req, _ := http.NewRequest("POST", "/api/v1/users/verify-email", bytes.NewBuffer(jsonValue))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestResendVerificationEmail(t *testing.T) {
+	redisClient.Del(context.Background(), "otp_send:dhyey123@gmail.com", "otp_send_count:dhyey123@gmail.com")
+	testCases := []struct {
+		TestCaseName string
+		Email        string
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "No Unverified Email Found, Answered Same Way.",
+			Email:        "dhyey123@gmail.com",
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Invalid Email",
+			Email:        "dhyeypanchal2204",
+			StatusCode:   400,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Post("/api/v1/users/verify-email/resend", NewUserController(userService).ResendVerificationEmail)
+
+			userEmail := request.UserEmail{
+				Email: v.Email,
+			}
+			jsonValue, _ := json.Marshal(userEmail)
+			req, _ := http.NewRequest("POST", "/api/v1/users/verify-email/resend", bytes.NewBuffer(jsonValue))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestConfirmEmailChange(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		OTP          string
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Email Changed Successfully.",
+			UserID:       954497896847212546,
+			OTP:          "577577",
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "No Email Change Pending.",
+			UserID:       954497896847212546,
+			OTP:          "577577",
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "OTP Must be Numeric.",
+			UserID:       954497896847212546,
+			OTP:          "abcdef",
+			StatusCode:   400,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Post("/api/v1/users/profile/email/verify", NewUserController(userService).ConfirmEmailChange)
+
+			emailChangeOTP := request.EmailChangeOTP{
+				OTP: v.OTP,
+			}
+			jsonValue, _ := json.Marshal(emailChangeOTP)
+			req, _ := http.NewRequest("POST", "/api/v1/users/profile/email/verify", bytes.NewBuffer(jsonValue))
+			req.Header.Set("Content-Type", "application/json")
+			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserID)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestExportUserData(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		Format       string
+		ContentType  string
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Exported as JSON.",
+			UserID:       954488202459119617,
+			ContentType:  "application/json; charset=utf-8",
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Exported as Zip.",
+			UserID:       954488202459119617,
+			Format:       "zip",
+			ContentType:  "application/zip",
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Value Must be in Enum Values.",
+			UserID:       954488202459119617,
+			Format:       "xml",
+			ContentType:  "application/json; charset=utf-8",
+			StatusCode:   400,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Get("/api/v1/users/me/export", NewUserController(userService).ExportUserData)
+
+			req, _ := http.NewRequest("GET", "/api/v1/users/me/export?Format="+v.Format, nil)
+			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserID)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+			assert.Equal(t, v.ContentType, w.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func TestGetUserPreferences(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Preferences Fetched Successfully.",
+			UserID:       954488202459119617,
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "User Not Found.",
+			UserID:       1,
+			StatusCode:   404,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Get("/api/v1/users/profile/preferences", NewUserController(userService).GetUserPreferences)
+
+			req, _ := http.NewRequest("GET", "/api/v1/users/profile/preferences", nil)
+			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserID)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestUpdateUserPreferences(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		Preferences  request.UserPreferences
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Invalid Timezone.",
+			UserID:       954488202459119617,
+			Preferences:  request.UserPreferences{Timezone: "Mars/Olympus"},
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "Invalid Locale.",
+			UserID:       954488202459119617,
+			Preferences:  request.UserPreferences{Locale: "not a locale"},
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "Security Alert can't be Sent over Socket.",
+			UserID:       954488202459119617,
+			Preferences:  request.UserPreferences{Notifications: request.NotificationPreferences{SecurityAlert: "SOCKET"}},
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "Preferences Updated Successfully.",
+			UserID:       954488202459119617,
+			Preferences: request.UserPreferences{
+				Timezone:      "America/New_York",
+				Locale:        "en-US",
+				DateFormat:    "MM/DD/YYYY",
+				WeekStart:     "SUNDAY",
+				Notifications: request.NotificationPreferences{TaskAssigned: "EMAIL", TaskUpdated: "NONE"},
+			},
+			StatusCode: 200,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Put("/api/v1/users/profile/preferences", NewUserController(userService).UpdateUserPreferences)
+
+			jsonValue, _ := json.Marshal(v.Preferences)
+			req, _ := http.NewRequest("PUT", "/api/v1/users/profile/preferences", bytes.NewBuffer(jsonValue))
+			req.Header.Set("Content-Type", "application/json")
+			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserID)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestDeleteAccount(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		Password     string
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Wrong Password.",
+			UserID:       954497896847212546,
+			Password:     "Chirag123$",
+			StatusCode:   401,
+		},
+		{
+			TestCaseName: "Field Must be Required",
+			UserID:       954497896847212546,
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "Account Deletion Scheduled Successfully.",
+			UserID:       954497896847212546,
+			Password:     "Aashutosh1234$",
+			StatusCode:   200,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Delete("/api/v1/users/me", NewUserController(userService).DeleteAccount)
+
+			userPassword := request.UserPassword{
+				Password: v.Password,
+			}
+			jsonValue, _ := json.Marshal(userPassword)
+			req, _ := http.NewRequest("DELETE", "/api/v1/users/me", bytes.NewBuffer(jsonValue))
+			req.Header.Set("Content-Type", "application/json")
+			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserID)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
+
+func TestUpdateUserAvatar(t *testing.T) {
+	storage.SetDefault(storage.NewLocalStorage(t.TempDir(), "/avatars"))
+
+	var pngImage bytes.Buffer
+	png.Encode(&pngImage, image.NewRGBA(image.Rect(0, 0, 300, 200)))
+
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		FieldName    string
+		File         []byte
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Avatar Updated Successfully.",
+			UserID:       954488202459119617,
+			FieldName:    "avatar",
+			File:         pngImage.Bytes(),
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "File is Not an Image.",
+			UserID:       954488202459119617,
+			FieldName:    "avatar",
+			File:         []byte("this is not an image"),
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "Avatar Not Provided.",
+			UserID:       954488202459119617,
+			FieldName:    "picture",
+			File:         pngImage.Bytes(),
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "Avatar Too Large.",
+			UserID:       954488202459119617,
+			FieldName:    "avatar",
+			File:         make([]byte, constant.AVATAR_MAX_UPLOAD_SIZE+1),
+			StatusCode:   413,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			r.Put("/api/v1/users/profile/avatar", NewUserController(userService).UpdateUserAvatar)
+
+			var body bytes.Buffer
+			multipartWriter := multipart.NewWriter(&body)
+			file, _ := multipartWriter.CreateFormFile(v.FieldName, "avatar.png")
+			file.Write(v.File)
+			multipartWriter.Close()
+
+			req, _ := http.NewRequest("PUT", "/api/v1/users/profile/avatar", &body)
+			req.Header.Set("Content-Type", multipartWriter.FormDataContentType())
+			ctx := context.WithValue(req.Context(), constant.UserIdKey, v.UserID)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+
+	userDetails, err := userService.GetMyDetails(954488202459119617)
+	assert.NoError(t, err)
+	assert.NotNil(t, userDetails.Avatar)
+}
@@ -0,0 +1,26 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/service"
+	"github.com/chirag1807/task-management-system/config"
+)
+
+// DeleteScheduledAccounts anonymizes accounts whose grace period of deletion is over once at start and then after every interval.
+// it blocks, so it should be started in separate goroutine.
+func DeleteScheduledAccounts(userService service.UserService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deletedAccounts, err := userService.DeleteScheduledAccounts()
+		if err != nil {
+			config.LoggerInstance.Warning(err.Error())
+		} else {
+			config.LoggerInstance.Info(fmt.Sprintf("%d Accounts Deleted after Grace Period.", deletedAccounts))
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,26 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/service"
+	"github.com/chirag1807/task-management-system/config"
+)
+
+// CleanupExpiredOTPs deletes expired and used otps once at start and then after every interval,
+// so that otps table doesn't keep hashes which can't be used anymore. it blocks, so it should be started in separate goroutine.
+func CleanupExpiredOTPs(userService service.UserService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deletedOTPs, err := userService.DeleteExpiredOTPs()
+		if err != nil {
+			config.LoggerInstance.Warning(err.Error())
+		} else {
+			config.LoggerInstance.Info(fmt.Sprintf("%d Expired or Used OTPs Deleted.", deletedOTPs))
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,26 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/service"
+	"github.com/chirag1807/task-management-system/config"
+)
+
+// CleanupExpiredRefreshTokens deletes expired refresh tokens once at start and then after every interval,
+// so that refresh_tokens table doesn't grow forever. it blocks, so it should be started in separate goroutine.
+func CleanupExpiredRefreshTokens(authService service.AuthService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deletedTokens, err := authService.DeleteExpiredRefreshTokens()
+		if err != nil {
+			config.LoggerInstance.Warning(err.Error())
+		} else {
+			config.LoggerInstance.Info(fmt.Sprintf("%d Expired Refresh Tokens Deleted.", deletedTokens))
+		}
+		<-ticker.C
+	}
+}
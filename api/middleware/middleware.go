@@ -3,16 +3,27 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/chirag1807/task-management-system/api/service"
 	"github.com/chirag1807/task-management-system/constant"
 	errorhandling "github.com/chirag1807/task-management-system/error"
 	"github.com/chirag1807/task-management-system/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-redis/redis/v8"
 )
 
 // VerifyToken retrieves token from request header and send it to VerifyJWTToken function of utils package.
 // after that it will check that err is nil or not and if it is nil then send token expired error response from here.
-// otherwise it will set token and userId to request's context and command will go to controller section.
-func VerifyToken(flag int) func(handler http.Handler) http.Handler {
+// flag 0 accepts only access token and other flag accepts only refresh token, so that refresh token can't be used as access token.
+// for access token it also checks redis denylist, so that token revoked by logout can't be used anymore.
+// personal access tokens are accepted in place of access token, they are verified against database and their scopes are set to request's context.
+// user whose account is deactivated by admin is rejected, whether user is admin is set to request's context for RequireAdmin.
+// otherwise it will set token, token claims and userId to request's context and command will go to controller section.
+func VerifyToken(flag int, authService service.AuthService) func(handler http.Handler) http.Handler {
 	return func(handler http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			token := r.Header.Get("Authorization")
@@ -21,7 +32,28 @@ func VerifyToken(flag int) func(handler http.Handler) http.Handler {
 				return
 			}
 			token = token[7:]
-			userId, err := utils.VerifyJWTToken(token)
+			if flag == 0 && authService != nil && strings.HasPrefix(token, constant.PERSONAL_ACCESS_TOKEN_PREFIX) {
+				userId, scopes, err := authService.VerifyPersonalAccessToken(token)
+				if err != nil {
+					errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+					return
+				}
+				ctx, err := withAccountStatus(r.Context(), authService, userId)
+				if err != nil {
+					errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+					return
+				}
+				ctx = context.WithValue(ctx, constant.TokenKey, token)
+				ctx = context.WithValue(ctx, constant.ScopesKey, scopes)
+				ctx = context.WithValue(ctx, constant.UserIdKey, userId)
+				handler.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+			tokenType := constant.JWT_TYPE_ACCESS
+			if flag != 0 {
+				tokenType = constant.JWT_TYPE_REFRESH
+			}
+			tokenClaims, err := utils.VerifyJWTToken(token, tokenType)
 			if err != nil {
 				if flag == 0 {
 					errorhandling.SendErrorResponse(r, w, errorhandling.AccessTokenExpired, constant.EMPTY_STRING)
@@ -30,9 +62,136 @@ func VerifyToken(flag int) func(handler http.Handler) http.Handler {
 				}
 				return
 			}
-			ctx := context.WithValue(r.Context(), constant.TokenKey, token)
-			ctx = context.WithValue(ctx, constant.UserIdKey, userId)
+			if flag == 0 && authService != nil {
+				denied, err := authService.IsAccessTokenDenied(tokenClaims)
+				if err != nil {
+					errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+					return
+				}
+				if denied {
+					errorhandling.SendErrorResponse(r, w, errorhandling.TokenRevoked, constant.EMPTY_STRING)
+					return
+				}
+			}
+			ctx := r.Context()
+			if authService != nil {
+				ctx, err = withAccountStatus(ctx, authService, tokenClaims.UserID)
+				if err != nil {
+					errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+					return
+				}
+			}
+			ctx = context.WithValue(ctx, constant.TokenKey, token)
+			ctx = context.WithValue(ctx, constant.TokenClaimsKey, tokenClaims)
+			ctx = context.WithValue(ctx, constant.UserIdKey, tokenClaims.UserID)
+			handler.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// withAccountStatus returns AccountDeactivated error if account of the user is deactivated by admin,
+// otherwise it sets whether user is admin to given context.
+func withAccountStatus(ctx context.Context, authService service.AuthService, userId int64) (context.Context, error) {
+	accountStatus, err := authService.GetAccountStatus(userId)
+	if err != nil {
+		return ctx, err
+	}
+	if accountStatus.Deactivated {
+		return ctx, errorhandling.AccountDeactivated
+	}
+	return context.WithValue(ctx, constant.IsAdminKey, accountStatus.IsAdmin), nil
+}
+
+// RequireAdmin allows request only if authenticated user is system administrator, it must be used after VerifyToken.
+func RequireAdmin(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isAdmin, ok := r.Context().Value(constant.IsAdminKey).(bool); !ok || !isAdmin {
+			errorhandling.SendErrorResponse(r, w, errorhandling.AdminAccessRequired, constant.EMPTY_STRING)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// RequireScope allows request authenticated by personal access token only if token is granted given scope.
+// request authenticated by access token of login session has all scopes so it is always allowed.
+func RequireScope(scope string) func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scopes, ok := r.Context().Value(constant.ScopesKey).([]string)
+			if ok && !slices.Contains(scopes, scope) {
+				errorhandling.SendErrorResponse(r, w, errorhandling.InsufficientScope, constant.EMPTY_STRING)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSession rejects request authenticated by personal access token,
+// it is used for endpoints which manage login sessions and credentials of the user.
+func RequireSession(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(constant.ScopesKey).([]string); ok {
+			errorhandling.SendErrorResponse(r, w, errorhandling.PersonalAccessTokenNotAllowed, constant.EMPTY_STRING)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// RequireOrganization sets active organization of the request and role of the user in it to request's context, it must be used after VerifyToken.
+// organization is taken from OrganizationID url parameter, otherwise from X-Organization-ID header, and if neither is given then
+// the only organization of the user is used. organization which user is not member of is rejected as not found.
+func RequireOrganization(organizationService service.OrganizationService) func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userId := r.Context().Value(constant.UserIdKey).(int64)
+			organizationParam := chi.URLParam(r, constant.ORGANIZATION_ID)
+			if organizationParam == constant.EMPTY_STRING {
+				organizationParam = r.Header.Get(constant.ORGANIZATION_HEADER)
+			}
+
+			var organizationId int64
+			var role string
+			var err error
+			if organizationParam == constant.EMPTY_STRING {
+				organizationId, role, err = organizationService.GetOnlyOrganization(userId)
+			} else {
+				organizationId, err = strconv.ParseInt(organizationParam, 10, 64)
+				if err != nil {
+					errorhandling.SendErrorResponse(r, w, errorhandling.NoOrganizationFound, constant.EMPTY_STRING)
+					return
+				}
+				role, err = organizationService.GetOrganizationRole(organizationId, userId)
+			}
+			if err != nil {
+				errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), constant.OrganizationIdKey, organizationId)
+			ctx = context.WithValue(ctx, constant.OrganizationRoleKey, role)
 			handler.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// RateLimit allows only given number of requests per window from one ip address for the route, further requests get 429 with Retry-After header.
+func RateLimit(redisClient *redis.Client, name string, limit int64, window time.Duration) func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := "rate_limit:" + name + ":" + utils.GetClientInfo(r).IPAddress
+			allowed, retryAfter, err := utils.AllowRequest(redisClient, key, limit, window)
+			if err != nil {
+				errorhandling.SendErrorResponse(r, w, err, utils.CreateErrorMessage())
+				return
+			}
+			if !allowed {
+				errorhandling.SendErrorResponse(r, w, errorhandling.CreateRateLimitError(constant.TOO_MANY_REQUESTS, retryAfter), constant.EMPTY_STRING)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
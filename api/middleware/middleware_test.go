@@ -1,10 +1,12 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/chirag1807/task-management-system/constant"
 	errorhandling "github.com/chirag1807/task-management-system/error"
 	"github.com/magiconair/properties/assert"
 )
@@ -45,7 +47,7 @@ func TestVerifyToken(t *testing.T) {
 			})
 
 			rr := httptest.NewRecorder()
-			VerifyToken(v.Flag)(handler).ServeHTTP(rr, req)
+			VerifyToken(v.Flag, nil)(handler).ServeHTTP(rr, req)
 
 			if v.Expected != nil {
 				assert.Equal(t, v.Expected, errorhandling.TokenNotFound)
@@ -53,3 +55,136 @@ func TestVerifyToken(t *testing.T) {
 		})
 	}
 }
+
+func TestRequireScope(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		Scopes       []string
+		Scope        string
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Access Token Has All Scopes.",
+			Scope:        constant.SCOPE_TASKS_WRITE,
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Scope Granted to Personal Access Token.",
+			Scopes:       []string{constant.SCOPE_TASKS_READ, constant.SCOPE_TASKS_WRITE},
+			Scope:        constant.SCOPE_TASKS_WRITE,
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Scope Not Granted to Personal Access Token.",
+			Scopes:       []string{constant.SCOPE_TASKS_READ},
+			Scope:        constant.SCOPE_TASKS_WRITE,
+			StatusCode:   403,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/", nil)
+			if v.Scopes != nil {
+				req = req.WithContext(context.WithValue(req.Context(), constant.ScopesKey, v.Scopes))
+			}
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			rr := httptest.NewRecorder()
+			RequireScope(v.Scope)(handler).ServeHTTP(rr, req)
+			assert.Equal(t, v.StatusCode, rr.Code)
+		})
+	}
+}
+
+func TestRequireSession(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		Scopes       []string
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Access Token Allowed.",
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Personal Access Token Not Allowed.",
+			Scopes:       []string{constant.SCOPE_USERS_WRITE},
+			StatusCode:   403,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/", nil)
+			if v.Scopes != nil {
+				req = req.WithContext(context.WithValue(req.Context(), constant.ScopesKey, v.Scopes))
+			}
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			rr := httptest.NewRecorder()
+			RequireSession(handler).ServeHTTP(rr, req)
+			assert.Equal(t, v.StatusCode, rr.Code)
+		})
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		IsAdmin      *bool
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Admin Allowed.",
+			IsAdmin:      func() *bool { isAdmin := true; return &isAdmin }(),
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "User who is not Admin Not Allowed.",
+			IsAdmin:      func() *bool { isAdmin := false; return &isAdmin }(),
+			StatusCode:   403,
+		},
+		{
+			TestCaseName: "Account Status Not Verified.",
+			StatusCode:   403,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/", nil)
+			if v.IsAdmin != nil {
+				req = req.WithContext(context.WithValue(req.Context(), constant.IsAdminKey, *v.IsAdmin))
+			}
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			rr := httptest.NewRecorder()
+			RequireAdmin(handler).ServeHTTP(rr, req)
+			assert.Equal(t, v.StatusCode, rr.Code)
+		})
+	}
+}
+
+func TestRequireOrganization(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/", nil)
+	req = req.WithContext(context.WithValue(req.Context(), constant.UserIdKey, int64(954488202459119617)))
+	req.Header.Set(constant.ORGANIZATION_HEADER, "organization")
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rr := httptest.NewRecorder()
+	RequireOrganization(nil)(handler).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
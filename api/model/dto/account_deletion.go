@@ -0,0 +1,10 @@
+package dto
+
+// AnonymizedUser holds what is changed by anonymizing a deleted user, so that redis sets, cached tasks and avatar files can be updated once transaction is committed.
+// teams which had no member other than the user are deleted and tasks which were assigned to the user or to deleted teams are unassigned.
+type AnonymizedUser struct {
+	UserID         int64
+	AvatarKey      *string
+	DeletedTeamIDs []int64
+	UpdatedTaskIDs []int64
+}
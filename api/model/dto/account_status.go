@@ -0,0 +1,8 @@
+package dto
+
+// AccountStatus holds whether user is system administrator and whether account of the user is deactivated by admin,
+// it is checked on every authenticated request.
+type AccountStatus struct {
+	IsAdmin     bool
+	Deactivated bool
+}
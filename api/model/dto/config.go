@@ -1,12 +1,22 @@
 package dto
 
+import "crypto"
+
 type Config struct {
-	Port            uint     `mapstructure:"PORT"`
-	Database        Database `mapstructure:",squash"`
-	Redis           Redis    `mapstructure:",squash"`
-	RabbitMQ        RabbitMQ `mapstructure:",squash"`
-	SMTP            SMTP     `mapstructure:",squash"`
-	TeamsWebHookURL string   `mapstructure:"TEAMS_WEBHOOK_URL"`
+	Port              uint              `mapstructure:"PORT"`
+	Database          Database          `mapstructure:",squash"`
+	Redis             Redis             `mapstructure:",squash"`
+	RabbitMQ          RabbitMQ          `mapstructure:",squash"`
+	SMTP              SMTP              `mapstructure:",squash"`
+	JWT               JWT               `mapstructure:",squash"`
+	OIDC              OIDC              `mapstructure:",squash"`
+	OTP               OTP               `mapstructure:",squash"`
+	EmailVerification EmailVerification `mapstructure:",squash"`
+	PasswordPolicy    PasswordPolicy    `mapstructure:",squash"`
+	AccountDeletion   AccountDeletion   `mapstructure:",squash"`
+	Storage           Storage           `mapstructure:",squash"`
+	Migration         Migration         `mapstructure:",squash"`
+	TeamsWebHookURL   string            `mapstructure:"TEAMS_WEBHOOK_URL"`
 }
 
 type Database struct {
@@ -17,6 +27,7 @@ type Database struct {
 	Name             string `mapstructure:"DATABASE_NAME"`
 	TestDatabaseName string `mapstructure:"TEST_DATABASE_NAME"`
 	SSLMode          string `mapstructure:"DATABASE_SSLMODE"`
+	TestPostgresURL  string `mapstructure:"TEST_POSTGRES_URL"`
 }
 
 type Redis struct {
@@ -40,6 +51,76 @@ type SMTP struct {
 	Port          string `mapstructure:"SMTP_PORT"`
 }
 
+// JWT holds keys used for signing tokens, DisableLegacyHS256 stops accepting HS256 tokens without kid once all of them are expired after switching to key directory.
+type JWT struct {
+	KeyDirectory       string `mapstructure:"JWT_KEY_DIRECTORY"`
+	SigningKeyID       string `mapstructure:"JWT_SIGNING_KEY_ID"`
+	Issuer             string `mapstructure:"JWT_ISSUER"`
+	Audience           string `mapstructure:"JWT_AUDIENCE"`
+	DisableLegacyHS256 bool   `mapstructure:"JWT_DISABLE_LEGACY_HS256"`
+}
+
+// OIDC holds configuration of identity provider used for single sign-on, endpoints of the provider are read from its discovery document.
+type OIDC struct {
+	IssuerURL    string `mapstructure:"OIDC_ISSUER_URL"`
+	ClientID     string `mapstructure:"OIDC_CLIENT_ID"`
+	ClientSecret string `mapstructure:"OIDC_CLIENT_SECRET"`
+	RedirectURL  string `mapstructure:"OIDC_REDIRECT_URL"`
+}
+
+// OTP holds configuration of one time passwords, length defaults to constant.OTP_DEFAULT_LENGTH if it is not set.
+type OTP struct {
+	Length int `mapstructure:"OTP_LENGTH"`
+}
+
+// EmailVerification tells what unverified users are restricted from, everything is allowed if it is not set.
+type EmailVerification struct {
+	RequiredForTeams bool `mapstructure:"EMAIL_VERIFICATION_REQUIRED_FOR_TEAMS"`
+	RequiredForTasks bool `mapstructure:"EMAIL_VERIFICATION_REQUIRED_FOR_TASKS"`
+}
+
+// PasswordPolicy holds rules which new passwords must follow, min length can't be less than constant.PASSWORD_MIN_LENGTH
+// and history size is count of last passwords (including current one) which can't be reused, 0 disables it.
+// breached list file is optional file having one password per line which is checked along with bundled list of common passwords.
+type PasswordPolicy struct {
+	MinLength        int    `mapstructure:"PASSWORD_MIN_LENGTH"`
+	RequireUppercase bool   `mapstructure:"PASSWORD_REQUIRE_UPPERCASE"`
+	RequireLowercase bool   `mapstructure:"PASSWORD_REQUIRE_LOWERCASE"`
+	RequireDigit     bool   `mapstructure:"PASSWORD_REQUIRE_DIGIT"`
+	RequireSpecial   bool   `mapstructure:"PASSWORD_REQUIRE_SPECIAL"`
+	HistorySize      int    `mapstructure:"PASSWORD_HISTORY_SIZE"`
+	BreachedListFile string `mapstructure:"PASSWORD_BREACHED_LIST_FILE"`
+}
+
+// AccountDeletion holds number of days for which deletion of account is kept pending, so that user can cancel it by logging in again.
+// grace period defaults to constant.ACCOUNT_DELETION_GRACE_DAYS if it is not set.
+type AccountDeletion struct {
+	GraceDays int `mapstructure:"ACCOUNT_DELETION_GRACE_DAYS"`
+}
+
+// Storage holds directory of local disk where avatars are stored and base url from which they are served,
+// directory defaults to constant.AVATAR_STORAGE_DIRECTORY and base url defaults to constant.AVATAR_BASE_URL if they are not set.
+type Storage struct {
+	AvatarDirectory string `mapstructure:"AVATAR_STORAGE_DIRECTORY"`
+	AvatarBaseURL   string `mapstructure:"AVATAR_BASE_URL"`
+}
+
+// Migration tells whether server applies pending migrations embedded in it before it starts serving requests,
+// concurrent instances wait for each other so it is safe to enable it on every instance.
+type Migration struct {
+	OnStartup bool `mapstructure:"MIGRATE_ON_STARTUP"`
+}
+
+// JWTSecret holds secrets of secret.json, otp secret key is used as key of otp hashes and secret key is used if it is not set.
 type JWTSecret struct {
-	SecretKey string `json:"secretkey"`
+	SecretKey    string `json:"secretkey"`
+	OTPSecretKey string `json:"otpsecretkey"`
+}
+
+// JWTKey is asymmetric key used for signing and verifying jwt tokens, private key is nil for key which is kept only for verification.
+type JWTKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
 }
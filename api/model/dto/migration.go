@@ -0,0 +1,9 @@
+package dto
+
+// MigrationStatus tells whether migration of given version is applied to database, name is file name of the migration
+// without version and extension, it is empty for version which is applied but whose file is not embedded in binary.
+type MigrationStatus struct {
+	Version string
+	Name    string
+	Applied bool
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/response"
+)
+
+// NotificationRecipient holds email and preferences of the user to whom notification is sent.
+type NotificationRecipient struct {
+	UserID      int64
+	Email       string
+	Preferences response.UserPreferences
+}
+
+// TaskNotification holds details of the task which are rendered in task notification emails.
+type TaskNotification struct {
+	TaskID   int64
+	Title    string
+	Deadline time.Time
+}
@@ -0,0 +1,19 @@
+package dto
+
+// OIDCDiscoveryDocument holds endpoints of identity provider which are read from its /.well-known/openid-configuration.
+type OIDCDiscoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// OIDCClaims holds claims of verified id token which are needed for matching or provisioning the user.
+type OIDCClaims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+}
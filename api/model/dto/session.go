@@ -0,0 +1,11 @@
+package dto
+
+// SessionToken holds refresh token issued for a session along with ids of its user and session,
+// so that access token of the same session can be created from it.
+// when user has two factor authentication enabled, login issues only ChallengeToken and no session is created.
+type SessionToken struct {
+	UserID         int64
+	SessionID      int64
+	RefreshToken   string
+	ChallengeToken string
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// SprintTask holds time between which task was in the sprint along with it's current status, removed at is nil while task is in the sprint.
+type SprintTask struct {
+	TaskID    int64
+	Status    string
+	AddedAt   time.Time
+	RemovedAt *time.Time
+}
+
+// TaskStatusChange holds status from which task was moved and time of the change, as recorded in team activities.
+type TaskStatusChange struct {
+	TaskID    int64
+	From      string
+	ChangedAt time.Time
+}
@@ -0,0 +1,37 @@
+package request
+
+// AdminUserQueryParams model info
+// @Description used for retrieving all users by admin with pagination, search on name and email, and deactivated filter.
+type AdminUserQueryParams struct {
+	Limit       int    `json:"limit" example:"10" validate:"number,gte=0,max=50"`
+	Offset      int    `json:"offset" example:"0" validate:"number"`
+	Search      string `json:"search" example:"chirag" validate:"omitempty,max=255"`
+	Deactivated *bool  `json:"deactivated" example:"false"`
+}
+
+// TeamOwnershipTransfer model info
+// @Description Id of the user to whom ownership of the team is to be transferred, user is added to the team if not already a member.
+type TeamOwnershipTransfer struct {
+	TeamID  int64 `json:"-"`
+	OwnerID int64 `json:"ownerId" example:"954497896847212545" validate:"required"`
+}
+
+// AdminAuditLog model info
+// @Description Admin action which is written to audit log along with the change it describes.
+type AdminAuditLog struct {
+	AdminID      int64                  `json:"adminId" example:"954488202459119617"`
+	Action       string                 `json:"action" example:"USER-DEACTIVATED"`
+	TargetUserID *int64                 `json:"targetUserId,omitempty" example:"954497896847212545"`
+	TargetTeamID *int64                 `json:"targetTeamId,omitempty" example:"954507580144451585"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+}
+
+// AdminAuditLogQueryParams model info
+// @Description used for retrieving admin audit log from database with pagination, action, admin and target user filter.
+type AdminAuditLogQueryParams struct {
+	Limit        int    `json:"limit" example:"10" validate:"number,gte=0,max=50"`
+	Offset       int    `json:"offset" example:"0" validate:"number"`
+	Action       string `json:"action" example:"USER-DEACTIVATED" validate:"omitempty,oneof=USERS-LISTED USER-DEACTIVATED USER-REACTIVATED PASSWORD-RESET-FORCED SESSIONS-REVOKED TEAM-VIEWED TEAM-OWNERSHIP-TRANSFERRED AUDIT-LOGS-VIEWED"`
+	AdminID      int64  `json:"adminId" example:"954488202459119617" validate:"omitempty,number"`
+	TargetUserID int64  `json:"targetUserId" example:"954497896847212545" validate:"omitempty,number"`
+}
@@ -0,0 +1,10 @@
+package request
+
+// OIDCCallback model info
+// @Description Query parameters sent by identity provider while redirecting user back after login.
+type OIDCCallback struct {
+	Code             string `json:"code" schema:"code" example:"SplxlOBeZQQYbYS6WxSbIA" validate:"required"`
+	State            string `json:"state" schema:"state" example:"af0ifjsldkj" validate:"required"`
+	Error            string `json:"error" schema:"error" example:"access_denied"`
+	ErrorDescription string `json:"errorDescription" schema:"error_description" example:"User denied the request."`
+}
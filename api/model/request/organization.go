@@ -0,0 +1,16 @@
+package request
+
+// Organization model info
+// @Description Organization (workspace) with it's name, user who creates it becomes it's owner.
+type Organization struct {
+	Name      string `json:"name" example:"Rapidops" validate:"required,alphanum_with_spaces,min=3,max=48"`
+	CreatedBy int64  `json:"-"`
+}
+
+// OrganizationMember model info
+// @Description Email of the user to add in organization along with role (OWNER, ADMIN or MEMBER) given to them, role defaults to MEMBER.
+type OrganizationMember struct {
+	OrganizationID int64  `json:"-"`
+	Email          string `json:"email" example:"ridham@gmail.com" validate:"required,email"`
+	Role           string `json:"role,omitempty" example:"MEMBER" validate:"omitempty,oneof=OWNER ADMIN MEMBER"`
+}
@@ -1,13 +1,14 @@
 package request
 
-import (
-	"time"
-)
-
 // OTP model info
-// @Description OTP information with id, 4-digit otp and expiry time of otp.
+// @Description OTP information with email to which otp is sent and otp which user has entered.
 type OTP struct {
-	ID            int64     `json:"id" example:"974751326021189896" validate:"required,number"`
-	OTP           int       `json:"otp" example:"5896" validate:"required,number,min=1000,max=9999"`
-	OTPExpiryTime time.Time `json:"otpExpireTime,omitempty" example:"2024-03-25T14:29:19.000Z"`
+	Email string `json:"email" example:"chiragmakwana@gmail.com" validate:"required,email"`
+	OTP   string `json:"otp" example:"589612" validate:"required,numeric,min=6,max=10"`
+}
+
+// EmailChangeOTP model info
+// @Description OTP which is sent to new email of the user for confirming email change.
+type EmailChangeOTP struct {
+	OTP string `json:"otp" example:"589612" validate:"required,numeric,min=6,max=10"`
 }
@@ -0,0 +1,11 @@
+package request
+
+import "time"
+
+// PersonalAccessToken model info
+// @Description Personal access token information with name, scopes granted to it and optional expiry time.
+type PersonalAccessToken struct {
+	Name      string     `json:"name" example:"CI Pipeline" validate:"required,alphanum_with_spaces,min=3,max=100"`
+	Scopes    []string   `json:"scopes" example:"tasks:read,tasks:write" validate:"required,min=1,dive,oneof=tasks:read tasks:write teams:read teams:write users:read users:write"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" example:"2024-12-31T23:59:59.000Z" validate:"omitempty,time"`
+}
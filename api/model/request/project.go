@@ -0,0 +1,55 @@
+package request
+
+import "time"
+
+// Project model info
+// @Description Project groups tasks of several teams, it has name, description, status (PLANNED, ACTIVE, ON-HOLD, COMPLETED or ARCHIVED) and users and teams who can access it. User who creates it becomes it's owner.
+type Project struct {
+	Name           string  `json:"name" example:"Q3 Launch" validate:"required,alphanum_with_spaces,min=3,max=48"`
+	Description    string  `json:"description" example:"Everything which has to be shipped for launch in Q3." validate:"omitempty,max=1000"`
+	Status         string  `json:"status,omitempty" example:"ACTIVE" validate:"omitempty,oneof=PLANNED ACTIVE ON-HOLD COMPLETED ARCHIVED"`
+	MemberIDs      []int64 `json:"memberIds" example:"954497896847212545,954497896847212547" validate:"omitempty,slice_of_numbers"`
+	TeamIDs        []int64 `json:"teamIds" example:"954507580144451585" validate:"omitempty,slice_of_numbers"`
+	OwnerID        int64   `json:"-"`
+	OrganizationID int64   `json:"-"`
+}
+
+// UpdateProject model info
+// @Description Fields of project to update, members and teams who can access the project are replaced by given lists when they are sent.
+type UpdateProject struct {
+	ID          int64      `json:"-"`
+	Name        string     `json:"name" example:"Q3 Launch" validate:"omitempty,alphanum_with_spaces,min=3,max=48"`
+	Description *string    `json:"description,omitempty" example:"Everything which has to be shipped for launch in Q3." validate:"omitempty,max=1000"`
+	Status      string     `json:"status" example:"ON-HOLD" validate:"omitempty,oneof=PLANNED ACTIVE ON-HOLD COMPLETED ARCHIVED"`
+	MemberIDs   []int64    `json:"memberIds" example:"954497896847212545" validate:"omitempty,slice_of_numbers"`
+	TeamIDs     []int64    `json:"teamIds" example:"954507580144451585" validate:"omitempty,slice_of_numbers"`
+	UpdatedAt   *time.Time `json:"-"`
+}
+
+// ProjectQueryParams model info
+// @Description used for retrieving projects from database with pagination, search and status filter.
+type ProjectQueryParams struct {
+	Limit  int    `json:"limit" example:"10" validate:"number,gte=0,max=50"`
+	Offset int    `json:"offset" example:"0" validate:"number"`
+	Search string `json:"search" example:"Launch" validate:"omitempty,alphanum_with_spaces"`
+	Status string `json:"status" example:"ACTIVE" validate:"omitempty,oneof=PLANNED ACTIVE ON-HOLD COMPLETED ARCHIVED"`
+}
+
+// Milestone model info
+// @Description Milestone of project with name, description and date by which it's tasks should be completed.
+type Milestone struct {
+	ProjectID   int64     `json:"-"`
+	Name        string    `json:"name" example:"Beta Release" validate:"required,alphanum_with_spaces,min=3,max=48"`
+	Description string    `json:"description" example:"Feature complete build for beta users." validate:"omitempty,max=1000"`
+	TargetDate  time.Time `json:"targetDate" example:"2024-08-31T22:59:59.000Z" validate:"required,time"`
+}
+
+// UpdateMilestone model info
+// @Description Fields of milestone to update.
+type UpdateMilestone struct {
+	ID          int64      `json:"-"`
+	ProjectID   int64      `json:"-"`
+	Name        string     `json:"name" example:"Beta Release" validate:"omitempty,alphanum_with_spaces,min=3,max=48"`
+	Description *string    `json:"description,omitempty" example:"Feature complete build for beta users." validate:"omitempty,max=1000"`
+	TargetDate  *time.Time `json:"targetDate,omitempty" example:"2024-08-31T22:59:59.000Z" validate:"omitempty,time"`
+}
@@ -0,0 +1,56 @@
+package request
+
+import "time"
+
+// Sprint model info
+// @Description Sprint of team with name, goal and dates between which team works on it's tasks.
+type Sprint struct {
+	TeamID    int64     `json:"-"`
+	Name      string    `json:"name" example:"Sprint 12" validate:"required,alphanum_with_spaces,min=3,max=48"`
+	Goal      string    `json:"goal" example:"Ship beta of mobile app." validate:"omitempty,max=1000"`
+	StartDate time.Time `json:"startDate" example:"2024-08-19T00:00:00.000Z" validate:"required"`
+	EndDate   time.Time `json:"endDate" example:"2024-09-01T23:59:59.000Z" validate:"required"`
+	CreatedBy int64     `json:"-"`
+}
+
+// UpdateSprint model info
+// @Description Fields of sprint to update, status ACTIVE starts the sprint and team can have only one active sprint at a time.
+type UpdateSprint struct {
+	ID        int64      `json:"-"`
+	TeamID    int64      `json:"-"`
+	Name      string     `json:"name" example:"Sprint 12" validate:"omitempty,alphanum_with_spaces,min=3,max=48"`
+	Goal      *string    `json:"goal,omitempty" example:"Ship beta of mobile app." validate:"omitempty,max=1000"`
+	StartDate *time.Time `json:"startDate,omitempty" example:"2024-08-19T00:00:00.000Z"`
+	EndDate   *time.Time `json:"endDate,omitempty" example:"2024-09-01T23:59:59.000Z"`
+	Status    string     `json:"status" example:"ACTIVE" validate:"omitempty,oneof=PLANNED ACTIVE"`
+}
+
+// SprintTasks model info
+// @Description IDs of tasks of the team to plan into sprint or to move back to backlog.
+type SprintTasks struct {
+	TeamID   int64   `json:"-"`
+	SprintID int64   `json:"-"`
+	TaskIDs  []int64 `json:"taskIds" example:"954751326021189800,954751326021189801" validate:"required,slice_of_numbers"`
+}
+
+// CompleteSprint model info
+// @Description Sprint to which unfinished tasks of completed sprint are moved, they are moved to backlog if it's not sent.
+type CompleteSprint struct {
+	TeamID       int64  `json:"-"`
+	SprintID     int64  `json:"-"`
+	NextSprintID *int64 `json:"nextSprintId,omitempty" example:"954751326021189633" validate:"omitempty,number"`
+}
+
+// SprintQueryParams model info
+// @Description used for retrieving sprints of team from database with pagination and status filter, sprints are ordered from latest to oldest start date.
+type SprintQueryParams struct {
+	Limit  int    `json:"limit" example:"10" validate:"number,gte=0,max=50"`
+	Offset int    `json:"offset" example:"0" validate:"number"`
+	Status string `json:"status" example:"COMPLETED" validate:"omitempty,oneof=PLANNED ACTIVE COMPLETED"`
+}
+
+// SprintVelocityQueryParams model info
+// @Description number of last completed sprints on which velocity of team is calculated.
+type SprintVelocityQueryParams struct {
+	Limit int `json:"limit" example:"5" validate:"number,gte=0,max=50"`
+}
@@ -3,7 +3,7 @@ package request
 import "time"
 
 // Task model info
-// @Description Task information with title, description, deadline, assignee, status, priority.
+// @Description Task information with title, description, deadline, assignee, status, priority and optionally project and milestone it belongs to.
 type Task struct {
 	ID                 int64      `json:"id,omitempty" db:"id" example:"974751326021189496" validate:"number"`
 	Title              string     `json:"title" db:"title" example:"GoLang project: Task Manager" validate:"required,alphanum_with_spaces,min=4,max=48"`
@@ -17,6 +17,10 @@ type Task struct {
 	CreatedAt          time.Time  `json:"createdAt" db:"created_at" example:"2024-03-25T22:59:59.000Z"`
 	UpdatedBy          *int64     `json:"updatedBy,omitempty" db:"updated_by" example:"974751326021189896"`
 	UpdatedAt          *time.Time `json:"updatedAt,omitempty" db:"updated_at" example:"2024-03-26T12:49:539.000Z"`
+	OwnerIndividual    *int64     `json:"ownerIndividual,omitempty" db:"owner_individual" example:"974751326021189123" swaggerignore:"true"`
+	OrganizationID     int64      `json:"organizationId,omitempty" db:"organization_id" example:"954560000000000001" swaggerignore:"true"`
+	ProjectID          *int64     `json:"projectId,omitempty" db:"project_id" example:"954570000000000001" validate:"omitempty,number"`
+	MilestoneID        *int64     `json:"milestoneId,omitempty" db:"milestone_id" example:"954570000000000101" validate:"omitempty,number"`
 }
 
 type UpdateTask struct {
@@ -30,6 +34,8 @@ type UpdateTask struct {
 	Priority           string     `json:"priority" db:"priority" example:"High" validate:"omitempty,oneof=LOW MEDIUM HIGH 'VERY HIGH'"`
 	UpdatedBy          *int64     `json:"updatedBy,omitempty" db:"updated_by" example:"974751326021189896"`
 	UpdatedAt          *time.Time `json:"updatedAt,omitempty" db:"updated_at" example:"2024-03-26T12:49:539.000Z"`
+	ProjectID          *int64     `json:"projectId,omitempty" db:"project_id" example:"954570000000000001" validate:"omitempty,number"`
+	MilestoneID        *int64     `json:"milestoneId,omitempty" db:"milestone_id" example:"954570000000000101" validate:"omitempty,number"`
 }
 
 // TaskQueryParams model info
@@ -42,3 +48,13 @@ type TaskQueryParams struct {
 	Status       string `json:"status" example:"TO-DO" validate:"omitempty,oneof=TO-DO In-PROGRESS COMPLETED CLOSED"`
 	SortByFilter bool   `json:"sortByFilter" example:"true" validate:"boolean"`
 }
+
+// TaskComment model info
+// @Description Comment added on a task by it's creator or assignee.
+type TaskComment struct {
+	ID        int64     `json:"id,omitempty" example:"974751326021189496"`
+	TaskID    int64     `json:"taskId,omitempty" example:"954511608047501313" validate:"required,number"`
+	Comment   string    `json:"comment" example:"Started working on this, will update by evening." validate:"required,min=1,max=1000"`
+	CreatedBy int64     `json:"createdBy" example:"974751326021189896"`
+	CreatedAt time.Time `json:"createdAt" example:"2024-03-25T22:59:59.000Z"`
+}
@@ -14,11 +14,12 @@ type CreateTeam struct {
 // Team model info
 // @Description Team information with it's id, name, privacy (PUBLIC or PRIVATE), id of user who created it and time when it was created.
 type Team struct {
-	ID        int64     `json:"id,omitempty" example:"954751326021189633"`
-	Name      string    `json:"name" example:"Team Jupiter" validate:"required,alphanum_with_spaces,min=3,max=15"`
-	Privacy   *string   `json:"privacy,omitempty" example:"PUBLIC" validate:"omitempty,oneof=PUBLIC PRIVATE"`
-	CreatedBy int64     `json:"createdBy" example:"954751326021189799"`
-	CreatedAt time.Time `json:"createdAt,omitempty" example:"2024-03-25T22:59:59.000Z"`
+	ID             int64     `json:"id,omitempty" example:"954751326021189633"`
+	Name           string    `json:"name" example:"Team Jupiter" validate:"required,alphanum_with_spaces,min=3,max=15"`
+	Privacy        *string   `json:"privacy,omitempty" example:"PUBLIC" validate:"omitempty,oneof=PUBLIC PRIVATE"`
+	CreatedBy      int64     `json:"createdBy" example:"954751326021189799"`
+	CreatedAt      time.Time `json:"createdAt,omitempty" example:"2024-03-25T22:59:59.000Z"`
+	OrganizationID int64     `json:"-"`
 }
 
 // TeamMembers model info
@@ -37,3 +38,24 @@ type TeamQueryParams struct {
 	Search         string `json:"search" example:"Jupiter" validate:"omitempty,alphanum_with_spaces"`
 	SortByCreatedAt bool   `json:"sortByCreatedAt" example:"true" validate:"boolean"`
 }
+
+// TeamAutoAssignStrategy model info
+// @Description Strategy used to pick a member as owner of the task assigned to the team, NONE keeps task unowned.
+type TeamAutoAssignStrategy struct {
+	TeamID   int64  `json:"teamId,omitempty" example:"954751326021189633" validate:"required,number"`
+	Strategy string `json:"strategy" example:"ROUND-ROBIN" validate:"required,oneof=NONE ROUND-ROBIN LEAST-LOADED"`
+}
+
+// TeamMemberAvailability model info
+// @Description Time until which member is unavailable in the team and will be skipped by auto assignment, null marks member available again.
+type TeamMemberAvailability struct {
+	TeamID           int64      `json:"teamId,omitempty" example:"954751326021189633" validate:"required,number"`
+	UnavailableUntil *time.Time `json:"unavailableUntil" example:"2024-03-25T22:59:59.000Z" validate:"omitempty,time"`
+}
+
+// TeamAnalyticsQueryParams model info
+// @Description used for retrieving analytics of the team for a date range, both dates are inclusive and range covers last 12 weeks if they are not sent.
+type TeamAnalyticsQueryParams struct {
+	From string `json:"from" example:"2024-01-01" validate:"omitempty,datetime=2006-01-02"`
+	To   string `json:"to" example:"2024-03-31" validate:"omitempty,datetime=2006-01-02"`
+}
@@ -0,0 +1,21 @@
+package request
+
+// TeamActivity model info
+// @Description Team activity which is written along with the change it describes, like member added or task status changed.
+type TeamActivity struct {
+	TeamID   int64                  `json:"teamId" example:"954507580144451585"`
+	ActorID  int64                  `json:"actorId" example:"954488202459119617"`
+	Type     string                 `json:"type" example:"MEMBER-ADDED"`
+	TaskID   *int64                 `json:"taskId,omitempty" example:"954511608047501313"`
+	MemberID *int64                 `json:"memberId,omitempty" example:"954497896847212545"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+}
+
+// TeamActivityQueryParams model info
+// @Description used for retrieving team activity feed from database with pagination, activity type and actor filter.
+type TeamActivityQueryParams struct {
+	Limit   int    `json:"limit" example:"10" validate:"number,gte=0,max=50"`
+	Offset  int    `json:"offset" example:"0" validate:"number"`
+	Type    string `json:"type" example:"MEMBER-ADDED" validate:"omitempty,oneof=MEMBER-ADDED MEMBER-REMOVED MEMBER-LEFT TASK-CREATED TASK-REASSIGNED TASK-STATUS-CHANGED COMMENT-ADDED"`
+	ActorID int64  `json:"actorId" example:"954488202459119617" validate:"omitempty,number"`
+}
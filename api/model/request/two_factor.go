@@ -0,0 +1,20 @@
+package request
+
+// TwoFactorCode model info
+// @Description Code generated by authenticator app, used for confirming two factor enrollment.
+type TwoFactorCode struct {
+	Code string `json:"code" example:"287082" validate:"required,numeric,len=6"`
+}
+
+// TwoFactorLogin model info
+// @Description Challenge token returned by login along with code of authenticator app or one of the recovery codes.
+type TwoFactorLogin struct {
+	ChallengeToken string `json:"challengeToken" example:"9f86d081884c7d659a2feaa0c55ad015" validate:"required"`
+	Code           string `json:"code" example:"287082" validate:"required,min=6,max=11"`
+}
+
+// UserPassword model info
+// @Description Current password of the user, used for confirming sensitive changes of account.
+type UserPassword struct {
+	Password string `json:"password" example:"Chirag123$" validate:"required,min=8"`
+}
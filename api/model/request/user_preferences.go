@@ -0,0 +1,19 @@
+package request
+
+// UserPreferences model info
+// @Description Preferences of the user to update, only given fields are updated and rest are kept as they are.
+type UserPreferences struct {
+	Timezone      string                  `json:"timezone" example:"Asia/Kolkata" validate:"omitempty,timezone"`
+	Locale        string                  `json:"locale" example:"en-IN" validate:"omitempty,bcp47_language_tag"`
+	DateFormat    string                  `json:"dateFormat" example:"DD/MM/YYYY" validate:"omitempty,oneof=YYYY-MM-DD DD/MM/YYYY MM/DD/YYYY"`
+	WeekStart     string                  `json:"weekStart" example:"MONDAY" validate:"omitempty,oneof=MONDAY SUNDAY SATURDAY"`
+	Notifications NotificationPreferences `json:"notifications"`
+}
+
+// NotificationPreferences model info
+// @Description Channel over which user wants to receive notification of each event, security alerts can't be sent over socket.
+type NotificationPreferences struct {
+	TaskAssigned  string `json:"taskAssigned" example:"EMAIL" validate:"omitempty,oneof=EMAIL SOCKET NONE"`
+	TaskUpdated   string `json:"taskUpdated" example:"SOCKET" validate:"omitempty,oneof=EMAIL SOCKET NONE"`
+	SecurityAlert string `json:"securityAlert" example:"EMAIL" validate:"omitempty,oneof=EMAIL NONE"`
+}
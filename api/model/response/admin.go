@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+// AdminUser model info
+// @Description User information as seen by admin, privacy settings of the user are not applied and account status is included.
+type AdminUser struct {
+	User
+	IsAdmin               bool       `json:"isAdmin" example:"false"`
+	DeactivatedAt         *time.Time `json:"deactivatedAt,omitempty" example:"2024-03-25T22:59:59.000Z"`
+	PasswordResetRequired bool       `json:"passwordResetRequired" example:"false"`
+	DeletedAt             *time.Time `json:"deletedAt,omitempty" example:"2024-03-25T22:59:59.000Z"`
+}
+
+// TeamDetails model info
+// @Description Team information along with all of its members, as seen by admin.
+type TeamDetails struct {
+	Team    Team   `json:"team"`
+	Members []User `json:"members"`
+}
+
+// AdminAuditLog model info
+// @Description Audit log entry of admin action with admin who performed it, target user or team and time when it happened.
+type AdminAuditLog struct {
+	ID           int64                  `json:"id" example:"974751326021189896"`
+	AdminID      int64                  `json:"adminId" example:"954488202459119617"`
+	Action       string                 `json:"action" example:"USER-DEACTIVATED"`
+	TargetUserID *int64                 `json:"targetUserId,omitempty" example:"954497896847212545"`
+	TargetTeamID *int64                 `json:"targetTeamId,omitempty" example:"954507580144451585"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+	CreatedAt    time.Time              `json:"createdAt" example:"2024-03-25T22:59:59.000Z"`
+}
@@ -0,0 +1,22 @@
+package response
+
+// Dashboard model info
+// @Description Home page of the user in the organization. my tasks are tasks assigned to the user or to teams of the user, recently updated tasks
+// also include tasks created by the user. today and week are taken in timezone and week start of the user.
+type Dashboard struct {
+	OpenTasksByStatus   map[string]int  `json:"openTasksByStatus"`
+	DueToday            []Task          `json:"dueToday"`
+	DueThisWeek         []Task          `json:"dueThisWeek"`
+	Overdue             []Task          `json:"overdue"`
+	RecentlyUpdated     []Task          `json:"recentlyUpdated"`
+	BusiestTeams        []DashboardTeam `json:"busiestTeams"`
+	UnreadNotifications int64           `json:"unreadNotifications" example:"3"`
+}
+
+// DashboardTeam model info
+// @Description Team of the user along with number of open tasks assigned to it.
+type DashboardTeam struct {
+	ID        int64  `json:"id" example:"954507580144451585"`
+	Name      string `json:"name" example:"Jupiter"`
+	OpenTasks int    `json:"openTasks" example:"7"`
+}
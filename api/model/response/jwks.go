@@ -0,0 +1,20 @@
+package response
+
+// JWK model info
+// @Description Public key which can be used for verifying tokens issued by task manager, rsa keys have n and e and ed25519 keys have crv and x.
+type JWK struct {
+	KeyType   string `json:"kty" example:"RSA"`
+	KeyID     string `json:"kid" example:"2024-10-key"`
+	Use       string `json:"use" example:"sig"`
+	Algorithm string `json:"alg" example:"RS256"`
+	N         string `json:"n,omitempty" example:"0vx7agoebGcQSuu..."`
+	E         string `json:"e,omitempty" example:"AQAB"`
+	Curve     string `json:"crv,omitempty" example:"Ed25519"`
+	X         string `json:"x,omitempty" example:"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"`
+}
+
+// JWKS model info
+// @Description Set of public keys which are currently active for verifying tokens.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
@@ -0,0 +1,24 @@
+package response
+
+import "time"
+
+// Organization model info
+// @Description Organization (workspace) with it's id, name, user who created it, time when it was created and role of the requesting user in it.
+type Organization struct {
+	ID        int64     `json:"id" example:"954560000000000001"`
+	Name      string    `json:"name" example:"Rapidops"`
+	CreatedBy *int64    `json:"createdBy,omitempty" example:"954488202459119617"`
+	CreatedAt time.Time `json:"createdAt" example:"2024-03-25T22:59:59.000Z"`
+	Role      string    `json:"role" example:"OWNER"`
+}
+
+// OrganizationMember model info
+// @Description Member of organization with their role and time when they joined, email is left out if member has hidden it from the viewer.
+type OrganizationMember struct {
+	ID        int64     `json:"id" example:"954497896847212545"`
+	FirstName string    `json:"firstName" example:"Ridham"`
+	LastName  string    `json:"lastName" example:"Chauhan"`
+	Email     string    `json:"email,omitempty" example:"ridham@gmail.com"`
+	Role      string    `json:"role" example:"MEMBER"`
+	JoinedAt  time.Time `json:"joinedAt" example:"2024-03-25T22:59:59.000Z"`
+}
@@ -0,0 +1,23 @@
+package response
+
+import "time"
+
+// PersonalAccessToken model info
+// @Description Personal access token information, token itself is never returned after its creation.
+type PersonalAccessToken struct {
+	ID         int64      `json:"id" example:"974751326021189896"`
+	Name       string     `json:"name" example:"CI Pipeline"`
+	Scopes     []string   `json:"scopes" example:"tasks:read,tasks:write"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty" example:"2024-12-31T23:59:59.000Z"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" example:"2024-03-26T10:15:00.000Z"`
+	CreatedAt  time.Time  `json:"createdAt" example:"2024-03-25T22:59:59.000Z"`
+}
+
+// CreatedPersonalAccessToken model info
+// @Description Newly created personal access token, it is shown only once so it must be stored safely.
+type CreatedPersonalAccessToken struct {
+	Code    string `json:"code" example:"200 OK"`
+	Message string `json:"message" example:"Personal Access Token Created Successfully, Copy It Now as It won't be Shown Again."`
+	ID      int64  `json:"id" example:"974751326021189896"`
+	Token   string `json:"token" example:"tms_pat_9f86d081884c7d659a2feaa0c55ad015"`
+}
@@ -0,0 +1,33 @@
+package response
+
+import "time"
+
+// Project model info
+// @Description Project with it's owner, status and ids of users and teams who can access it.
+type Project struct {
+	ID             int64      `json:"id" example:"954570000000000001"`
+	OrganizationID int64      `json:"organizationId" example:"954560000000000001"`
+	Name           string     `json:"name" example:"Q3 Launch"`
+	Description    string     `json:"description" example:"Everything which has to be shipped for launch in Q3."`
+	OwnerID        int64      `json:"ownerId" example:"954488202459119617"`
+	Status         string     `json:"status" example:"ACTIVE"`
+	CreatedAt      time.Time  `json:"createdAt" example:"2024-03-25T22:59:59.000Z"`
+	UpdatedAt      *time.Time `json:"updatedAt,omitempty" example:"2024-03-26T12:49:53.000Z"`
+	MemberIDs      []int64    `json:"memberIds" example:"954497896847212545"`
+	TeamIDs        []int64    `json:"teamIds" example:"954507580144451585"`
+}
+
+// Milestone model info
+// @Description Milestone of project along with it's progress, milestone is at risk when any of it's open tasks is due after target date.
+type Milestone struct {
+	ID             int64     `json:"id" example:"954570000000000101"`
+	ProjectID      int64     `json:"projectId" example:"954570000000000001"`
+	Name           string    `json:"name" example:"Beta Release"`
+	Description    string    `json:"description" example:"Feature complete build for beta users."`
+	TargetDate     time.Time `json:"targetDate" example:"2024-08-31T22:59:59.000Z"`
+	CreatedAt      time.Time `json:"createdAt" example:"2024-03-25T22:59:59.000Z"`
+	TotalTasks     int       `json:"totalTasks" example:"12"`
+	CompletedTasks int       `json:"completedTasks" example:"7"`
+	AtRiskTasks    int       `json:"atRiskTasks" example:"1"`
+	AtRisk         bool      `json:"atRisk" example:"true"`
+}
@@ -0,0 +1,14 @@
+package response
+
+import "time"
+
+// Session model info
+// @Description Active login session of the user with device and ip address it is used from, current is true for the session of requesting access token.
+type Session struct {
+	ID         int64     `json:"id" example:"974751326021189896"`
+	UserAgent  string    `json:"userAgent" example:"Mozilla/5.0 (X11; Linux x86_64)"`
+	IPAddress  string    `json:"ipAddress" example:"127.0.0.1"`
+	CreatedAt  time.Time `json:"createdAt" example:"2024-03-25T22:59:59.000Z"`
+	LastUsedAt time.Time `json:"lastUsedAt" example:"2024-03-26T10:15:00.000Z"`
+	Current    bool      `json:"current" example:"true"`
+}
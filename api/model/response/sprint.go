@@ -0,0 +1,46 @@
+package response
+
+import "time"
+
+// Sprint model info
+// @Description Sprint of team along with number of it's tasks and number of them completed, counts of completed sprint are the ones at the time of completion.
+type Sprint struct {
+	ID             int64      `json:"id" example:"954580000000000001"`
+	TeamID         int64      `json:"teamId" example:"954507580144451585"`
+	Name           string     `json:"name" example:"Sprint 12"`
+	Goal           string     `json:"goal" example:"Ship beta of mobile app."`
+	StartDate      time.Time  `json:"startDate" example:"2024-08-19T00:00:00.000Z"`
+	EndDate        time.Time  `json:"endDate" example:"2024-09-01T23:59:59.000Z"`
+	Status         string     `json:"status" example:"ACTIVE"`
+	CreatedBy      int64      `json:"createdBy" example:"954488202459119617"`
+	CreatedAt      time.Time  `json:"createdAt" example:"2024-08-16T10:00:00.000Z"`
+	CompletedAt    *time.Time `json:"completedAt,omitempty" example:"2024-09-01T18:00:00.000Z"`
+	PlannedTasks   int        `json:"plannedTasks" example:"12"`
+	CompletedTasks int        `json:"completedTasks" example:"9"`
+}
+
+// SprintBurndown model info
+// @Description Number of tasks remaining in sprint at the end of each day of sprint up to today, along with ideal number of remaining tasks.
+type SprintBurndown struct {
+	SprintID  int64               `json:"sprintId" example:"954580000000000001"`
+	StartDate time.Time           `json:"startDate" example:"2024-08-19T00:00:00.000Z"`
+	EndDate   time.Time           `json:"endDate" example:"2024-09-01T23:59:59.000Z"`
+	Days      []SprintBurndownDay `json:"days"`
+}
+
+// SprintBurndownDay model info
+// @Description Tasks in sprint, remaining and completed tasks at the end of the day.
+type SprintBurndownDay struct {
+	Date                time.Time `json:"date" example:"2024-08-19T00:00:00.000Z"`
+	TotalTasks          int       `json:"totalTasks" example:"12"`
+	RemainingTasks      int       `json:"remainingTasks" example:"10"`
+	CompletedTasks      int       `json:"completedTasks" example:"2"`
+	IdealRemainingTasks float64   `json:"idealRemainingTasks" example:"11.14"`
+}
+
+// SprintVelocity model info
+// @Description Planned and completed tasks of last completed sprints of team, from latest to oldest, and average number of tasks completed per sprint.
+type SprintVelocity struct {
+	Sprints               []Sprint `json:"sprints"`
+	AverageCompletedTasks float64  `json:"averageCompletedTasks" example:"8.5"`
+}
@@ -17,4 +17,18 @@ type Task struct {
 	CreatedAt          time.Time  `json:"createdAt" example:"2024-03-25T22:59:59.000Z"`
 	UpdatedBy          *int64     `json:"updatedBy,omitempty" example:"974751326021189896"`
 	UpdatedAt          *time.Time `json:"updatedAt,omitempty" example:"2024-03-26T12:49:539.000Z"`
+	OwnerIndividual    *int64     `json:"ownerIndividual,omitempty" example:"974751326021189123"`
+	OrganizationID     int64      `json:"organizationId" example:"954560000000000001"`
+	ProjectID          *int64     `json:"projectId,omitempty" example:"954570000000000001"`
+	MilestoneID        *int64     `json:"milestoneId,omitempty" example:"954570000000000101"`
+}
+
+// TaskComment model info
+// @Description Comment added on a task by it's creator or assignee.
+type TaskComment struct {
+	ID        int64     `json:"id" example:"974751326021189496"`
+	TaskID    int64     `json:"taskId" example:"954511608047501313"`
+	Comment   string    `json:"comment" example:"Started working on this, will update by evening."`
+	CreatedBy int64     `json:"createdBy" example:"974751326021189896"`
+	CreatedAt time.Time `json:"createdAt" example:"2024-03-25T22:59:59.000Z"`
 }
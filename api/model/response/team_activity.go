@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+// TeamActivity model info
+// @Description Team activity feed entry with actor, activity type, related task or member and time when it happened.
+type TeamActivity struct {
+	ID        int64                  `json:"id" example:"974751326021189896"`
+	TeamID    int64                  `json:"teamId" example:"954507580144451585"`
+	ActorID   int64                  `json:"actorId" example:"954488202459119617"`
+	Type      string                 `json:"type" example:"TASK-STATUS-CHANGED"`
+	TaskID    *int64                 `json:"taskId,omitempty" example:"954511608047501313"`
+	MemberID  *int64                 `json:"memberId,omitempty" example:"954497896847212545"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	CreatedAt time.Time              `json:"createdAt" example:"2024-03-25T22:59:59.000Z"`
+}
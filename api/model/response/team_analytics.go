@@ -0,0 +1,61 @@
+package response
+
+import "time"
+
+// TeamAnalytics model info
+// @Description Analytics of tasks assigned to the team for a date range, to is exclusive end of the range.
+type TeamAnalytics struct {
+	TeamID      int64                      `json:"teamId" example:"954507580144451585"`
+	From        time.Time                  `json:"from" example:"2024-01-01T00:00:00.000Z"`
+	To          time.Time                  `json:"to" example:"2024-04-01T00:00:00.000Z"`
+	Throughput  []TeamWeeklyThroughput     `json:"throughput"`
+	CycleTime   TeamCycleTime              `json:"cycleTime"`
+	Overdue     TeamOverdueTasks           `json:"overdue"`
+	ByPriority  []TeamPriorityDistribution `json:"byPriority"`
+	ByAssignee  []TeamAssigneeDistribution `json:"byAssignee"`
+	GeneratedAt time.Time                  `json:"generatedAt" example:"2024-03-25T22:59:59.000Z"`
+}
+
+// TeamWeeklyThroughput model info
+// @Description Number of tasks created and completed in the week starting on Monday, counts of first and last week only cover days in the range.
+type TeamWeeklyThroughput struct {
+	WeekStart      time.Time `json:"weekStart" example:"2024-03-18T00:00:00.000Z"`
+	CreatedTasks   int       `json:"createdTasks" example:"6"`
+	CompletedTasks int       `json:"completedTasks" example:"4"`
+}
+
+// TeamCycleTime model info
+// @Description Hours taken by tasks completed in the range from first moving to IN-PROGRESS to completion, percentiles are null if no task is completed.
+type TeamCycleTime struct {
+	CompletedTasks int      `json:"completedTasks" example:"12"`
+	MedianHours    *float64 `json:"medianHours" example:"30.5"`
+	P85Hours       *float64 `json:"p85Hours" example:"72"`
+}
+
+// TeamOverdueTasks model info
+// @Description Tasks due in the range which are either still open after their deadline or completed after it.
+type TeamOverdueTasks struct {
+	DueTasks          int     `json:"dueTasks" example:"20"`
+	OverdueTasks      int     `json:"overdueTasks" example:"3"`
+	OverduePercentage float64 `json:"overduePercentage" example:"15"`
+}
+
+// TeamPriorityDistribution model info
+// @Description Number of tasks created in the range with the priority, by their current status.
+type TeamPriorityDistribution struct {
+	Priority       string `json:"priority" example:"HIGH"`
+	TotalTasks     int    `json:"totalTasks" example:"8"`
+	OpenTasks      int    `json:"openTasks" example:"3"`
+	CompletedTasks int    `json:"completedTasks" example:"5"`
+}
+
+// TeamAssigneeDistribution model info
+// @Description Number of tasks created in the range which are owned by the member, by their current status. member is null for tasks without owner.
+type TeamAssigneeDistribution struct {
+	MemberID       *int64 `json:"memberId" example:"954497896847212547"`
+	FirstName      string `json:"firstName,omitempty" example:"Jay"`
+	LastName       string `json:"lastName,omitempty" example:"Shah"`
+	TotalTasks     int    `json:"totalTasks" example:"8"`
+	OpenTasks      int    `json:"openTasks" example:"3"`
+	CompletedTasks int    `json:"completedTasks" example:"5"`
+}
@@ -0,0 +1,26 @@
+package response
+
+// TwoFactorEnrollment model info
+// @Description Secret and otpauth uri which is to be added in authenticator app of the user.
+type TwoFactorEnrollment struct {
+	Code    string `json:"code" example:"200 OK"`
+	Message string `json:"message" example:"Scan the URI in Your Authenticator App and Confirm It with a Code."`
+	Secret  string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	URI     string `json:"uri" example:"otpauth://totp/Task%20Management%20System:chiragmakwana@gmail.com?algorithm=SHA1&digits=6&issuer=Task+Management+System&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+// RecoveryCodes model info
+// @Description One time recovery codes which can be used in place of authenticator app code, these are shown only once.
+type RecoveryCodes struct {
+	Code          string   `json:"code" example:"200 OK"`
+	Message       string   `json:"message" example:"Two Factor Authentication Enabled Successfully, Store Recovery Codes Safely."`
+	RecoveryCodes []string `json:"recoveryCodes" example:"3f9a1-0c2d4,8b7e6-5a4c3"`
+}
+
+// TwoFactorChallenge model info
+// @Description Short lived challenge token returned by login in place of tokens when two factor authentication is enabled.
+type TwoFactorChallenge struct {
+	Code           string `json:"code" example:"200 OK"`
+	Message        string `json:"message" example:"Two Factor Authentication is Required, Send Code with Challenge Token to Complete Login."`
+	ChallengeToken string `json:"challengeToken" example:"9f86d081884c7d659a2feaa0c55ad015"`
+}
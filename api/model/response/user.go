@@ -1,15 +1,44 @@
 package response
 
 // User model info
-// @Description User information with id, first name, last name, bio, email, password and privacy.
+// @Description User information with id, first name, last name, bio, email, password, privacy settings, whether email is verified and avatar.
+// @Description email and bio are left out if user has hidden them from the viewer.
 type User struct {
-	ID        int64  `json:"id" example:"974751326021189896"`
-	FirstName string `json:"firstName" example:"Chirag"`
-	LastName  string `json:"lastName" example:"Makwana"`
-	Bio       string `json:"bio" example:"Junior Software Engineer at ZURU TECH INDIA."`
-	Email     string `json:"email" example:"chiragmakwana@gmail.com"`
-	Password  string `json:"password" example:"Chirag123$,omitempty"`
-	Privacy   string `json:"privacy" example:"PUBLIC"`
+	ID            int64           `json:"id" example:"974751326021189896"`
+	FirstName     string          `json:"firstName" example:"Chirag"`
+	LastName      string          `json:"lastName" example:"Makwana"`
+	Bio           string          `json:"bio,omitempty" example:"Junior Software Engineer at ZURU TECH INDIA."`
+	Email         string          `json:"email,omitempty" example:"chiragmakwana@gmail.com"`
+	Password      string          `json:"password" example:"Chirag123$,omitempty"`
+	Privacy       PrivacySettings `json:"privacy"`
+	EmailVerified bool            `json:"emailVerified" example:"true"`
+	Avatar        *Avatar         `json:"avatar,omitempty"`
+}
+
+// PrivacySettings model info
+// @Description Who can see email, bio and teams of the user (EVERYONE, TEAMMATES or NOBODY), whether user can be found in search and who can assign tasks to the user.
+type PrivacySettings struct {
+	EmailVisibility string `json:"emailVisibility" example:"TEAMMATES"`
+	BioVisibility   string `json:"bioVisibility" example:"EVERYONE"`
+	TeamsVisibility string `json:"teamsVisibility" example:"TEAMMATES"`
+	Discoverable    bool   `json:"discoverable" example:"true"`
+	AssignableBy    string `json:"assignableBy" example:"TEAMMATES"`
+}
+
+// Avatar model info
+// @Description Urls of square thumbnails of avatar of the user in small (64px), medium (128px) and large (256px) size.
+type Avatar struct {
+	Small  string `json:"small" example:"http://localhost:9090/avatars/974751326021189896/9f86d081884c7d659a2feaa0c55ad015-64.png"`
+	Medium string `json:"medium" example:"http://localhost:9090/avatars/974751326021189896/9f86d081884c7d659a2feaa0c55ad015-128.png"`
+	Large  string `json:"large" example:"http://localhost:9090/avatars/974751326021189896/9f86d081884c7d659a2feaa0c55ad015-256.png"`
+}
+
+// UserAvatar model info
+// @Description Send urls of avatar of the user to response once it is uploaded.
+type UserAvatar struct {
+	Code    string `json:"code" example:"200 OK"`
+	Message string `json:"message" example:"Avatar Updated Successfully."`
+	Avatar  Avatar `json:"avatar"`
 }
 
 // UserWithTokens model info
@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+// UserDataExport model info
+// @Description Copy of personal data of the user with profile, preferences, teams, tasks created by and assigned to the user, sessions and comments.
+type UserDataExport struct {
+	ExportedAt    time.Time       `json:"exportedAt" example:"2024-03-25T22:59:59.000Z"`
+	Profile       User            `json:"profile"`
+	Preferences   UserPreferences `json:"preferences"`
+	Teams         []Team          `json:"teams"`
+	CreatedTasks  []Task          `json:"createdTasks"`
+	AssignedTasks []Task          `json:"assignedTasks"`
+	Sessions      []Session       `json:"sessions"`
+	Comments      []TaskComment   `json:"comments"`
+}
@@ -0,0 +1,27 @@
+package response
+
+// UserPreferences model info
+// @Description Preferences of the user with timezone and locale in which dates are shown, date format, first day of week and notification channels.
+type UserPreferences struct {
+	Timezone      string                  `json:"timezone" example:"Asia/Kolkata"`
+	Locale        string                  `json:"locale" example:"en-IN"`
+	DateFormat    string                  `json:"dateFormat" example:"DD/MM/YYYY"`
+	WeekStart     string                  `json:"weekStart" example:"MONDAY"`
+	Notifications NotificationPreferences `json:"notifications"`
+}
+
+// NotificationPreferences model info
+// @Description Channel (EMAIL, SOCKET or NONE) over which user receives notification of each event.
+type NotificationPreferences struct {
+	TaskAssigned  string `json:"taskAssigned" example:"EMAIL"`
+	TaskUpdated   string `json:"taskUpdated" example:"SOCKET"`
+	SecurityAlert string `json:"securityAlert" example:"EMAIL"`
+}
+
+// UserPreferencesUpdated model info
+// @Description Send updated preferences of the user to response.
+type UserPreferencesUpdated struct {
+	Code        string          `json:"code" example:"200 OK"`
+	Message     string          `json:"message" example:"Preferences Updated Successfully."`
+	Preferences UserPreferences `json:"preferences"`
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/dto"
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/jackc/pgx/v5"
+)
+
+// AnonymizeUser removes personal data of the user within given transaction, row of the user is kept with anonymous name and email
+// so that tasks, comments and activities which are created or updated by the user keep pointing to it.
+// ownership of teams created by the user is given to another member of the team and teams having no other member are deleted.
+// user is removed from all organizations and projects, projects owned by the user are given to an owner of their organization.
+func AnonymizeUser(ctx context.Context, tx pgx.Tx, userID int64) (dto.AnonymizedUser, error) {
+	anonymizedUser := dto.AnonymizedUser{UserID: userID}
+
+	ownedTeamIDs, err := queryIDs(ctx, tx, `SELECT id FROM teams WHERE created_by = $1`, userID)
+	if err != nil {
+		return anonymizedUser, err
+	}
+	for _, teamID := range ownedTeamIDs {
+		var newOwnerID int64
+		err := tx.QueryRow(ctx, `SELECT member_id FROM team_members WHERE team_id = $1 AND member_id <> $2 ORDER BY member_id LIMIT 1`, teamID, userID).Scan(&newOwnerID)
+		if err == nil {
+			_, err = tx.Exec(ctx, `UPDATE teams SET created_by = $1 WHERE id = $2`, newOwnerID, teamID)
+			if err != nil {
+				return anonymizedUser, err
+			}
+			continue
+		}
+		if err.Error() != constant.PG_NO_ROWS {
+			return anonymizedUser, err
+		}
+
+		taskIDs, err := queryIDs(ctx, tx, `UPDATE tasks SET assignee_team = NULL WHERE assignee_team = $1 RETURNING id`, teamID)
+		if err != nil {
+			return anonymizedUser, err
+		}
+		anonymizedUser.UpdatedTaskIDs = append(anonymizedUser.UpdatedTaskIDs, taskIDs...)
+		for _, query := range []string{`DELETE FROM team_activities WHERE team_id = $1`, `DELETE FROM team_members WHERE team_id = $1`, `DELETE FROM project_teams WHERE team_id = $1`,
+			`DELETE FROM sprint_tasks WHERE sprint_id IN (SELECT id FROM sprints WHERE team_id = $1)`, `DELETE FROM sprints WHERE team_id = $1`, `DELETE FROM teams WHERE id = $1`} {
+			if _, err := tx.Exec(ctx, query, teamID); err != nil {
+				return anonymizedUser, err
+			}
+		}
+		anonymizedUser.DeletedTeamIDs = append(anonymizedUser.DeletedTeamIDs, teamID)
+	}
+
+	leftTeamIDs, err := queryIDs(ctx, tx, `DELETE FROM team_members WHERE member_id = $1 RETURNING team_id`, userID)
+	if err != nil {
+		return anonymizedUser, err
+	}
+	activities := make([]request.TeamActivity, 0, len(leftTeamIDs))
+	for _, teamID := range leftTeamIDs {
+		activities = append(activities, request.TeamActivity{TeamID: teamID, ActorID: userID, Type: constant.TEAM_ACTIVITY_MEMBER_LEFT, MemberID: &userID})
+	}
+	if _, err := InsertTeamActivities(ctx, tx, activities); err != nil {
+		return anonymizedUser, err
+	}
+	_, err = tx.Exec(ctx, `UPDATE teams SET last_auto_assigned_member = NULL WHERE last_auto_assigned_member = $1`, userID)
+	if err != nil {
+		return anonymizedUser, err
+	}
+
+	taskIDs, err := queryIDs(ctx, tx, `UPDATE tasks SET assignee_individual = NULL, owner_individual = NULL
+	WHERE assignee_individual = $1 OR owner_individual = $1 RETURNING id`, userID)
+	if err != nil {
+		return anonymizedUser, err
+	}
+	anonymizedUser.UpdatedTaskIDs = append(anonymizedUser.UpdatedTaskIDs, taskIDs...)
+
+	// organizations in which user is the only owner get their earliest joined member as owner.
+	_, err = tx.Exec(ctx, `UPDATE organization_members SET role = 'OWNER' WHERE (organization_id, member_id) IN (SELECT DISTINCT ON (om.organization_id) om.organization_id,
+	om.member_id FROM organization_members as om WHERE om.member_id <> $1 AND om.organization_id IN (SELECT organization_id FROM organization_members WHERE member_id = $1 AND role = 'OWNER')
+	AND NOT EXISTS (SELECT 1 FROM organization_members WHERE organization_id = om.organization_id AND member_id <> $1 AND role = 'OWNER') ORDER BY om.organization_id, om.joined_at)`, userID)
+	if err != nil {
+		return anonymizedUser, err
+	}
+	// projects of the user are given to earliest joined owner of their organization.
+	_, err = tx.Exec(ctx, `UPDATE projects as p SET owner_id = COALESCE((SELECT om.member_id FROM organization_members as om WHERE om.organization_id = p.organization_id
+	AND om.member_id <> $1 AND om.role = 'OWNER' ORDER BY om.joined_at LIMIT 1), p.owner_id) WHERE p.owner_id = $1`, userID)
+	if err != nil {
+		return anonymizedUser, err
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM project_members WHERE member_id = $1`, userID)
+	if err != nil {
+		return anonymizedUser, err
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM organization_members WHERE member_id = $1`, userID)
+	if err != nil {
+		return anonymizedUser, err
+	}
+
+	var email string
+	err = tx.QueryRow(ctx, `SELECT email, avatar_key FROM users WHERE id = $1`, userID).Scan(&email, &anonymizedUser.AvatarKey)
+	if err != nil {
+		return anonymizedUser, err
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM otps WHERE email = $1`, email)
+	if err != nil {
+		return anonymizedUser, err
+	}
+	for _, table := range []string{"refresh_tokens", "user_sessions", "two_factor_recovery_codes", "user_two_factor", "personal_access_tokens", "user_identities", "password_history", "user_preferences"} {
+		if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE user_id = $1`, userID); err != nil {
+			return anonymizedUser, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE users SET first_name = $1, last_name = $2, bio = '', email = $3, password = NULL, email_visibility = 'NOBODY',
+	bio_visibility = 'NOBODY', teams_visibility = 'NOBODY', discoverable = false, assignable_by = 'NOBODY', email_verified = false, pending_email = NULL, avatar_key = NULL, deletion_scheduled_at = NULL, deleted_at = $4 WHERE id = $5`,
+		constant.DELETED_USER_FIRST_NAME, constant.DELETED_USER_LAST_NAME, strconv.FormatInt(userID, 10)+constant.DELETED_USER_EMAIL_DOMAIN, time.Now().UTC(), userID)
+	if err != nil {
+		return anonymizedUser, err
+	}
+	return anonymizedUser, nil
+}
+
+// queryIDs runs query returning single id column within given transaction and returns scanned ids.
+func queryIDs(ctx context.Context, tx pgx.Tx, query string, args ...interface{}) ([]int64, error) {
+	ids := make([]int64, 0)
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return ids, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
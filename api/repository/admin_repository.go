@@ -0,0 +1,508 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/go-redis/redis/v8"
+	socketio "github.com/googollee/go-socket.io"
+	"github.com/jackc/pgx/v5"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// AdminRepository is used by system administrators only, every method writes admin audit log
+// in the same transaction as the action it describes, including actions which only read data.
+type AdminRepository interface {
+	GetAllUsers(adminId int64, queryParams request.AdminUserQueryParams) ([]response.AdminUser, error)
+	DeactivateUser(adminId int64, userId int64) error
+	ReactivateUser(adminId int64, userId int64) error
+	ForcePasswordReset(adminId int64, userId int64) error
+	RevokeUserSessions(adminId int64, userId int64) error
+	GetTeam(adminId int64, teamId int64) (response.TeamDetails, error)
+	TransferTeamOwnership(adminId int64, ownershipTransfer request.TeamOwnershipTransfer) error
+	GetAuditLogs(adminId int64, queryParams request.AdminAuditLogQueryParams) ([]response.AdminAuditLog, error)
+}
+
+type adminRepository struct {
+	dbConn       *pgx.Conn
+	redisClient  *redis.Client
+	rabbitmqConn *amqp.Connection
+	socketServer *socketio.Server
+}
+
+func NewAdminRepo(dbConn *pgx.Conn, redisClient *redis.Client, rabbitmqConn *amqp.Connection, socketServer *socketio.Server) AdminRepository {
+	return adminRepository{
+		dbConn:       dbConn,
+		redisClient:  redisClient,
+		rabbitmqConn: rabbitmqConn,
+		socketServer: socketServer,
+	}
+}
+
+// InsertAdminAuditLog writes given admin action to audit log using the transaction of the action.
+// audit log is append-only, there is no way to update or delete its entries.
+func InsertAdminAuditLog(ctx context.Context, tx pgx.Tx, auditLog request.AdminAuditLog) error {
+	_, err := tx.Exec(ctx, `INSERT INTO admin_audit_logs (admin_id, action, target_user_id, target_team_id, details) VALUES ($1, $2, $3, $4, $5)`,
+		auditLog.AdminID, auditLog.Action, auditLog.TargetUserID, auditLog.TargetTeamID, auditLog.Details)
+	return err
+}
+
+// GetAllUsers returns all users including those who are not discoverable or deleted, privacy settings are not applied for admin.
+func (a adminRepository) GetAllUsers(adminId int64, queryParams request.AdminUserQueryParams) ([]response.AdminUser, error) {
+	ctx := context.Background()
+	usersSlice := make([]response.AdminUser, 0)
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return usersSlice, err
+	}
+
+	query, args := CreateQueryForParamsOfGetAllUsers(`SELECT id, first_name, last_name, bio, email, email_visibility, bio_visibility, teams_visibility, discoverable, assignable_by,
+	email_verified, avatar_key, is_admin, deactivated_at, password_reset_required, deleted_at FROM users WHERE true`, []interface{}{}, queryParams)
+	users, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		tx.Rollback(ctx)
+		return usersSlice, err
+	}
+
+	for users.Next() {
+		var user response.AdminUser
+		var avatarKey *string
+		if err := users.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Bio, &user.Email, &user.Privacy.EmailVisibility, &user.Privacy.BioVisibility,
+			&user.Privacy.TeamsVisibility, &user.Privacy.Discoverable, &user.Privacy.AssignableBy, &user.EmailVerified, &avatarKey, &user.IsAdmin,
+			&user.DeactivatedAt, &user.PasswordResetRequired, &user.DeletedAt); err != nil {
+			users.Close()
+			tx.Rollback(ctx)
+			return usersSlice, err
+		}
+		user.Avatar = utils.AvatarOf(avatarKey)
+		usersSlice = append(usersSlice, user)
+	}
+	users.Close()
+
+	details := map[string]interface{}{"search": queryParams.Search, "limit": queryParams.Limit, "offset": queryParams.Offset}
+	if queryParams.Deactivated != nil {
+		details["deactivated"] = *queryParams.Deactivated
+	}
+	err = InsertAdminAuditLog(ctx, tx, request.AdminAuditLog{AdminID: adminId, Action: constant.ADMIN_USERS_LISTED, Details: details})
+	if err != nil {
+		tx.Rollback(ctx)
+		return usersSlice, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return usersSlice, err
+	}
+	return usersSlice, nil
+}
+
+// CreateQueryForParamsOfGetAllUsers appends search on name and email and deactivated filter as query arguments,
+// and orders users by id so that pagination stays stable.
+func CreateQueryForParamsOfGetAllUsers(query string, args []interface{}, queryParams request.AdminUserQueryParams) (string, []interface{}) {
+	if queryParams.Search != constant.EMPTY_STRING {
+		args = append(args, "%"+queryParams.Search+"%")
+		placeholder := "$" + strconv.Itoa(len(args))
+		query += " AND (first_name ILIKE " + placeholder + " OR last_name ILIKE " + placeholder + " OR email ILIKE " + placeholder + ")"
+	}
+	if queryParams.Deactivated != nil {
+		if *queryParams.Deactivated {
+			query += " AND deactivated_at IS NOT NULL"
+		} else {
+			query += " AND deactivated_at IS NULL"
+		}
+	}
+	query += " ORDER BY id"
+	query += fmt.Sprintf(" LIMIT %d", queryParams.Limit)
+	query += fmt.Sprintf(" OFFSET %d", queryParams.Offset)
+	return query, args
+}
+
+// DeactivateUser deactivates account of the user and revokes all of its sessions, deactivated user can neither login
+// nor use access token or personal access token issued earlier. admin can't deactivate own account.
+func (a adminRepository) DeactivateUser(adminId int64, userId int64) error {
+	if adminId == userId {
+		return errorhandling.AdminCannotDeactivateSelf
+	}
+
+	ctx := context.Background()
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deactivated bool
+	err = tx.QueryRow(ctx, `SELECT deactivated_at IS NOT NULL FROM users WHERE id = $1 FOR UPDATE`, userId).Scan(&deactivated)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err.Error() == constant.PG_NO_ROWS {
+			return errorhandling.NoUserFound
+		}
+		return err
+	}
+	if deactivated {
+		tx.Rollback(ctx)
+		return errorhandling.UserAlreadyDeactivated
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE users SET deactivated_at = $1 WHERE id = $2`, time.Now(), userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = RevokeAllSessionsOfUser(ctx, tx, userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = InsertAdminAuditLog(ctx, tx, request.AdminAuditLog{AdminID: adminId, Action: constant.ADMIN_USER_DEACTIVATED, TargetUserID: &userId})
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return utils.DenyAllAccessTokensOfUser(a.redisClient, userId, time.Now())
+}
+
+// ReactivateUser reactivates deactivated account of the user, user has to login again as sessions revoked by deactivation stay revoked.
+func (a adminRepository) ReactivateUser(adminId int64, userId int64) error {
+	ctx := context.Background()
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deactivated bool
+	err = tx.QueryRow(ctx, `SELECT deactivated_at IS NOT NULL FROM users WHERE id = $1 FOR UPDATE`, userId).Scan(&deactivated)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err.Error() == constant.PG_NO_ROWS {
+			return errorhandling.NoUserFound
+		}
+		return err
+	}
+	if !deactivated {
+		tx.Rollback(ctx)
+		return errorhandling.UserNotDeactivated
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE users SET deactivated_at = NULL WHERE id = $1`, userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = InsertAdminAuditLog(ctx, tx, request.AdminAuditLog{AdminID: adminId, Action: constant.ADMIN_USER_REACTIVATED, TargetUserID: &userId})
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return nil
+}
+
+// ForcePasswordReset logs the user out from all sessions, revokes personal access tokens of the user and sends password reset otp to email of the user,
+// current password can't be used for login until user resets it via VerifyOTP and ResetUserPassword APIs.
+func (a adminRepository) ForcePasswordReset(adminId int64, userId int64) error {
+	ctx := context.Background()
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	var email string
+	err = tx.QueryRow(ctx, `SELECT email FROM users WHERE id = $1 FOR UPDATE`, userId).Scan(&email)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err.Error() == constant.PG_NO_ROWS {
+			return errorhandling.NoUserFound
+		}
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE users SET password_reset_required = true WHERE id = $1`, userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = RevokeAllSessionsOfUser(ctx, tx, userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = RevokeAllPersonalAccessTokensOfUser(ctx, tx, userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = InsertAdminAuditLog(ctx, tx, request.AdminAuditLog{AdminID: adminId, Action: constant.ADMIN_PASSWORD_RESET_FORCED, TargetUserID: &userId})
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = SendOTPEmail(ctx, tx, a.rabbitmqConn, email, constant.OTP_PURPOSE_PASSWORD_RESET, "Password Reset Required")
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return utils.DenyAllAccessTokensOfUser(a.redisClient, userId, time.Now())
+}
+
+// RevokeUserSessions revokes every session, refresh token and personal access token of the user and all access tokens issued to user till now.
+func (a adminRepository) RevokeUserSessions(adminId int64, userId int64) error {
+	ctx := context.Background()
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	var userCount int
+	err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE id = $1`, userId).Scan(&userCount)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if userCount == 0 {
+		tx.Rollback(ctx)
+		return errorhandling.NoUserFound
+	}
+
+	err = RevokeAllSessionsOfUser(ctx, tx, userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = RevokeAllPersonalAccessTokensOfUser(ctx, tx, userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = InsertAdminAuditLog(ctx, tx, request.AdminAuditLog{AdminID: adminId, Action: constant.ADMIN_SESSIONS_REVOKED, TargetUserID: &userId})
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return utils.DenyAllAccessTokensOfUser(a.redisClient, userId, time.Now())
+}
+
+// GetTeam returns any team along with all of its members, privacy settings of members are not applied for admin.
+func (a adminRepository) GetTeam(adminId int64, teamId int64) (response.TeamDetails, error) {
+	ctx := context.Background()
+	teamDetails := response.TeamDetails{Members: make([]response.User, 0)}
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return teamDetails, err
+	}
+
+	team := &teamDetails.Team
+	err = tx.QueryRow(ctx, `SELECT id, name, created_by, created_at, team_privacy, auto_assign_strategy, organization_id FROM teams WHERE id = $1`,
+		teamId).Scan(&team.ID, &team.Name, &team.CreatedBy, &team.CreatedAt, &team.TeamPrivacy, &team.AutoAssignStrategy, &team.OrganizationID)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err.Error() == constant.PG_NO_ROWS {
+			return teamDetails, errorhandling.NoTeamFound
+		}
+		return teamDetails, err
+	}
+
+	members, err := tx.Query(ctx, `SELECT id, first_name, last_name, bio, email, email_visibility, bio_visibility, teams_visibility, discoverable, assignable_by,
+	email_verified, avatar_key FROM users WHERE id IN (SELECT member_id FROM team_members WHERE team_id = $1) ORDER BY id`, teamId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return teamDetails, err
+	}
+
+	for members.Next() {
+		var member response.User
+		var avatarKey *string
+		if err := members.Scan(&member.ID, &member.FirstName, &member.LastName, &member.Bio, &member.Email, &member.Privacy.EmailVisibility, &member.Privacy.BioVisibility,
+			&member.Privacy.TeamsVisibility, &member.Privacy.Discoverable, &member.Privacy.AssignableBy, &member.EmailVerified, &avatarKey); err != nil {
+			members.Close()
+			tx.Rollback(ctx)
+			return teamDetails, err
+		}
+		member.Avatar = utils.AvatarOf(avatarKey)
+		teamDetails.Members = append(teamDetails.Members, member)
+	}
+	members.Close()
+
+	err = InsertAdminAuditLog(ctx, tx, request.AdminAuditLog{AdminID: adminId, Action: constant.ADMIN_TEAM_VIEWED, TargetTeamID: &teamId})
+	if err != nil {
+		tx.Rollback(ctx)
+		return teamDetails, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return teamDetails, err
+	}
+	return teamDetails, nil
+}
+
+// TransferTeamOwnership makes given user owner of the team, user is added to the team if not already a member.
+// ownership can't be transferred to deactivated user.
+func (a adminRepository) TransferTeamOwnership(adminId int64, ownershipTransfer request.TeamOwnershipTransfer) error {
+	ctx := context.Background()
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	var previousOwnerId int64
+	err = tx.QueryRow(ctx, `SELECT created_by FROM teams WHERE id = $1 FOR UPDATE`, ownershipTransfer.TeamID).Scan(&previousOwnerId)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err.Error() == constant.PG_NO_ROWS {
+			return errorhandling.NoTeamFound
+		}
+		return err
+	}
+
+	var deactivated, isMember, isOrganizationMember bool
+	err = tx.QueryRow(ctx, `SELECT deactivated_at IS NOT NULL, EXISTS (SELECT 1 FROM team_members WHERE team_id = $2 AND member_id = $1),
+	EXISTS (SELECT 1 FROM organization_members WHERE member_id = $1 AND organization_id = (SELECT organization_id FROM teams WHERE id = $2)) FROM users WHERE id = $1`,
+		ownershipTransfer.OwnerID, ownershipTransfer.TeamID).Scan(&deactivated, &isMember, &isOrganizationMember)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err.Error() == constant.PG_NO_ROWS {
+			return errorhandling.NoUserFound
+		}
+		return err
+	}
+	if deactivated {
+		tx.Rollback(ctx)
+		return errorhandling.NewOwnerDeactivated
+	}
+	if !isOrganizationMember {
+		tx.Rollback(ctx)
+		return errorhandling.NotMemberOfOrganization
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE teams SET created_by = $1 WHERE id = $2`, ownershipTransfer.OwnerID, ownershipTransfer.TeamID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	var activitiesToInsert []request.TeamActivity
+	if !isMember {
+		_, err = tx.Exec(ctx, `INSERT INTO team_members (team_id, member_id) VALUES ($1, $2)`, ownershipTransfer.TeamID, ownershipTransfer.OwnerID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return err
+		}
+		activitiesToInsert = append(activitiesToInsert, request.TeamActivity{TeamID: ownershipTransfer.TeamID, ActorID: adminId, Type: constant.TEAM_ACTIVITY_MEMBER_ADDED,
+			MemberID: &ownershipTransfer.OwnerID})
+	}
+	activities, err := InsertTeamActivities(ctx, tx, activitiesToInsert)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	// ids are kept as strings in details so that they don't lose precision as json numbers.
+	err = InsertAdminAuditLog(ctx, tx, request.AdminAuditLog{AdminID: adminId, Action: constant.ADMIN_TEAM_OWNERSHIP_TRANSFERRED, TargetUserID: &ownershipTransfer.OwnerID,
+		TargetTeamID: &ownershipTransfer.TeamID, Details: map[string]interface{}{"previousOwnerId": strconv.FormatInt(previousOwnerId, 10)}})
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	if !isMember {
+		a.redisClient.SAdd(ctx, "user:"+strconv.FormatInt(ownershipTransfer.OwnerID, 10)+":teams", ownershipTransfer.TeamID)
+	}
+	EmitTeamActivities(a.socketServer, activities)
+	return nil
+}
+
+// GetAuditLogs returns admin audit log from latest to oldest entry, viewing it is recorded in audit log as well.
+func (a adminRepository) GetAuditLogs(adminId int64, queryParams request.AdminAuditLogQueryParams) ([]response.AdminAuditLog, error) {
+	ctx := context.Background()
+	auditLogsSlice := make([]response.AdminAuditLog, 0)
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return auditLogsSlice, err
+	}
+
+	query, args := CreateQueryForParamsOfGetAuditLogs(`SELECT id, admin_id, action, target_user_id, target_team_id, details, created_at FROM admin_audit_logs WHERE true`,
+		[]interface{}{}, queryParams)
+	auditLogs, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		tx.Rollback(ctx)
+		return auditLogsSlice, err
+	}
+
+	for auditLogs.Next() {
+		var auditLog response.AdminAuditLog
+		if err := auditLogs.Scan(&auditLog.ID, &auditLog.AdminID, &auditLog.Action, &auditLog.TargetUserID, &auditLog.TargetTeamID, &auditLog.Details,
+			&auditLog.CreatedAt); err != nil {
+			auditLogs.Close()
+			tx.Rollback(ctx)
+			return auditLogsSlice, err
+		}
+		auditLogsSlice = append(auditLogsSlice, auditLog)
+	}
+	auditLogs.Close()
+
+	err = InsertAdminAuditLog(ctx, tx, request.AdminAuditLog{AdminID: adminId, Action: constant.ADMIN_AUDIT_LOGS_VIEWED,
+		Details: map[string]interface{}{"action": queryParams.Action, "limit": queryParams.Limit, "offset": queryParams.Offset}})
+	if err != nil {
+		tx.Rollback(ctx)
+		return auditLogsSlice, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return auditLogsSlice, err
+	}
+	return auditLogsSlice, nil
+}
+
+// CreateQueryForParamsOfGetAuditLogs appends action, admin and target user filters as query arguments,
+// and orders audit log from latest to oldest entry with id as tie breaker so that pagination stays stable.
+func CreateQueryForParamsOfGetAuditLogs(query string, args []interface{}, queryParams request.AdminAuditLogQueryParams) (string, []interface{}) {
+	if queryParams.Action != constant.EMPTY_STRING {
+		args = append(args, queryParams.Action)
+		query += " AND action = $" + strconv.Itoa(len(args))
+	}
+	if queryParams.AdminID != 0 {
+		args = append(args, queryParams.AdminID)
+		query += " AND admin_id = $" + strconv.Itoa(len(args))
+	}
+	if queryParams.TargetUserID != 0 {
+		args = append(args, queryParams.TargetUserID)
+		query += " AND target_user_id = $" + strconv.Itoa(len(args))
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	query += fmt.Sprintf(" LIMIT %d", queryParams.Limit)
+	query += fmt.Sprintf(" OFFSET %d", queryParams.Offset)
+	return query, args
+}
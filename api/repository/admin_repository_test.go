@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllUsers(t *testing.T) {
+	deactivated := true
+	testCases := []struct {
+		TestCaseName string
+		QueryParams  request.AdminUserQueryParams
+		Expected     []int64
+	}{
+		{
+			TestCaseName: "User who is not Discoverable Found by Email.",
+			QueryParams:  request.AdminUserQueryParams{Limit: 10, Search: "guptaaahutosh355@"},
+			Expected:     []int64{954497896847212546},
+		},
+		{
+			TestCaseName: "Deactivated Users Only.",
+			QueryParams:  request.AdminUserQueryParams{Limit: 10, Deactivated: &deactivated},
+			Expected:     []int64{954497896847212549},
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			users, err := NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer).GetAllUsers(954488202459119617, v.QueryParams)
+			assert.NoError(t, err)
+			userIds := make([]int64, 0)
+			for _, user := range users {
+				userIds = append(userIds, user.ID)
+			}
+			assert.Equal(t, v.Expected, userIds)
+		})
+	}
+}
+
+func TestDeactivateUser(t *testing.T) {
+	// audit log is append-only, so entries written by earlier runs are counted as well.
+	countAuditLogs := func() int {
+		var auditLogCount int
+		dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM admin_audit_logs WHERE action = $1 AND target_user_id = $2`, constant.ADMIN_USER_DEACTIVATED,
+			954497896847212548).Scan(&auditLogCount)
+		return auditLogCount
+	}
+	auditLogCount := countAuditLogs()
+
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Admin Deactivating Self.",
+			UserID:       954488202459119617,
+			Expected:     errorhandling.AdminCannotDeactivateSelf,
+		},
+		{
+			TestCaseName: "User Deactivated Successfully.",
+			UserID:       954497896847212548,
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "User Already Deactivated.",
+			UserID:       954497896847212548,
+			Expected:     errorhandling.UserAlreadyDeactivated,
+		},
+		{
+			TestCaseName: "User Not Found.",
+			UserID:       1,
+			Expected:     errorhandling.NoUserFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer).DeactivateUser(954488202459119617, v.UserID)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+
+	accountStatus, err := NewAuthRepo(dbConn, redisClient, rabbitmqConn).GetAccountStatus(954497896847212548)
+	assert.NoError(t, err)
+	assert.Equal(t, true, accountStatus.Deactivated)
+	assert.Equal(t, auditLogCount+1, countAuditLogs())
+}
+
+func TestReactivateUser(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "User Reactivated Successfully.",
+			UserID:       954497896847212548,
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "User Not Deactivated.",
+			UserID:       954497896847212548,
+			Expected:     errorhandling.UserNotDeactivated,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer).ReactivateUser(954488202459119617, v.UserID)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestForcePasswordReset(t *testing.T) {
+	_, token, err := NewAuthRepo(dbConn, redisClient, rabbitmqConn).CreatePersonalAccessToken(954497896847212548, request.PersonalAccessToken{Name: "Sync Script", Scopes: []string{"tasks:read"}})
+	assert.NoError(t, err)
+
+	err = NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer).ForcePasswordReset(954488202459119617, 954497896847212548)
+	assert.NoError(t, err)
+
+	_, _, err = NewAuthRepo(dbConn, redisClient, rabbitmqConn).VerifyPersonalAccessToken(token)
+	assert.Equal(t, errorhandling.PersonalAccessTokenInvalid, err)
+
+	_, _, err = NewAuthRepo(dbConn, redisClient, rabbitmqConn).UserLogin(request.UserCredentials{Email: "meetpatel@gmail.com", Password: "Aashutosh1234$"}, request.ClientInfo{})
+	assert.Equal(t, errorhandling.PasswordResetRequired, err)
+
+	err = NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer).ForcePasswordReset(954488202459119617, 1)
+	assert.Equal(t, errorhandling.NoUserFound, err)
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	_, token, err := NewAuthRepo(dbConn, redisClient, rabbitmqConn).CreatePersonalAccessToken(954497896847212548, request.PersonalAccessToken{Name: "Sync Script", Scopes: []string{"tasks:read"}})
+	assert.NoError(t, err)
+
+	err = NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer).RevokeUserSessions(954488202459119617, 954497896847212548)
+	assert.NoError(t, err)
+
+	_, _, err = NewAuthRepo(dbConn, redisClient, rabbitmqConn).VerifyPersonalAccessToken(token)
+	assert.Equal(t, errorhandling.PersonalAccessTokenInvalid, err)
+
+	denied, err := utils.IsAccessTokenDenied(redisClient, utils.JWTTokenClaims{UserID: 954497896847212548, IssuedAt: time.Now().Add(-time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, true, denied)
+
+	err = NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer).RevokeUserSessions(954488202459119617, 1)
+	assert.Equal(t, errorhandling.NoUserFound, err)
+}
+
+func TestGetTeam(t *testing.T) {
+	teamDetails, err := NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer).GetTeam(954488202459119617, 954507580144451587)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(954497896847212547), teamDetails.Team.CreatedBy)
+	assert.Equal(t, 1, len(teamDetails.Members))
+
+	_, err = NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer).GetTeam(954488202459119617, 1)
+	assert.Equal(t, errorhandling.NoTeamFound, err)
+}
+
+func TestTransferTeamOwnership(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		OwnerID      int64
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Ownership Transferred to User who is not Member.",
+			OwnerID:      954497896847212545,
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "Ownership Transferred Back to Member.",
+			OwnerID:      954488202459119617,
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "New Owner Not Member of Organization.",
+			OwnerID:      954497896847212548,
+			Expected:     errorhandling.NotMemberOfOrganization,
+		},
+		{
+			TestCaseName: "New Owner Deactivated.",
+			OwnerID:      954497896847212549,
+			Expected:     errorhandling.NewOwnerDeactivated,
+		},
+		{
+			TestCaseName: "New Owner Not Found.",
+			OwnerID:      1,
+			Expected:     errorhandling.NoUserFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer).TransferTeamOwnership(954488202459119617, request.TeamOwnershipTransfer{
+				TeamID:  954507580144451586,
+				OwnerID: v.OwnerID,
+			})
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+
+	var memberCount int
+	dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM team_members WHERE team_id = 954507580144451586 AND member_id = 954497896847212545`).Scan(&memberCount)
+	assert.Equal(t, 1, memberCount)
+}
+
+func TestGetAuditLogs(t *testing.T) {
+	auditLogs, err := NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer).GetAuditLogs(954488202459119617, request.AdminAuditLogQueryParams{
+		Limit:        10,
+		Action:       constant.ADMIN_USER_REACTIVATED,
+		TargetUserID: 954497896847212548,
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, auditLogs)
+	for _, auditLog := range auditLogs {
+		assert.Equal(t, constant.ADMIN_USER_REACTIVATED, auditLog.Action)
+	}
+}
+
+func TestAuditLogsAreAppendOnly(t *testing.T) {
+	ctx := context.Background()
+	var auditLogId int64
+	err := dbConn.QueryRow(ctx, `INSERT INTO admin_audit_logs (admin_id, action, target_user_id) VALUES ($1, $2, $3) RETURNING id`,
+		954488202459119617, constant.ADMIN_USER_REACTIVATED, 954497896847212548).Scan(&auditLogId)
+	assert.NoError(t, err)
+
+	_, err = dbConn.Exec(ctx, `UPDATE admin_audit_logs SET target_user_id = $1 WHERE id = $2`, 954497896847212545, auditLogId)
+	assert.Error(t, err)
+	_, err = dbConn.Exec(ctx, `DELETE FROM admin_audit_logs WHERE id = $1`, auditLogId)
+	assert.Error(t, err)
+
+	var targetUserId int64
+	err = dbConn.QueryRow(ctx, `SELECT target_user_id FROM admin_audit_logs WHERE id = $1`, auditLogId).Scan(&targetUserId)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(954497896847212548), targetUserId)
+}
@@ -2,97 +2,775 @@ package repository
 
 import (
 	"context"
-	"fmt"
 	"time"
 
+	"github.com/chirag1807/task-management-system/api/model/dto"
 	"github.com/chirag1807/task-management-system/api/model/request"
 	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/config"
 	"github.com/chirag1807/task-management-system/constant"
 	errorhandling "github.com/chirag1807/task-management-system/error"
 	"github.com/chirag1807/task-management-system/utils"
+	"github.com/go-redis/redis/v8"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 type AuthRepository interface {
 	UserRegistration(user request.User) (int64, error)
-	UserLogin(user request.UserCredentials) (response.User, string, error)
-	RefreshToken(refreshToken string) (int64, string, error)
+	UserLogin(user request.UserCredentials, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error)
+	VerifyTwoFactorLogin(twoFactorLogin request.TwoFactorLogin, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error)
+	RefreshToken(refreshToken string, clientInfo request.ClientInfo) (dto.SessionToken, error)
+	Logout(userID int64, refreshToken string, accessToken utils.JWTTokenClaims) error
+	LogoutAll(userID int64) error
+	DeleteExpiredRefreshTokens() (int64, error)
+	GetActiveSessions(userID int64, currentSessionID int64) ([]response.Session, error)
+	RevokeSession(userID int64, sessionID int64) error
+	IsAccessTokenDenied(accessToken utils.JWTTokenClaims) (bool, error)
+	GetAccountStatus(userID int64) (dto.AccountStatus, error)
+	CreatePersonalAccessToken(userID int64, personalAccessToken request.PersonalAccessToken) (int64, string, error)
+	GetPersonalAccessTokens(userID int64) ([]response.PersonalAccessToken, error)
+	RevokePersonalAccessToken(userID int64, tokenID int64) error
+	VerifyPersonalAccessToken(token string) (int64, []string, error)
+	StartOIDCLogin() (string, error)
+	CompleteOIDCLogin(oidcCallback request.OIDCCallback, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error)
 }
 
 type authRepository struct {
-	dbConn *pgx.Conn
+	dbConn       *pgx.Conn
+	redisClient  *redis.Client
+	rabbitmqConn *amqp.Connection
 }
 
-func NewAuthRepo(dbConn *pgx.Conn) AuthRepository {
+func NewAuthRepo(dbConn *pgx.Conn, redisClient *redis.Client, rabbitmqConn *amqp.Connection) AuthRepository {
 	return authRepository{
-		dbConn: dbConn,
+		dbConn:       dbConn,
+		redisClient:  redisClient,
+		rabbitmqConn: rabbitmqConn,
 	}
 }
 
+// UserRegistration creates unverified user and sends email verification otp to its email in the same transaction.
 func (a authRepository) UserRegistration(user request.User) (int64, error) {
+	ctx := context.Background()
 	var userID int64
-	fmt.Println(user.Email)
-	rows := a.dbConn.QueryRow(context.Background(), `INSERT INTO users (first_name, last_name, bio, email, password, privacy) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, user.FirstName, user.LastName, user.Bio, user.Email, user.Password, user.Privacy)
-	err := rows.Scan(&userID)
+	tx, err := a.dbConn.Begin(ctx)
 	if err != nil {
+		return 0, err
+	}
+	privacy := DefaultPrivacySettings(user.PrivacySettings)
+	rows := tx.QueryRow(ctx, `INSERT INTO users (first_name, last_name, bio, email, password, email_visibility, bio_visibility, teams_visibility, discoverable, assignable_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`, user.FirstName, user.LastName, user.Bio, user.Email, user.Password,
+		privacy.EmailVisibility, privacy.BioVisibility, privacy.TeamsVisibility, privacy.Discoverable, privacy.AssignableBy)
+	err = rows.Scan(&userID)
+	if err != nil {
+		tx.Rollback(ctx)
 		pgErr, ok := err.(*pgconn.PgError)
 		if ok && pgErr.Code == constant.PG_Duplicate_Error_Code {
 			return 0, errorhandling.DuplicateEmailFound
 		}
 		return 0, err
 	}
+	err = SavePasswordHistory(ctx, tx, userID, user.Password)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	err = SendOTPEmail(ctx, tx, a.rabbitmqConn, user.Email, constant.OTP_PURPOSE_EMAIL_VERIFICATION, "Email Verification")
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
 	return userID, nil
 }
 
-func (a authRepository) UserLogin(user request.UserCredentials) (response.User, string, error) {
+func (a authRepository) UserLogin(user request.UserCredentials, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error) {
+	ctx := context.Background()
 	var dbUser response.User
-	rows := a.dbConn.QueryRow(context.Background(), `SELECT id, first_name, last_name, bio, email, password, privacy FROM users WHERE email = $1`, user.Email)
-	err := rows.Scan(&dbUser.ID, &dbUser.FirstName, &dbUser.LastName, &dbUser.Bio, &dbUser.Email, &dbUser.Password, &dbUser.Privacy)
+	var avatarKey *string
+	var passwordResetRequired bool
+	rows := a.dbConn.QueryRow(ctx, `SELECT id, first_name, last_name, bio, email, password, email_visibility, bio_visibility, teams_visibility, discoverable, assignable_by, email_verified, avatar_key, password_reset_required FROM users WHERE email = $1`, user.Email)
+	err := rows.Scan(&dbUser.ID, &dbUser.FirstName, &dbUser.LastName, &dbUser.Bio, &dbUser.Email, &dbUser.Password, &dbUser.Privacy.EmailVisibility, &dbUser.Privacy.BioVisibility, &dbUser.Privacy.TeamsVisibility, &dbUser.Privacy.Discoverable, &dbUser.Privacy.AssignableBy, &dbUser.EmailVerified, &avatarKey, &passwordResetRequired)
+	dbUser.Avatar = utils.AvatarOf(avatarKey)
 
 	if err != nil && err.Error() == constant.PG_NO_ROWS {
-		return response.User{}, constant.EMPTY_STRING, errorhandling.NoUserFound
+		return response.User{}, dto.SessionToken{}, errorhandling.NoUserFound
+	}
+
+	lockoutKey := "login_lockout:" + dbUser.Email
+	lockedFor, err := a.redisClient.TTL(ctx, lockoutKey).Result()
+	if err != nil {
+		return response.User{}, dto.SessionToken{}, err
+	}
+	if lockedFor > 0 {
+		return response.User{}, dto.SessionToken{}, errorhandling.CreateRateLimitError(constant.ACCOUNT_LOCKED, lockedFor)
 	}
 
 	passwordMatched := utils.VerifyPassword(user.Password, dbUser.Password)
 	if !passwordMatched {
-		return response.User{}, constant.EMPTY_STRING, errorhandling.PasswordNotMatched
+		return response.User{}, dto.SessionToken{}, a.recordFailedLogin(ctx, dbUser.ID, dbUser.Email, clientInfo)
+	}
+	a.redisClient.Del(ctx, "login_failures:"+dbUser.Email)
+	// password which admin has forced to be reset can't be used for login anymore.
+	if passwordResetRequired {
+		return response.User{}, dto.SessionToken{}, errorhandling.PasswordResetRequired
 	}
 
-	refreshToken, err := utils.CreateJWTToken(time.Now().Add(time.Hour*24*7), dbUser.ID)
+	return a.startSession(ctx, dbUser, clientInfo)
+}
+
+// recordFailedLogin counts failed password checks of the account, after too many failures in short time
+// account is locked temporarily and user is notified via email unless user has turned security alerts off.
+func (a authRepository) recordFailedLogin(ctx context.Context, userID int64, email string, clientInfo request.ClientInfo) error {
+	failuresKey := "login_failures:" + email
+	allowed, _, err := utils.AllowRequest(a.redisClient, failuresKey, constant.LOGIN_MAX_FAILED_ATTEMPTS-1, constant.LOGIN_FAILURE_WINDOW)
 	if err != nil {
-		return response.User{}, constant.EMPTY_STRING, err
+		return err
+	}
+	if allowed {
+		return errorhandling.PasswordNotMatched
 	}
 
-	_, err = a.dbConn.Exec(context.Background(), `INSERT INTO refresh_tokens (user_id, refresh_token) VALUES ($1, $2)`, dbUser.ID, refreshToken)
+	err = a.redisClient.Set(ctx, "login_lockout:"+email, time.Now().Unix(), constant.LOGIN_LOCKOUT_DURATION).Err()
 	if err != nil {
-		return response.User{}, constant.EMPTY_STRING, err
+		return err
 	}
+	a.redisClient.Del(ctx, failuresKey)
 
-	return dbUser, refreshToken, nil
+	lockedUntil := time.Now().Add(constant.LOGIN_LOCKOUT_DURATION)
+	SendSecurityAlert(ctx, a.dbConn, a.rabbitmqConn, userID, "Security Alert: Account Temporarily Locked", func(preferences response.UserPreferences) string {
+		return utils.PrepareAccountLockoutEmailBody(clientInfo, lockedUntil, preferences)
+	})
+	return errorhandling.CreateRateLimitError(constant.ACCOUNT_LOCKED, constant.LOGIN_LOCKOUT_DURATION)
 }
 
-func (a authRepository) RefreshToken(refreshToken string) (int64, string, error) {
-	var userID int64
-	rows := a.dbConn.QueryRow(context.Background(), `SELECT id FROM users as u LEFT JOIN refresh_tokens as r on u.id = r.user_id WHERE r.refresh_token = $1`, refreshToken)
-	err := rows.Scan(&userID)
+// startSession creates new session for authenticated user, if user has two factor authentication enabled
+// then only challenge token is issued and session is created after verifying the code. deactivated users can't start session.
+func (a authRepository) startSession(ctx context.Context, dbUser response.User, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error) {
+	err := a.checkAccountActive(ctx, dbUser.ID)
+	if err != nil {
+		return response.User{}, dto.SessionToken{}, err
+	}
+
+	var twoFactorEnabled bool
+	rows := a.dbConn.QueryRow(ctx, `SELECT enabled FROM user_two_factor WHERE user_id = $1`, dbUser.ID)
+	err = rows.Scan(&twoFactorEnabled)
+	if err != nil && err.Error() != constant.PG_NO_ROWS {
+		return response.User{}, dto.SessionToken{}, err
+	}
+	if twoFactorEnabled {
+		challengeToken, err := createLoginChallenge(a.redisClient, dbUser.ID)
+		if err != nil {
+			return response.User{}, dto.SessionToken{}, err
+		}
+		return response.User{}, dto.SessionToken{UserID: dbUser.ID, ChallengeToken: challengeToken}, nil
+	}
+
+	sessionToken, err := a.createSession(ctx, dbUser.ID, clientInfo)
+	if err != nil {
+		return response.User{}, dto.SessionToken{}, err
+	}
+	return dbUser, sessionToken, nil
+}
+
+// VerifyTwoFactorLogin completes login of user having two factor authentication enabled, it exchanges challenge token
+// issued by UserLogin and code of authenticator app or recovery code for new session.
+// challenge is discarded after few wrong codes, so that user has to enter password again.
+func (a authRepository) VerifyTwoFactorLogin(twoFactorLogin request.TwoFactorLogin, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error) {
+	ctx := context.Background()
+	challengeKey := "login_challenge:" + twoFactorLogin.ChallengeToken
+	userID, err := a.redisClient.HGet(ctx, challengeKey, "userId").Int64()
+	if err != nil {
+		if err == redis.Nil {
+			return response.User{}, dto.SessionToken{}, errorhandling.LoginChallengeExpired
+		}
+		return response.User{}, dto.SessionToken{}, err
+	}
+
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return response.User{}, dto.SessionToken{}, err
+	}
+	err = VerifyTwoFactorCode(ctx, tx, userID, twoFactorLogin.Code)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err == errorhandling.InvalidTwoFactorCode {
+			attempts, redisErr := a.redisClient.HIncrBy(ctx, challengeKey, "attempts", 1).Result()
+			if redisErr != nil {
+				return response.User{}, dto.SessionToken{}, redisErr
+			}
+			if attempts >= constant.LOGIN_CHALLENGE_MAX_ATTEMPTS {
+				a.redisClient.Del(ctx, challengeKey)
+			}
+		}
+		return response.User{}, dto.SessionToken{}, err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return response.User{}, dto.SessionToken{}, err
+	}
+
+	deleted, err := a.redisClient.Del(ctx, challengeKey).Result()
+	if err != nil {
+		return response.User{}, dto.SessionToken{}, err
+	}
+	// challenge is deleted by concurrent request with valid code, so it can't be used twice.
+	if deleted == 0 {
+		return response.User{}, dto.SessionToken{}, errorhandling.LoginChallengeExpired
+	}
+
+	var dbUser response.User
+	var avatarKey *string
+	rows := a.dbConn.QueryRow(ctx, `SELECT id, first_name, last_name, bio, email, email_visibility, bio_visibility, teams_visibility, discoverable, assignable_by, email_verified, avatar_key FROM users WHERE id = $1`, userID)
+	err = rows.Scan(&dbUser.ID, &dbUser.FirstName, &dbUser.LastName, &dbUser.Bio, &dbUser.Email, &dbUser.Privacy.EmailVisibility, &dbUser.Privacy.BioVisibility, &dbUser.Privacy.TeamsVisibility, &dbUser.Privacy.Discoverable, &dbUser.Privacy.AssignableBy, &dbUser.EmailVerified, &avatarKey)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return response.User{}, dto.SessionToken{}, errorhandling.NoUserFound
+		}
+		return response.User{}, dto.SessionToken{}, err
+	}
+	dbUser.Avatar = utils.AvatarOf(avatarKey)
+
+	// account might be deactivated after challenge is issued.
+	err = a.checkAccountActive(ctx, dbUser.ID)
+	if err != nil {
+		return response.User{}, dto.SessionToken{}, err
+	}
+
+	sessionToken, err := a.createSession(ctx, dbUser.ID, clientInfo)
+	if err != nil {
+		return response.User{}, dto.SessionToken{}, err
+	}
+	return dbUser, sessionToken, nil
+}
+
+// checkAccountActive returns AccountDeactivated error if admin has deactivated account of the user.
+func (a authRepository) checkAccountActive(ctx context.Context, userID int64) error {
+	accountStatus, err := getAccountStatus(ctx, a.dbConn, userID)
+	if err != nil {
+		return err
+	}
+	if accountStatus.Deactivated {
+		return errorhandling.AccountDeactivated
+	}
+	return nil
+}
+
+// createSession starts a new session with its own token family and issues first refresh token of it,
+// all refresh tokens rotated from this one will belong to the same family. pending deletion of the account is cancelled by it.
+func (a authRepository) createSession(ctx context.Context, userID int64, clientInfo request.ClientInfo) (dto.SessionToken, error) {
+	var sessionID int64
+	family, err := utils.CreateRandomID()
+	if err != nil {
+		return dto.SessionToken{}, err
+	}
+
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return dto.SessionToken{}, err
+	}
+
+	rows := tx.QueryRow(ctx, `INSERT INTO user_sessions (user_id, family, user_agent, ip_address) VALUES ($1, $2, $3, $4) RETURNING id`,
+		userID, family, clientInfo.UserAgent, clientInfo.IPAddress)
+	err = rows.Scan(&sessionID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return dto.SessionToken{}, err
+	}
+
+	// logging in within grace period of account deletion cancels the deletion.
+	_, err = tx.Exec(ctx, `UPDATE users SET deletion_scheduled_at = NULL WHERE id = $1 AND deletion_scheduled_at IS NOT NULL`, userID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return dto.SessionToken{}, err
+	}
+
+	refreshTokenExpiryTime := time.Now().Add(constant.REFRESH_TOKEN_LIFETIME)
+	refreshToken, err := utils.CreateJWTToken(constant.JWT_TYPE_REFRESH, refreshTokenExpiryTime, userID, sessionID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return dto.SessionToken{}, err
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO refresh_tokens (user_id, family, refresh_token, expires_at, user_agent, ip_address) VALUES ($1, $2, $3, $4, $5, $6)`,
+		userID, family, refreshToken, refreshTokenExpiryTime, clientInfo.UserAgent, clientInfo.IPAddress)
+	if err != nil {
+		tx.Rollback(ctx)
+		return dto.SessionToken{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return dto.SessionToken{}, err
+	}
+
+	return dto.SessionToken{UserID: userID, SessionID: sessionID, RefreshToken: refreshToken}, nil
+}
+
+// createLoginChallenge stores short lived challenge for the user in redis and returns its token.
+func createLoginChallenge(redisClient *redis.Client, userID int64) (string, error) {
+	ctx := context.Background()
+	challengeToken, err := utils.CreateRandomID()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+
+	challengeKey := "login_challenge:" + challengeToken
+	err = redisClient.HSet(ctx, challengeKey, "userId", userID, "attempts", 0).Err()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	err = redisClient.Expire(ctx, challengeKey, constant.LOGIN_CHALLENGE_LIFETIME).Err()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	return challengeToken, nil
+}
+
+// RefreshToken rotates the given refresh token, it marks the token as used and issues new token of the same family.
+// if already used token is presented again then it is treated as stolen, so whole family and its session are revoked and user is notified via email unless user has turned security alerts off.
+func (a authRepository) RefreshToken(refreshToken string, clientInfo request.ClientInfo) (dto.SessionToken, error) {
+	ctx := context.Background()
+	var tokenID, userID, sessionID int64
+	var family string
+	var usedAt, revokedAt *time.Time
+
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return dto.SessionToken{}, err
+	}
+
+	rows := tx.QueryRow(ctx, `SELECT r.id, r.user_id, r.family, r.used_at, r.revoked_at, s.id FROM refresh_tokens as r
+	JOIN user_sessions as s on s.family = r.family WHERE r.refresh_token = $1 AND r.expires_at > $2 FOR UPDATE`, refreshToken, time.Now())
+	err = rows.Scan(&tokenID, &userID, &family, &usedAt, &revokedAt, &sessionID)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err.Error() == constant.PG_NO_ROWS {
+			return dto.SessionToken{}, errorhandling.RefreshTokenNotFound
+		}
+		return dto.SessionToken{}, err
+	}
+
+	if revokedAt != nil {
+		tx.Rollback(ctx)
+		return dto.SessionToken{}, errorhandling.RefreshTokenRevoked
+	}
+
+	if usedAt != nil {
+		err = revokeSession(ctx, tx, sessionID, family)
+		if err != nil {
+			tx.Rollback(ctx)
+			return dto.SessionToken{}, err
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			tx.Rollback(ctx)
+			return dto.SessionToken{}, err
+		}
+
+		detectedAt := time.Now()
+		SendSecurityAlert(ctx, a.dbConn, a.rabbitmqConn, userID, "Security Alert: Suspicious Sign In Activity", func(preferences response.UserPreferences) string {
+			return utils.PrepareRefreshTokenReuseEmailBody(clientInfo, detectedAt, preferences)
+		})
+		err = utils.DenySession(a.redisClient, sessionID)
+		if err != nil {
+			return dto.SessionToken{}, err
+		}
+		return dto.SessionToken{}, errorhandling.RefreshTokenReused
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE refresh_tokens SET used_at = $1 WHERE id = $2`, time.Now(), tokenID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return dto.SessionToken{}, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE user_sessions SET last_used_at = $1, user_agent = $2, ip_address = $3 WHERE id = $4`, time.Now(), clientInfo.UserAgent, clientInfo.IPAddress, sessionID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return dto.SessionToken{}, err
+	}
+
+	refreshTokenExpiryTime := time.Now().Add(constant.REFRESH_TOKEN_LIFETIME)
+	refreshToken, err = utils.CreateJWTToken(constant.JWT_TYPE_REFRESH, refreshTokenExpiryTime, userID, sessionID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return dto.SessionToken{}, err
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO refresh_tokens (user_id, family, refresh_token, expires_at, user_agent, ip_address) VALUES ($1, $2, $3, $4, $5, $6)`,
+		userID, family, refreshToken, refreshTokenExpiryTime, clientInfo.UserAgent, clientInfo.IPAddress)
+	if err != nil {
+		tx.Rollback(ctx)
+		return dto.SessionToken{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return dto.SessionToken{}, err
+	}
+
+	return dto.SessionToken{UserID: userID, SessionID: sessionID, RefreshToken: refreshToken}, nil
+}
+
+// Logout revokes session of the given refresh token and adds current access token to redis denylist,
+// so that both tokens can't be used anymore.
+func (a authRepository) Logout(userID int64, refreshToken string, accessToken utils.JWTTokenClaims) error {
+	ctx := context.Background()
+	var sessionID int64
+	var family string
+
+	err := utils.DenyAccessToken(a.redisClient, accessToken)
+	if err != nil {
+		return err
+	}
+
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := tx.QueryRow(ctx, `SELECT s.id, s.family FROM refresh_tokens as r JOIN user_sessions as s on s.family = r.family WHERE r.user_id = $1 AND r.refresh_token = $2 AND s.revoked_at IS NULL`, userID, refreshToken)
+	err = rows.Scan(&sessionID, &family)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err.Error() == constant.PG_NO_ROWS {
+			return errorhandling.RefreshTokenNotFound
+		}
+		return err
+	}
+
+	err = revokeSession(ctx, tx, sessionID, family)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return utils.DenySession(a.redisClient, sessionID)
+}
+
+// LogoutAll revokes every session and refresh token of the user and all access tokens issued to user till now.
+func (a authRepository) LogoutAll(userID int64) error {
+	ctx := context.Background()
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = RevokeAllSessionsOfUser(ctx, tx, userID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	return utils.DenyAllAccessTokensOfUser(a.redisClient, userID, time.Now())
+}
+
+// RevokeAllSessionsOfUser revokes every session of the user and deletes all of its refresh tokens within given transaction,
+// access tokens of the user are to be denied by utils.DenyAllAccessTokensOfUser once transaction is committed.
+func RevokeAllSessionsOfUser(ctx context.Context, tx pgx.Tx, userID int64) error {
+	_, err := tx.Exec(ctx, `UPDATE user_sessions SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`, time.Now(), userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1`, userID)
+	return err
+}
+
+// RevokeAllPersonalAccessTokensOfUser revokes every personal access token of the user within given transaction,
+// it is used along with RevokeAllSessionsOfUser when admin takes away account access from the user.
+func RevokeAllPersonalAccessTokensOfUser(ctx context.Context, tx pgx.Tx, userID int64) error {
+	_, err := tx.Exec(ctx, `UPDATE personal_access_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`, time.Now(), userID)
+	return err
+}
+
+// DeleteExpiredRefreshTokens deletes refresh tokens whose expiry time is passed and returns number of deleted tokens.
+// sessions which are left without any refresh token are deleted as well.
+func (a authRepository) DeleteExpiredRefreshTokens() (int64, error) {
+	ctx := context.Background()
+	result, err := a.dbConn.Exec(ctx, `DELETE FROM refresh_tokens WHERE expires_at <= $1`, time.Now())
 	if err != nil {
+		return 0, err
+	}
+
+	_, err = a.dbConn.Exec(ctx, `DELETE FROM user_sessions as s WHERE NOT EXISTS (SELECT 1 FROM refresh_tokens as r WHERE r.family = s.family)`)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// GetActiveSessions returns sessions of the user which are neither revoked nor expired, session of current access token is flagged as current.
+func (a authRepository) GetActiveSessions(userID int64, currentSessionID int64) ([]response.Session, error) {
+	sessions := make([]response.Session, 0)
+	rows, err := a.dbConn.Query(context.Background(), `SELECT id, user_agent, ip_address, created_at, last_used_at FROM user_sessions as s
+	WHERE user_id = $1 AND revoked_at IS NULL AND EXISTS (SELECT 1 FROM refresh_tokens as r WHERE r.family = s.family AND r.used_at IS NULL AND r.revoked_at IS NULL AND r.expires_at > $2)
+	ORDER BY last_used_at DESC`, userID, time.Now())
+	if err != nil {
+		return sessions, err
+	}
+	defer rows.Close()
+
+	var session response.Session
+	for rows.Next() {
+		if err := rows.Scan(&session.ID, &session.UserAgent, &session.IPAddress, &session.CreatedAt, &session.LastUsedAt); err != nil {
+			return sessions, err
+		}
+		session.Current = session.ID == currentSessionID
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes single session of the user, its refresh tokens can't be used anymore and access tokens issued to it are rejected.
+func (a authRepository) RevokeSession(userID int64, sessionID int64) error {
+	ctx := context.Background()
+	var family string
+
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := tx.QueryRow(ctx, `SELECT family FROM user_sessions WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`, sessionID, userID)
+	err = rows.Scan(&family)
+	if err != nil {
+		tx.Rollback(ctx)
 		if err.Error() == constant.PG_NO_ROWS {
-			return 0, constant.EMPTY_STRING, errorhandling.RefreshTokenNotFound
-		} else {
-			return 0, constant.EMPTY_STRING, err
+			return errorhandling.NoSessionFound
 		}
+		return err
 	}
 
-	refreshToken, err = utils.CreateJWTToken(time.Now().Add(time.Hour*24*7), userID)
+	err = revokeSession(ctx, tx, sessionID, family)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return utils.DenySession(a.redisClient, sessionID)
+}
+
+// IsAccessTokenDenied checks redis denylist for given access token.
+func (a authRepository) IsAccessTokenDenied(accessToken utils.JWTTokenClaims) (bool, error) {
+	return utils.IsAccessTokenDenied(a.redisClient, accessToken)
+}
+
+// GetAccountStatus returns whether user is admin and whether account of the user is deactivated.
+func (a authRepository) GetAccountStatus(userID int64) (dto.AccountStatus, error) {
+	return getAccountStatus(context.Background(), a.dbConn, userID)
+}
+
+// getAccountStatus reads admin flag and deactivation of the user, user who is deleted (anonymized) or doesn't exist anymore is treated as deactivated.
+func getAccountStatus(ctx context.Context, dbConn *pgx.Conn, userID int64) (dto.AccountStatus, error) {
+	var accountStatus dto.AccountStatus
+	rows := dbConn.QueryRow(ctx, `SELECT is_admin, (deactivated_at IS NOT NULL OR deleted_at IS NOT NULL) FROM users WHERE id = $1`, userID)
+	err := rows.Scan(&accountStatus.IsAdmin, &accountStatus.Deactivated)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return dto.AccountStatus{Deactivated: true}, nil
+		}
+		return accountStatus, err
+	}
+	return accountStatus, nil
+}
+
+// CreatePersonalAccessToken creates new personal access token of the user, only hash of the token is stored
+// so token is returned only this time.
+func (a authRepository) CreatePersonalAccessToken(userID int64, personalAccessToken request.PersonalAccessToken) (int64, string, error) {
+	var tokenID int64
+	token, err := utils.CreatePersonalAccessToken()
 	if err != nil {
 		return 0, constant.EMPTY_STRING, err
 	}
 
-	_, err = a.dbConn.Exec(context.Background(), `INSERT INTO refresh_tokens (user_id, refresh_token) VALUES ($1, $2)`, userID, refreshToken)
+	rows := a.dbConn.QueryRow(context.Background(), `INSERT INTO personal_access_tokens (user_id, name, token_hash, scopes, expires_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		userID, personalAccessToken.Name, utils.HashPersonalAccessToken(token), personalAccessToken.Scopes, personalAccessToken.ExpiresAt)
+	err = rows.Scan(&tokenID)
 	if err != nil {
 		return 0, constant.EMPTY_STRING, err
 	}
+	return tokenID, token, nil
+}
+
+// GetPersonalAccessTokens returns personal access tokens of the user which are not revoked, expired tokens are also returned so that user can see them.
+func (a authRepository) GetPersonalAccessTokens(userID int64) ([]response.PersonalAccessToken, error) {
+	personalAccessTokens := make([]response.PersonalAccessToken, 0)
+	rows, err := a.dbConn.Query(context.Background(), `SELECT id, name, scopes, expires_at, last_used_at, created_at FROM personal_access_tokens
+	WHERE user_id = $1 AND revoked_at IS NULL ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return personalAccessTokens, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var personalAccessToken response.PersonalAccessToken
+		if err := rows.Scan(&personalAccessToken.ID, &personalAccessToken.Name, &personalAccessToken.Scopes, &personalAccessToken.ExpiresAt,
+			&personalAccessToken.LastUsedAt, &personalAccessToken.CreatedAt); err != nil {
+			return personalAccessTokens, err
+		}
+		personalAccessTokens = append(personalAccessTokens, personalAccessToken)
+	}
+	return personalAccessTokens, nil
+}
+
+// RevokePersonalAccessToken revokes personal access token of the user, it can't be used anymore.
+func (a authRepository) RevokePersonalAccessToken(userID int64, tokenID int64) error {
+	result, err := a.dbConn.Exec(context.Background(), `UPDATE personal_access_tokens SET revoked_at = $1 WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL`, time.Now(), tokenID, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errorhandling.NoPersonalAccessTokenFound
+	}
+	return nil
+}
+
+// VerifyPersonalAccessToken returns id of the user and scopes granted to given personal access token if it is neither revoked nor expired,
+// it also records last used time of the token.
+func (a authRepository) VerifyPersonalAccessToken(token string) (int64, []string, error) {
+	var userID int64
+	var scopes []string
+	now := time.Now()
+	rows := a.dbConn.QueryRow(context.Background(), `UPDATE personal_access_tokens SET last_used_at = $1
+	WHERE token_hash = $2 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > $1) RETURNING user_id, scopes`, now, utils.HashPersonalAccessToken(token))
+	err := rows.Scan(&userID, &scopes)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return 0, nil, errorhandling.PersonalAccessTokenInvalid
+		}
+		return 0, nil, err
+	}
+	return userID, scopes, nil
+}
+
+// StartOIDCLogin starts single sign-on login and returns url of identity provider where user has to be redirected.
+// state, nonce and pkce code verifier of this login are kept in redis for short time, so that callback can be verified.
+func (a authRepository) StartOIDCLogin() (string, error) {
+	if config.Config.OIDC.IssuerURL == constant.EMPTY_STRING {
+		return constant.EMPTY_STRING, errorhandling.OIDCNotConfigured
+	}
+	discoveryDocument, err := utils.GetOIDCDiscoveryDocument()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+
+	state, err := utils.CreateRandomID()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	nonce, err := utils.CreateRandomID()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	codeVerifier, err := utils.CreatePKCECodeVerifier()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+
+	ctx := context.Background()
+	stateKey := "oidc_state:" + state
+	err = a.redisClient.HSet(ctx, stateKey, "nonce", nonce, "codeVerifier", codeVerifier).Err()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	err = a.redisClient.Expire(ctx, stateKey, constant.OIDC_STATE_LIFETIME).Err()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	return utils.CreateOIDCAuthorizationURL(discoveryDocument, state, nonce, codeVerifier)
+}
+
+// CompleteOIDCLogin exchanges authorization code of identity provider for id token using code verifier of the login,
+// then user of that identity is found or provisioned and session is started same as UserLogin.
+// state can be used only once, so callback can't be replayed.
+func (a authRepository) CompleteOIDCLogin(oidcCallback request.OIDCCallback, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error) {
+	if config.Config.OIDC.IssuerURL == constant.EMPTY_STRING {
+		return response.User{}, dto.SessionToken{}, errorhandling.OIDCNotConfigured
+	}
+
+	ctx := context.Background()
+	stateKey := "oidc_state:" + oidcCallback.State
+	state, err := a.redisClient.HGetAll(ctx, stateKey).Result()
+	if err != nil {
+		return response.User{}, dto.SessionToken{}, err
+	}
+	deleted, err := a.redisClient.Del(ctx, stateKey).Result()
+	if err != nil {
+		return response.User{}, dto.SessionToken{}, err
+	}
+	if len(state) == 0 || deleted == 0 {
+		return response.User{}, dto.SessionToken{}, errorhandling.OIDCStateExpired
+	}
+
+	discoveryDocument, err := utils.GetOIDCDiscoveryDocument()
+	if err != nil {
+		return response.User{}, dto.SessionToken{}, err
+	}
+	idToken, err := utils.ExchangeOIDCAuthorizationCode(discoveryDocument, oidcCallback.Code, state["codeVerifier"])
+	if err != nil {
+		config.LoggerInstance.Warning(err.Error())
+		return response.User{}, dto.SessionToken{}, errorhandling.OIDCLoginFailed
+	}
+	oidcClaims, err := utils.VerifyOIDCIDToken(discoveryDocument, idToken, state["nonce"])
+	if err != nil {
+		config.LoggerInstance.Warning(err.Error())
+		return response.User{}, dto.SessionToken{}, errorhandling.OIDCLoginFailed
+	}
+
+	tx, err := a.dbConn.Begin(ctx)
+	if err != nil {
+		return response.User{}, dto.SessionToken{}, err
+	}
+	dbUser, err := FindOrCreateOIDCUser(ctx, tx, oidcClaims)
+	if err != nil {
+		tx.Rollback(ctx)
+		return response.User{}, dto.SessionToken{}, err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return response.User{}, dto.SessionToken{}, err
+	}
+
+	return a.startSession(ctx, dbUser, clientInfo)
+}
+
+// revokeSession marks session and all refresh tokens of its family as revoked within given transaction,
+// session is to be added to redis denylist by utils.DenySession once transaction is committed so that its access tokens are rejected immediately.
+func revokeSession(ctx context.Context, tx pgx.Tx, sessionID int64, family string) error {
+	revokedAt := time.Now()
+	_, err := tx.Exec(ctx, `UPDATE user_sessions SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`, revokedAt, sessionID)
+	if err != nil {
+		return err
+	}
 
-	return userID, refreshToken, nil
+	_, err = tx.Exec(ctx, `UPDATE refresh_tokens SET revoked_at = $1 WHERE family = $2 AND revoked_at IS NULL`, revokedAt, family)
+	return err
 }
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/go-redis/redis/v8"
+	"github.com/jackc/pgx/v5"
+)
+
+// tasks assigned to the user either directly or through teams of the user, same as tasks assigned to me in GetAllTasks.
+const assignedToUserCondition = `(assignee_individual = $1 OR assignee_team IN (SELECT team_id FROM team_members WHERE member_id = $1))`
+
+type DashboardRepository interface {
+	GetDashboard(organizationId int64, userId int64) (response.Dashboard, error)
+	MarkNotificationsRead(userId int64) error
+}
+
+type dashboardRepository struct {
+	dbConn      *pgx.Conn
+	redisClient *redis.Client
+}
+
+func NewDashboardRepo(dbConn *pgx.Conn, redisClient *redis.Client) DashboardRepository {
+	return dashboardRepository{
+		dbConn:      dbConn,
+		redisClient: redisClient,
+	}
+}
+
+// GetDashboard returns open tasks of the user by status, tasks due today and this week, overdue tasks, recently updated tasks, teams of the user
+// having most open tasks and number of unread notifications. all lists are read in one round trip by batch, redis task cache is not used as it
+// is filled lazily and can't be filtered, whereas unread notifications are only counted in redis.
+func (d dashboardRepository) GetDashboard(organizationId int64, userId int64) (response.Dashboard, error) {
+	dashboard := response.Dashboard{OpenTasksByStatus: map[string]int{"TO-DO": 0, "IN-PROGRESS": 0}, BusiestTeams: make([]response.DashboardTeam, 0)}
+	ctx := context.Background()
+	recipient, err := LoadNotificationRecipient(ctx, d.dbConn, userId)
+	if err != nil {
+		return dashboard, err
+	}
+	now := time.Now().UTC()
+	startOfToday, endOfToday, endOfWeek := GetDashboardPeriods(recipient.Preferences, now)
+
+	batch := &pgx.Batch{}
+	batch.Queue(`SELECT status, COUNT(*) FROM tasks WHERE organization_id = $2 AND `+assignedToUserCondition+` AND status IN ('TO-DO', 'IN-PROGRESS') GROUP BY status`, userId, organizationId)
+	batch.Queue(`SELECT `+TaskColumns+` FROM tasks WHERE organization_id = $2 AND `+assignedToUserCondition+` AND status IN ('TO-DO', 'IN-PROGRESS') AND deadline >= $3 AND deadline < $4
+	ORDER BY deadline LIMIT $5`, userId, organizationId, startOfToday, endOfToday, constant.DASHBOARD_TASK_LIMIT)
+	batch.Queue(`SELECT `+TaskColumns+` FROM tasks WHERE organization_id = $2 AND `+assignedToUserCondition+` AND status IN ('TO-DO', 'IN-PROGRESS') AND deadline >= $3 AND deadline < $4
+	ORDER BY deadline LIMIT $5`, userId, organizationId, startOfToday, endOfWeek, constant.DASHBOARD_TASK_LIMIT)
+	batch.Queue(`SELECT `+TaskColumns+` FROM tasks WHERE organization_id = $2 AND `+assignedToUserCondition+` AND status IN ('TO-DO', 'IN-PROGRESS') AND deadline < $3
+	ORDER BY deadline LIMIT $4`, userId, organizationId, now, constant.DASHBOARD_TASK_LIMIT)
+	batch.Queue(`SELECT `+TaskColumns+` FROM tasks WHERE organization_id = $2 AND (created_by = $1 OR `+assignedToUserCondition+`)
+	ORDER BY COALESCE(updated_at, created_at) DESC LIMIT $3`, userId, organizationId, constant.DASHBOARD_TASK_LIMIT)
+	batch.Queue(`SELECT t.id, t.name, COUNT(ta.id) FROM teams AS t LEFT JOIN tasks AS ta ON ta.assignee_team = t.id AND ta.status IN ('TO-DO', 'IN-PROGRESS')
+	WHERE (t.created_by = $1 OR t.id IN (SELECT team_id FROM team_members WHERE member_id = $1)) AND t.organization_id = $2
+	GROUP BY t.id, t.name ORDER BY COUNT(ta.id) DESC, t.id LIMIT $3`, userId, organizationId, constant.DASHBOARD_TEAM_LIMIT)
+
+	results := d.dbConn.SendBatch(ctx, batch)
+	defer results.Close()
+
+	statusCounts, err := results.Query()
+	if err != nil {
+		return dashboard, err
+	}
+	for statusCounts.Next() {
+		var status string
+		var count int
+		if err := statusCounts.Scan(&status, &count); err != nil {
+			statusCounts.Close()
+			return dashboard, err
+		}
+		dashboard.OpenTasksByStatus[status] = count
+	}
+	statusCounts.Close()
+	if err := statusCounts.Err(); err != nil {
+		return dashboard, err
+	}
+
+	for _, tasks := range []*[]response.Task{&dashboard.DueToday, &dashboard.DueThisWeek, &dashboard.Overdue, &dashboard.RecentlyUpdated} {
+		*tasks, err = scanBatchTasks(results)
+		if err != nil {
+			return dashboard, err
+		}
+	}
+
+	teams, err := results.Query()
+	if err != nil {
+		return dashboard, err
+	}
+	for teams.Next() {
+		var team response.DashboardTeam
+		if err := teams.Scan(&team.ID, &team.Name, &team.OpenTasks); err != nil {
+			teams.Close()
+			return dashboard, err
+		}
+		dashboard.BusiestTeams = append(dashboard.BusiestTeams, team)
+	}
+	teams.Close()
+	if err := teams.Err(); err != nil {
+		return dashboard, err
+	}
+	if err := results.Close(); err != nil {
+		return dashboard, err
+	}
+
+	unreadNotifications, err := d.redisClient.Get(ctx, "notifications:unread:"+strconv.FormatInt(userId, 10)).Int64()
+	if err != nil && err != redis.Nil {
+		return dashboard, err
+	}
+	dashboard.UnreadNotifications = unreadNotifications
+	return dashboard, nil
+}
+
+// MarkNotificationsRead resets number of unread notifications of the user.
+func (d dashboardRepository) MarkNotificationsRead(userId int64) error {
+	return d.redisClient.Del(context.Background(), "notifications:unread:"+strconv.FormatInt(userId, 10)).Err()
+}
+
+// GetDashboardPeriods returns start and end of today and end of this week in timezone of the user as UTC times, week ends before
+// next week start day of the user. UTC and MONDAY are used if preferences are not valid.
+func GetDashboardPeriods(preferences response.UserPreferences, now time.Time) (time.Time, time.Time, time.Time) {
+	location, err := time.LoadLocation(preferences.Timezone)
+	if err != nil || preferences.Timezone == constant.EMPTY_STRING {
+		location = time.UTC
+	}
+	weekStart := time.Monday
+	switch preferences.WeekStart {
+	case constant.WEEK_START_SUNDAY:
+		weekStart = time.Sunday
+	case constant.WEEK_START_SATURDAY:
+		weekStart = time.Saturday
+	}
+
+	localNow := now.In(location)
+	startOfToday := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, location)
+	daysLeftInWeek := (int(weekStart) - int(localNow.Weekday()) + 7) % 7
+	if daysLeftInWeek == 0 {
+		daysLeftInWeek = 7
+	}
+	return startOfToday.UTC(), startOfToday.AddDate(0, 0, 1).UTC(), startOfToday.AddDate(0, 0, daysLeftInWeek).UTC()
+}
+
+// scanBatchTasks reads tasks returned by next query of the batch.
+func scanBatchTasks(results pgx.BatchResults) ([]response.Task, error) {
+	tasksSlice := make([]response.Task, 0)
+	tasks, err := results.Query()
+	if err != nil {
+		return tasksSlice, err
+	}
+	defer tasks.Close()
+	for tasks.Next() {
+		var task response.Task
+		if err := ScanTask(tasks, &task); err != nil {
+			return tasksSlice, err
+		}
+		tasksSlice = append(tasksSlice, task)
+	}
+	return tasksSlice, tasks.Err()
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDashboard(t *testing.T) {
+	dashboardRepository := NewDashboardRepo(dbConn, redisClient)
+	err := dashboardRepository.MarkNotificationsRead(954488202459119617)
+	assert.NoError(t, err)
+
+	dashboard, err := dashboardRepository.GetDashboard(954560000000000001, 954488202459119617)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), dashboard.UnreadNotifications)
+	assert.Contains(t, dashboard.OpenTasksByStatus, "TO-DO")
+	assert.Contains(t, dashboard.OpenTasksByStatus, "IN-PROGRESS")
+	assert.NotEmpty(t, dashboard.BusiestTeams)
+	for _, task := range dashboard.RecentlyUpdated {
+		assert.Equal(t, int64(954560000000000001), task.OrganizationID)
+	}
+	for _, task := range dashboard.Overdue {
+		assert.True(t, task.Deadline.Before(time.Now().UTC()))
+	}
+
+	// tasks and teams of other organization are not shown.
+	dashboard, err = dashboardRepository.GetDashboard(954560000000000002, 954488202459119617)
+	assert.NoError(t, err)
+	assert.Empty(t, dashboard.RecentlyUpdated)
+	assert.Empty(t, dashboard.BusiestTeams)
+}
+
+func TestGetDashboardPeriods(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		Preferences  response.UserPreferences
+		Now          time.Time
+		StartOfToday time.Time
+		EndOfToday   time.Time
+		EndOfWeek    time.Time
+	}{
+		{
+			TestCaseName: "Today and Week in Timezone of User.",
+			Preferences:  response.UserPreferences{Timezone: "Asia/Kolkata", WeekStart: "MONDAY"},
+			Now:          time.Date(2024, 3, 27, 20, 0, 0, 0, time.UTC),
+			StartOfToday: time.Date(2024, 3, 27, 18, 30, 0, 0, time.UTC),
+			EndOfToday:   time.Date(2024, 3, 28, 18, 30, 0, 0, time.UTC),
+			EndOfWeek:    time.Date(2024, 3, 31, 18, 30, 0, 0, time.UTC),
+		},
+		{
+			TestCaseName: "Week Starting on Sunday.",
+			Preferences:  response.UserPreferences{Timezone: "Asia/Kolkata", WeekStart: "SUNDAY"},
+			Now:          time.Date(2024, 3, 27, 20, 0, 0, 0, time.UTC),
+			StartOfToday: time.Date(2024, 3, 27, 18, 30, 0, 0, time.UTC),
+			EndOfToday:   time.Date(2024, 3, 28, 18, 30, 0, 0, time.UTC),
+			EndOfWeek:    time.Date(2024, 3, 30, 18, 30, 0, 0, time.UTC),
+		},
+		{
+			TestCaseName: "Today is First Day of Week.",
+			Preferences:  response.UserPreferences{Timezone: "UTC", WeekStart: "MONDAY"},
+			Now:          time.Date(2024, 3, 25, 10, 0, 0, 0, time.UTC),
+			StartOfToday: time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC),
+			EndOfToday:   time.Date(2024, 3, 26, 0, 0, 0, 0, time.UTC),
+			EndOfWeek:    time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			startOfToday, endOfToday, endOfWeek := GetDashboardPeriods(v.Preferences, v.Now)
+			assert.Equal(t, v.StartOfToday, startOfToday)
+			assert.Equal(t, v.EndOfToday, endOfToday)
+			assert.Equal(t, v.EndOfWeek, endOfWeek)
+		})
+	}
+}
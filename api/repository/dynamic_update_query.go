@@ -9,12 +9,16 @@ import (
 )
 
 func UpdateQuery(tableName string, model interface{}, id int64, flag int) (string, []interface{}, error) {
-	modelType := reflect.TypeOf(model)
-	modelValue := reflect.ValueOf(model)
+	query, args := setFieldsOfUpdateQuery("UPDATE "+tableName+" SET", nil, reflect.ValueOf(model), flag)
+	query = query[:len(query)-1] + " WHERE id = $" + strconv.Itoa(len(args)+1)
+	args = append(args, id)
 
-	query := "UPDATE " + tableName + " SET"
-	var args []interface{}
+	return query, args, nil
+}
 
+// setFieldsOfUpdateQuery appends non zero fields of the model to set clause of update query, fields of embedded structs are set as columns of same table.
+func setFieldsOfUpdateQuery(query string, args []interface{}, modelValue reflect.Value, flag int) (string, []interface{}) {
+	modelType := modelValue.Type()
 	for i := 0; i < modelType.NumField(); i++ {
 		field := modelType.Field(i)
 		fieldValue := modelValue.Field(i)
@@ -22,6 +26,10 @@ func UpdateQuery(tableName string, model interface{}, id int64, flag int) (strin
 		if !fieldValue.CanInterface() {
 			continue
 		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			query, args = setFieldsOfUpdateQuery(query, args, fieldValue, flag)
+			continue
+		}
 		if field.Tag.Get("json") == "id" {
 			continue
 		}
@@ -43,10 +51,7 @@ func UpdateQuery(tableName string, model interface{}, id int64, flag int) (strin
 			args = append(args, nil)
 		}
 	}
-	query = query[:len(query)-1] + " WHERE id = $" + strconv.Itoa(len(args)+1)
-	args = append(args, id)
-
-	return query, args, nil
+	return query, args
 }
 
 func UpdateTaskFields(dbTask response.Task, requestTask request.UpdateTask) response.Task {
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/go-redis/redis/v8"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// MaintenanceRepository is used by tmsctl for tasks of operators who have access to database and config of the server,
+// so unlike AdminRepository its methods don't need an admin user and they are not written to admin audit log.
+type MaintenanceRepository interface {
+	CreateAdminUser(user request.User) (int64, error)
+	SetUserPassword(email string, password string) error
+	RebuildTaskCache() (int64, error)
+}
+
+type maintenanceRepository struct {
+	dbConn      *pgx.Conn
+	redisClient *redis.Client
+}
+
+func NewMaintenanceRepo(dbConn *pgx.Conn, redisClient *redis.Client) MaintenanceRepository {
+	return maintenanceRepository{
+		dbConn:      dbConn,
+		redisClient: redisClient,
+	}
+}
+
+// CreateAdminUser creates user having admin flag with already hashed password, email of the user is treated as verified
+// because it is given by operator. privacy settings which are not given are set same as for registration.
+func (m maintenanceRepository) CreateAdminUser(user request.User) (int64, error) {
+	ctx := context.Background()
+	var userID int64
+	tx, err := m.dbConn.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	privacy := DefaultPrivacySettings(user.PrivacySettings)
+	rows := tx.QueryRow(ctx, `INSERT INTO users (first_name, last_name, bio, email, password, email_visibility, bio_visibility, teams_visibility, discoverable, assignable_by, email_verified, is_admin)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, true, true) RETURNING id`, user.FirstName, user.LastName, user.Bio, user.Email, user.Password,
+		privacy.EmailVisibility, privacy.BioVisibility, privacy.TeamsVisibility, privacy.Discoverable, privacy.AssignableBy)
+	err = rows.Scan(&userID)
+	if err != nil {
+		tx.Rollback(ctx)
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == constant.PG_Duplicate_Error_Code {
+			return 0, errorhandling.DuplicateEmailFound
+		}
+		return 0, err
+	}
+	err = SavePasswordHistory(ctx, tx, userID, user.Password)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	return userID, nil
+}
+
+// SetUserPassword replaces password of the user having given email with given plain text password, password history rules
+// still apply. all sessions of the user are revoked so that whoever knew the old password is logged out.
+func (m maintenanceRepository) SetUserPassword(email string, password string) error {
+	ctx := context.Background()
+	var userID int64
+	err := m.dbConn.QueryRow(ctx, `SELECT id FROM users WHERE email = $1`, email).Scan(&userID)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return errorhandling.NoUserFound
+		}
+		return err
+	}
+
+	tx, err := m.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	err = CheckPasswordReuse(ctx, tx, userID, password, "password")
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	_, err = tx.Exec(ctx, "UPDATE users SET password = $1, password_reset_required = false WHERE id = $2", hashedPassword, userID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = SavePasswordHistory(ctx, tx, userID, hashedPassword)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	revokedAt := time.Now()
+	err = RevokeAllSessionsOfUser(ctx, tx, userID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return utils.DenyAllAccessTokensOfUser(m.redisClient, userID, revokedAt)
+}
+
+// RebuildTaskCache drops cached tasks and teams of users from redis and writes them again from database,
+// it returns number of cached tasks. it is meant for when redis was flushed or has drifted from database.
+func (m maintenanceRepository) RebuildTaskCache() (int64, error) {
+	ctx := context.Background()
+	for _, pattern := range []string{constant.TASK_CACHE_KEY_PATTERN, constant.USER_TEAMS_KEY_PATTERN} {
+		iter := m.redisClient.Scan(ctx, 0, pattern, 0).Iterator()
+		for iter.Next(ctx) {
+			if err := m.redisClient.Del(ctx, iter.Val()).Err(); err != nil {
+				return 0, err
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	rows, err := m.dbConn.Query(ctx, `SELECT `+TaskColumns+` FROM tasks`)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var taskCount int64
+	pipe := m.redisClient.Pipeline()
+	for rows.Next() {
+		var task request.Task
+		err = rows.Scan(&task.ID, &task.Title, &task.Description, &task.Deadline, &task.AssigneeIndividual, &task.AssigneeTeam, &task.Status, &task.Priority,
+			&task.CreatedBy, &task.CreatedAt, &task.UpdatedBy, &task.UpdatedAt, &task.OwnerIndividual, &task.OrganizationID, &task.ProjectID, &task.MilestoneID)
+		if err != nil {
+			return 0, err
+		}
+		taskJSON, err := json.Marshal(task)
+		if err != nil {
+			return 0, err
+		}
+		pipe.Set(ctx, "tasks:"+strconv.FormatInt(task.ID, 10), taskJSON, 0)
+		if task.AssigneeTeam != nil {
+			pipe.SAdd(ctx, "tasks:assigned_to_team:"+strconv.FormatInt(*task.AssigneeTeam, 10), task.ID)
+		}
+		if task.AssigneeIndividual != nil {
+			pipe.SAdd(ctx, "tasks:assigned_to_user:"+strconv.FormatInt(*task.AssigneeIndividual, 10), task.ID)
+		}
+		pipe.SAdd(ctx, "tasks:created_by:"+strconv.FormatInt(task.CreatedBy, 10), task.ID)
+		taskCount++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	memberRows, err := m.dbConn.Query(ctx, `SELECT team_id, member_id FROM team_members`)
+	if err != nil {
+		return 0, err
+	}
+	defer memberRows.Close()
+	for memberRows.Next() {
+		var teamId, memberId int64
+		if err := memberRows.Scan(&teamId, &memberId); err != nil {
+			return 0, err
+		}
+		pipe.SAdd(ctx, "user:"+strconv.FormatInt(memberId, 10)+":teams", teamId)
+	}
+	if err := memberRows.Err(); err != nil {
+		return 0, err
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return taskCount, nil
+}
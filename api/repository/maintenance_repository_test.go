@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAdminUser(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		Email        string
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Admin Created Successfully.",
+			Email:        "tmsadmin@gmail.com",
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "Duplicate Email Found.",
+			Email:        "dhyey@gmail.com",
+			Expected:     errorhandling.DuplicateEmailFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			userId, err := NewMaintenanceRepo(dbConn, redisClient).CreateAdminUser(request.User{
+				FirstName: "System",
+				LastName:  "Admin",
+				Bio:       "System Administrator",
+				Email:     v.Email,
+				Password:  "$2a$14$FhDiMSnCN8sJ7Tb0UDBXn.bbKVYF3b4ZVwEwPXfAzvDgXZlC3B1g2",
+			})
+			assert.Equal(t, v.Expected, err)
+			if err == nil {
+				accountStatus, err := NewAuthRepo(dbConn, redisClient, rabbitmqConn).GetAccountStatus(userId)
+				assert.NoError(t, err)
+				assert.Equal(t, true, accountStatus.IsAdmin)
+			}
+		})
+	}
+}
+
+func TestSetUserPassword(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		Email        string
+		Password     string
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Password Set Successfully.",
+			Email:        "meetpatel@gmail.com",
+			Password:     "MeetPatel2024$",
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "No User Found.",
+			Email:        "nirajdarji@gmail.com",
+			Password:     "MeetPatel2024$",
+			Expected:     errorhandling.NoUserFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := NewMaintenanceRepo(dbConn, redisClient).SetUserPassword(v.Email, v.Password)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+
+	err := NewMaintenanceRepo(dbConn, redisClient).SetUserPassword("meetpatel@gmail.com", "MeetPatel2024$")
+	assert.Error(t, err)
+
+	_, _, err = NewAuthRepo(dbConn, redisClient, rabbitmqConn).UserLogin(request.UserCredentials{Email: "meetpatel@gmail.com", Password: "MeetPatel2024$"}, request.ClientInfo{})
+	assert.NoError(t, err)
+}
+
+func TestRebuildTaskCache(t *testing.T) {
+	var taskCount int64
+	dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM tasks`).Scan(&taskCount)
+
+	cachedTasks, err := NewMaintenanceRepo(dbConn, redisClient).RebuildTaskCache()
+	assert.NoError(t, err)
+	assert.Equal(t, taskCount, cachedTasks)
+
+	tasks, err := GetTasksFromRedisByIDList(redisClient, []string{"954511608047501313"})
+	assert.NoError(t, err)
+	assert.Equal(t, "task3", tasks[0].Title)
+
+	isMember, err := redisClient.SIsMember(context.Background(), "user:954488202459119617:teams", "954507580144451585").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, true, isMember)
+}
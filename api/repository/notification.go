@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/chirag1807/task-management-system/api/model/dto"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/chirag1807/task-management-system/utils/socket"
+	"github.com/go-redis/redis/v8"
+	socketio "github.com/googollee/go-socket.io"
+	"github.com/jackc/pgx/v5"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// NotificationChannelOf returns channel which user has chosen in preferences for notifications of given event.
+func NotificationChannelOf(preferences response.UserPreferences, event string) string {
+	switch event {
+	case constant.NOTIFY_TASK_ASSIGNED:
+		return preferences.Notifications.TaskAssigned
+	case constant.NOTIFY_TASK_UPDATED:
+		return preferences.Notifications.TaskUpdated
+	case constant.NOTIFY_SECURITY_ALERT:
+		return preferences.Notifications.SecurityAlert
+	}
+	return constant.NOTIFICATION_CHANNEL_NONE
+}
+
+// NotifyUserOfTask notifies user about task assigned to them or updated over channel chosen by user for the event,
+// socket event is named by id of the user and carries the task and is counted as unread notification of the user, while email shows deadline
+// in timezone of the user. failures are only logged as task is already saved by then.
+func NotifyUserOfTask(ctx context.Context, dbConn *pgx.Conn, redisClient *redis.Client, socketServer *socketio.Server, rabbitmqConn *amqp.Connection, userID int64,
+	event string, task dto.TaskNotification, payload interface{}) {
+	recipient, err := LoadNotificationRecipient(ctx, dbConn, userID)
+	if err != nil {
+		config.LoggerInstance.Warning(err.Error())
+		return
+	}
+
+	switch NotificationChannelOf(recipient.Preferences, event) {
+	case constant.NOTIFICATION_CHANNEL_SOCKET:
+		socket.EmitCreateAndUpdateTaskEvents(socketServer, strconv.FormatInt(userID, 10), constant.EMPTY_STRING, payload, 0)
+		if err := redisClient.Incr(ctx, "notifications:unread:"+strconv.FormatInt(userID, 10)).Err(); err != nil {
+			config.LoggerInstance.Warning(err.Error())
+		}
+	case constant.NOTIFICATION_CHANNEL_EMAIL:
+		subject, message := "Task Updated: "+task.Title, "A task assigned to you has been updated."
+		if event == constant.NOTIFY_TASK_ASSIGNED {
+			subject, message = "Task Assigned: "+task.Title, "A new task has been assigned to you."
+		}
+		err = utils.ProduceEmail(rabbitmqConn, dto.Email{
+			To:      recipient.Email,
+			Subject: subject,
+			Body:    utils.PrepareTaskEmailBody(message, task, recipient.Preferences),
+		})
+		if err != nil {
+			config.LoggerInstance.Warning(err.Error())
+		}
+	}
+}
+
+// SendSecurityAlert emails security alert to the user unless user has turned security alerts off,
+// body is prepared with preferences of the user so that times in it are shown in timezone of the user.
+func SendSecurityAlert(ctx context.Context, dbConn *pgx.Conn, rabbitmqConn *amqp.Connection, userID int64, subject string,
+	prepareBody func(preferences response.UserPreferences) string) {
+	recipient, err := LoadNotificationRecipient(ctx, dbConn, userID)
+	if err != nil {
+		config.LoggerInstance.Warning(err.Error())
+		return
+	}
+	if NotificationChannelOf(recipient.Preferences, constant.NOTIFY_SECURITY_ALERT) != constant.NOTIFICATION_CHANNEL_EMAIL {
+		return
+	}
+
+	err = utils.ProduceEmail(rabbitmqConn, dto.Email{
+		To:      recipient.Email,
+		Subject: subject,
+		Body:    prepareBody(recipient.Preferences),
+	})
+	if err != nil {
+		config.LoggerInstance.Warning(err.Error())
+	}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/dto"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+// FindOrCreateOIDCUser returns user linked with identity of given claims within given transaction.
+// identity which is not linked yet is linked with user having same email, only if identity provider has verified that email,
+// otherwise new user is provisioned from claims. provisioned user has no password, so it can login only via single sign-on till password is reset.
+func FindOrCreateOIDCUser(ctx context.Context, tx pgx.Tx, oidcClaims dto.OIDCClaims) (response.User, error) {
+	var dbUser response.User
+	rows := tx.QueryRow(ctx, `UPDATE user_identities SET last_login_at = $1 WHERE issuer = $2 AND subject = $3 RETURNING user_id`,
+		time.Now(), oidcClaims.Issuer, oidcClaims.Subject)
+	err := rows.Scan(&dbUser.ID)
+	if err == nil {
+		return getOIDCUser(ctx, tx, dbUser.ID)
+	}
+	if err.Error() != constant.PG_NO_ROWS {
+		return response.User{}, err
+	}
+
+	if !oidcClaims.EmailVerified || oidcClaims.Email == constant.EMPTY_STRING {
+		return response.User{}, errorhandling.OIDCEmailNotVerified
+	}
+
+	rows = tx.QueryRow(ctx, `SELECT id FROM users WHERE email = $1`, oidcClaims.Email)
+	err = rows.Scan(&dbUser.ID)
+	if err != nil {
+		if err.Error() != constant.PG_NO_ROWS {
+			return response.User{}, err
+		}
+		firstName := oidcClaims.GivenName
+		if firstName == constant.EMPTY_STRING {
+			firstName = strings.Split(oidcClaims.Email, "@")[0]
+		}
+		rows = tx.QueryRow(ctx, `INSERT INTO users (first_name, last_name, bio, email, email_verified) VALUES ($1, $2, $3, $4, true) RETURNING id`,
+			firstName, oidcClaims.FamilyName, constant.EMPTY_STRING, oidcClaims.Email)
+		err = rows.Scan(&dbUser.ID)
+		if err != nil {
+			return response.User{}, err
+		}
+	} else {
+		// identity provider has verified the email, so ownership is proved for existing user too.
+		_, err = tx.Exec(ctx, `UPDATE users SET email_verified = true WHERE id = $1`, dbUser.ID)
+		if err != nil {
+			return response.User{}, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO user_identities (user_id, issuer, subject) VALUES ($1, $2, $3)`, dbUser.ID, oidcClaims.Issuer, oidcClaims.Subject)
+	if err != nil {
+		return response.User{}, err
+	}
+	return getOIDCUser(ctx, tx, dbUser.ID)
+}
+
+func getOIDCUser(ctx context.Context, tx pgx.Tx, userID int64) (response.User, error) {
+	var dbUser response.User
+	var avatarKey *string
+	rows := tx.QueryRow(ctx, `SELECT id, first_name, last_name, bio, email, email_visibility, bio_visibility, teams_visibility, discoverable, assignable_by, email_verified, avatar_key FROM users WHERE id = $1`, userID)
+	err := rows.Scan(&dbUser.ID, &dbUser.FirstName, &dbUser.LastName, &dbUser.Bio, &dbUser.Email, &dbUser.Privacy.EmailVisibility, &dbUser.Privacy.BioVisibility, &dbUser.Privacy.TeamsVisibility, &dbUser.Privacy.Discoverable, &dbUser.Privacy.AssignableBy, &dbUser.EmailVerified, &avatarKey)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return response.User{}, errorhandling.NoUserFound
+		}
+		return response.User{}, err
+	}
+	dbUser.Avatar = utils.AvatarOf(avatarKey)
+	return dbUser, nil
+}
@@ -0,0 +1,342 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/go-redis/redis/v8"
+	socketio "github.com/googollee/go-socket.io"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type OrganizationRepository interface {
+	CreateOrganization(organizationToCreate request.Organization) (int64, error)
+	GetMyOrganizations(userId int64) ([]response.Organization, error)
+	GetOrganizationRole(organizationId int64, userId int64) (string, error)
+	GetOnlyOrganization(userId int64) (int64, string, error)
+	GetOrganizationMembers(userId int64, organizationId int64, queryParams request.UserQueryParams) ([]response.OrganizationMember, error)
+	AddMemberToOrganization(addedBy int64, memberToAdd request.OrganizationMember) (int64, error)
+	RemoveMemberFromOrganization(removedBy int64, organizationId int64, memberId int64) error
+}
+
+type organizationRepository struct {
+	dbConn       *pgx.Conn
+	redisClient  *redis.Client
+	socketServer *socketio.Server
+}
+
+func NewOrganizationRepo(dbConn *pgx.Conn, redisClient *redis.Client, socketServer *socketio.Server) OrganizationRepository {
+	return organizationRepository{
+		dbConn:       dbConn,
+		redisClient:  redisClient,
+		socketServer: socketServer,
+	}
+}
+
+// CreateOrganization creates organization and makes the user who created it it's owner.
+func (o organizationRepository) CreateOrganization(organizationToCreate request.Organization) (int64, error) {
+	ctx := context.Background()
+	tx, err := o.dbConn.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var organizationId int64
+	err = tx.QueryRow(ctx, `INSERT INTO organizations (name, created_by) VALUES ($1, $2) RETURNING id`, organizationToCreate.Name, organizationToCreate.CreatedBy).
+		Scan(&organizationId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO organization_members (organization_id, member_id, role) VALUES ($1, $2, $3)`, organizationId, organizationToCreate.CreatedBy,
+		constant.ORGANIZATION_ROLE_OWNER)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	return organizationId, nil
+}
+
+func (o organizationRepository) GetMyOrganizations(userId int64) ([]response.Organization, error) {
+	organizationsSlice := make([]response.Organization, 0)
+	organizations, err := o.dbConn.Query(context.Background(), `SELECT o.id, o.name, o.created_by, o.created_at, om.role FROM organizations as o
+	JOIN organization_members as om ON om.organization_id = o.id WHERE om.member_id = $1 ORDER BY om.joined_at, o.id`, userId)
+	if err != nil {
+		return organizationsSlice, err
+	}
+	defer organizations.Close()
+
+	var organization response.Organization
+	for organizations.Next() {
+		if err := organizations.Scan(&organization.ID, &organization.Name, &organization.CreatedBy, &organization.CreatedAt, &organization.Role); err != nil {
+			return organizationsSlice, err
+		}
+		organizationsSlice = append(organizationsSlice, organization)
+	}
+	return organizationsSlice, organizations.Err()
+}
+
+// GetOrganizationRole returns role of the user in the organization, organization which user is not member of is reported as not found
+// so that existence of other organizations is not revealed.
+func (o organizationRepository) GetOrganizationRole(organizationId int64, userId int64) (string, error) {
+	var role string
+	err := o.dbConn.QueryRow(context.Background(), `SELECT role FROM organization_members WHERE organization_id = $1 AND member_id = $2`, organizationId, userId).
+		Scan(&role)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return constant.EMPTY_STRING, errorhandling.NoOrganizationFound
+		}
+		return constant.EMPTY_STRING, err
+	}
+	return role, nil
+}
+
+// GetOnlyOrganization returns organization of the user along with their role in it if user is member of exactly one organization,
+// otherwise organization can't be chosen on behalf of the user and OrganizationRequired error is returned.
+func (o organizationRepository) GetOnlyOrganization(userId int64) (int64, string, error) {
+	organizations, err := o.dbConn.Query(context.Background(), `SELECT organization_id, role FROM organization_members WHERE member_id = $1 LIMIT 2`, userId)
+	if err != nil {
+		return 0, constant.EMPTY_STRING, err
+	}
+	defer organizations.Close()
+
+	var organizationId int64
+	var role string
+	var organizationCount int
+	for organizations.Next() {
+		if err := organizations.Scan(&organizationId, &role); err != nil {
+			return 0, constant.EMPTY_STRING, err
+		}
+		organizationCount++
+	}
+	if err := organizations.Err(); err != nil {
+		return 0, constant.EMPTY_STRING, err
+	}
+	if organizationCount != 1 {
+		return 0, constant.EMPTY_STRING, errorhandling.OrganizationRequired
+	}
+	return organizationId, role, nil
+}
+
+// GetOrganizationMembers returns members of the organization along with their role, email of members is left out as per their privacy settings
+// where only teams of this organization make them teammates of the viewer.
+func (o organizationRepository) GetOrganizationMembers(userId int64, organizationId int64, queryParams request.UserQueryParams) ([]response.OrganizationMember, error) {
+	membersSlice := make([]response.OrganizationMember, 0)
+	query := `SELECT u.id, u.first_name, u.last_name, u.email, u.email_visibility, om.role, om.joined_at, ` + TeammateOfViewer("$2") + ` FROM organization_members as om
+	JOIN users as u ON u.id = om.member_id WHERE om.organization_id = $2 AND EXISTS (SELECT 1 FROM organization_members WHERE organization_id = $2 AND member_id = $1)`
+	query = CreateQueryForParamsOfGetUser(query, queryParams)
+	members, err := o.dbConn.Query(context.Background(), query, userId, organizationId)
+	if err != nil {
+		return membersSlice, err
+	}
+	defer members.Close()
+
+	var member response.OrganizationMember
+	for members.Next() {
+		var emailVisibility string
+		var isTeammate bool
+		if err := members.Scan(&member.ID, &member.FirstName, &member.LastName, &member.Email, &emailVisibility, &member.Role, &member.JoinedAt, &isTeammate); err != nil {
+			return membersSlice, err
+		}
+		if !IsVisibleTo(emailVisibility, member.ID == userId, isTeammate) {
+			member.Email = constant.EMPTY_STRING
+		}
+		membersSlice = append(membersSlice, member)
+	}
+	return membersSlice, members.Err()
+}
+
+// AddMemberToOrganization adds user having given email to the organization and returns their id, only owners and admins can add members
+// and only owners can add other owners.
+func (o organizationRepository) AddMemberToOrganization(addedBy int64, memberToAdd request.OrganizationMember) (int64, error) {
+	addedByRole, err := o.GetOrganizationRole(memberToAdd.OrganizationID, addedBy)
+	if err != nil {
+		return 0, err
+	}
+	if addedByRole != constant.ORGANIZATION_ROLE_OWNER && addedByRole != constant.ORGANIZATION_ROLE_ADMIN {
+		return 0, errorhandling.OrganizationAdminRequired
+	}
+	if memberToAdd.Role == constant.EMPTY_STRING {
+		memberToAdd.Role = constant.ORGANIZATION_ROLE_MEMBER
+	}
+	if memberToAdd.Role == constant.ORGANIZATION_ROLE_OWNER && addedByRole != constant.ORGANIZATION_ROLE_OWNER {
+		return 0, errorhandling.NotAllowed
+	}
+
+	var memberId int64
+	err = o.dbConn.QueryRow(context.Background(), `SELECT id FROM users WHERE email = $1`, memberToAdd.Email).Scan(&memberId)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return 0, errorhandling.NoEmailFound
+		}
+		return 0, err
+	}
+
+	_, err = o.dbConn.Exec(context.Background(), `INSERT INTO organization_members (organization_id, member_id, role) VALUES ($1, $2, $3)`, memberToAdd.OrganizationID,
+		memberId, memberToAdd.Role)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == constant.PG_Duplicate_Error_Code {
+			return 0, errorhandling.OrganizationMemberExist
+		}
+		return 0, err
+	}
+	return memberId, nil
+}
+
+// RemoveMemberFromOrganization removes member from the organization and from all teams and projects of it, members can remove themselves
+// while others can be removed only by owners and admins, owners can be removed only by owners.
+// last owner of the organization and member who owns teams or projects of it can't be removed.
+func (o organizationRepository) RemoveMemberFromOrganization(removedBy int64, organizationId int64, memberId int64) error {
+	removedByRole, err := o.GetOrganizationRole(organizationId, removedBy)
+	if err != nil {
+		return err
+	}
+	memberRole, err := o.GetOrganizationRole(organizationId, memberId)
+	if err != nil {
+		if err == errorhandling.NoOrganizationFound {
+			return errorhandling.NotMemberOfOrganization
+		}
+		return err
+	}
+	if removedBy != memberId {
+		if removedByRole != constant.ORGANIZATION_ROLE_OWNER && removedByRole != constant.ORGANIZATION_ROLE_ADMIN {
+			return errorhandling.OrganizationAdminRequired
+		}
+		if memberRole == constant.ORGANIZATION_ROLE_OWNER && removedByRole != constant.ORGANIZATION_ROLE_OWNER {
+			return errorhandling.NotAllowed
+		}
+	}
+
+	ctx := context.Background()
+	if memberRole == constant.ORGANIZATION_ROLE_OWNER {
+		var ownerCount int
+		err = o.dbConn.QueryRow(ctx, `SELECT COUNT(*) FROM organization_members WHERE organization_id = $1 AND role = $2`, organizationId, constant.ORGANIZATION_ROLE_OWNER).
+			Scan(&ownerCount)
+		if err != nil {
+			return err
+		}
+		if ownerCount == 1 {
+			return errorhandling.LastOrganizationOwner
+		}
+	}
+	var ownedTeamCount int
+	err = o.dbConn.QueryRow(ctx, `SELECT COUNT(*) FROM teams WHERE organization_id = $1 AND created_by = $2`, organizationId, memberId).Scan(&ownedTeamCount)
+	if err != nil {
+		return err
+	}
+	if ownedTeamCount != 0 {
+		return errorhandling.OrganizationMemberOwnsTeams
+	}
+	var ownedProjectCount int
+	err = o.dbConn.QueryRow(ctx, `SELECT COUNT(*) FROM projects WHERE organization_id = $1 AND owner_id = $2`, organizationId, memberId).Scan(&ownedProjectCount)
+	if err != nil {
+		return err
+	}
+	if ownedProjectCount != 0 {
+		return errorhandling.OrganizationMemberOwnsProjects
+	}
+
+	tx, err := o.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	teams, err := tx.Query(ctx, `DELETE FROM team_members WHERE member_id = $1 AND team_id IN (SELECT id FROM teams WHERE organization_id = $2) RETURNING team_id`,
+		memberId, organizationId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	var teamIds []int64
+	for teams.Next() {
+		var teamId int64
+		if err := teams.Scan(&teamId); err != nil {
+			teams.Close()
+			tx.Rollback(ctx)
+			return err
+		}
+		teamIds = append(teamIds, teamId)
+	}
+	teams.Close()
+	if err := teams.Err(); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	activityType := constant.TEAM_ACTIVITY_MEMBER_REMOVED
+	if removedBy == memberId {
+		activityType = constant.TEAM_ACTIVITY_MEMBER_LEFT
+	}
+	var activitiesToInsert []request.TeamActivity
+	for _, teamId := range teamIds {
+		activitiesToInsert = append(activitiesToInsert, request.TeamActivity{TeamID: teamId, ActorID: removedBy, Type: activityType, MemberID: &memberId})
+	}
+	activities, err := InsertTeamActivities(ctx, tx, activitiesToInsert)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM project_members WHERE member_id = $1 AND project_id IN (SELECT id FROM projects WHERE organization_id = $2)`, memberId, organizationId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM organization_members WHERE organization_id = $1 AND member_id = $2`, organizationId, memberId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	for _, teamId := range teamIds {
+		o.redisClient.SRem(ctx, "user:"+strconv.FormatInt(memberId, 10)+":teams", teamId)
+	}
+	EmitTeamActivities(o.socketServer, activities)
+	return nil
+}
+
+// CheckTeamOfOrganization returns NoTeamFound error if team doesn't exist in the organization, so that teams of other organizations
+// can't be told apart from teams which don't exist.
+func CheckTeamOfOrganization(ctx context.Context, dbConn *pgx.Conn, organizationId int64, teamId int64) error {
+	var teamCount int
+	err := dbConn.QueryRow(ctx, `SELECT COUNT(*) FROM teams WHERE id = $1 AND organization_id = $2`, teamId, organizationId).Scan(&teamCount)
+	if err != nil {
+		return err
+	}
+	if teamCount == 0 {
+		return errorhandling.NoTeamFound
+	}
+	return nil
+}
+
+// CheckOrganizationMembers returns NotMemberOfOrganization error if any of given users is not member of the organization.
+func CheckOrganizationMembers(ctx context.Context, dbConn *pgx.Conn, organizationId int64, userIds []int64) error {
+	distinctUserIds := make(map[int64]bool)
+	for _, v := range userIds {
+		distinctUserIds[v] = true
+	}
+	var memberCount int
+	err := dbConn.QueryRow(ctx, `SELECT COUNT(*) FROM organization_members WHERE organization_id = $1 AND member_id = ANY($2)`, organizationId, userIds).
+		Scan(&memberCount)
+	if err != nil {
+		return err
+	}
+	if memberCount != len(distinctUserIds) {
+		return errorhandling.NotMemberOfOrganization
+	}
+	return nil
+}
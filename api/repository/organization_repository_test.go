@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOrganization(t *testing.T) {
+	organizationId, err := NewOrganizationRepo(dbConn, redisClient, socketServer).CreateOrganization(request.Organization{
+		Name:      "Organization C",
+		CreatedBy: 954497896847212545,
+	})
+	assert.NoError(t, err)
+
+	role, err := NewOrganizationRepo(dbConn, redisClient, socketServer).GetOrganizationRole(organizationId, 954497896847212545)
+	assert.NoError(t, err)
+	assert.Equal(t, constant.ORGANIZATION_ROLE_OWNER, role)
+}
+
+func TestGetMyOrganizations(t *testing.T) {
+	organizations, err := NewOrganizationRepo(dbConn, redisClient, socketServer).GetMyOrganizations(954488202459119617)
+	assert.NoError(t, err)
+	assert.Len(t, organizations, 1)
+	assert.Equal(t, int64(954560000000000001), organizations[0].ID)
+	assert.Equal(t, constant.ORGANIZATION_ROLE_OWNER, organizations[0].Role)
+}
+
+func TestGetOrganizationRole(t *testing.T) {
+	testCases := []struct {
+		TestCaseName   string
+		OrganizationID int64
+		UserID         int64
+		Expected       interface{}
+		Role           string
+	}{
+		{
+			TestCaseName:   "Role of Member Fetched.",
+			OrganizationID: 954560000000000001,
+			UserID:         954497896847212547,
+			Expected:       nil,
+			Role:           constant.ORGANIZATION_ROLE_MEMBER,
+		},
+		{
+			TestCaseName:   "User is Not Member of Organization.",
+			OrganizationID: 954560000000000002,
+			UserID:         954488202459119617,
+			Expected:       errorhandling.NoOrganizationFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			role, err := NewOrganizationRepo(dbConn, redisClient, socketServer).GetOrganizationRole(v.OrganizationID, v.UserID)
+			assert.Equal(t, v.Expected, err)
+			assert.Equal(t, v.Role, role)
+		})
+	}
+}
+
+func TestGetOnlyOrganization(t *testing.T) {
+	testCases := []struct {
+		TestCaseName   string
+		UserID         int64
+		OrganizationID int64
+		Expected       interface{}
+	}{
+		{
+			TestCaseName:   "User is Member of One Organization.",
+			UserID:         954497896847212548,
+			OrganizationID: 954560000000000002,
+			Expected:       nil,
+		},
+		{
+			TestCaseName: "User is Member of More Than One Organization.",
+			UserID:       954497896847212545,
+			Expected:     errorhandling.OrganizationRequired,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			organizationId, _, err := NewOrganizationRepo(dbConn, redisClient, socketServer).GetOnlyOrganization(v.UserID)
+			assert.Equal(t, v.Expected, err)
+			assert.Equal(t, v.OrganizationID, organizationId)
+		})
+	}
+}
+
+func TestGetOrganizationMembers(t *testing.T) {
+	members, err := NewOrganizationRepo(dbConn, redisClient, socketServer).GetOrganizationMembers(954497896847212548, 954560000000000002, request.UserQueryParams{Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, members, 1)
+	assert.Equal(t, int64(954497896847212548), members[0].ID)
+}
+
+func TestAddMemberToOrganization(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		AddedBy      int64
+		Member       request.OrganizationMember
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Only Members Can Add Members.",
+			AddedBy:      954497896847212545,
+			Member:       request.OrganizationMember{OrganizationID: 954560000000000002, Email: "ridham@gmail.com"},
+			Expected:     errorhandling.NoOrganizationFound,
+		},
+		{
+			TestCaseName: "Member Added Successfully.",
+			AddedBy:      954497896847212548,
+			Member:       request.OrganizationMember{OrganizationID: 954560000000000002, Email: "ridham@gmail.com", Role: constant.ORGANIZATION_ROLE_ADMIN},
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "Member Already Exist.",
+			AddedBy:      954497896847212548,
+			Member:       request.OrganizationMember{OrganizationID: 954560000000000002, Email: "ridham@gmail.com"},
+			Expected:     errorhandling.OrganizationMemberExist,
+		},
+		{
+			TestCaseName: "Admin Can't Add Owner.",
+			AddedBy:      954497896847212545,
+			Member:       request.OrganizationMember{OrganizationID: 954560000000000002, Email: "jayshah@gmail.com", Role: constant.ORGANIZATION_ROLE_OWNER},
+			Expected:     errorhandling.NotAllowed,
+		},
+		{
+			TestCaseName: "Member Can't Add Members.",
+			AddedBy:      954497896847212547,
+			Member:       request.OrganizationMember{OrganizationID: 954560000000000001, Email: "meetpatel@gmail.com"},
+			Expected:     errorhandling.OrganizationAdminRequired,
+		},
+		{
+			TestCaseName: "No User Found with Email.",
+			AddedBy:      954497896847212548,
+			Member:       request.OrganizationMember{OrganizationID: 954560000000000002, Email: "nobody@gmail.com"},
+			Expected:     errorhandling.NoEmailFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			_, err := NewOrganizationRepo(dbConn, redisClient, socketServer).AddMemberToOrganization(v.AddedBy, v.Member)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestRemoveMemberFromOrganization(t *testing.T) {
+	// member who is removed from organization is removed from it's teams as well.
+	err := NewTeamRepo(dbConn, redisClient, socketServer).AddMembersToTeam(954560000000000002, 954497896847212548, request.TeamMembersWithTeamID{
+		TeamID:    954507580144451588,
+		MemberIDs: []int64{954497896847212545},
+	})
+	assert.NoError(t, err)
+	_, err = NewProjectRepo(dbConn, redisClient).CreateProject(request.Project{Name: "Ridham Project", OwnerID: 954497896847212545, OrganizationID: 954560000000000001})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		TestCaseName   string
+		RemovedBy      int64
+		OrganizationID int64
+		MemberID       int64
+		Expected       interface{}
+	}{
+		{
+			TestCaseName:   "Admin Can't Remove Owner.",
+			RemovedBy:      954497896847212545,
+			OrganizationID: 954560000000000002,
+			MemberID:       954497896847212548,
+			Expected:       errorhandling.NotAllowed,
+		},
+		{
+			TestCaseName:   "Last Owner Can't Leave.",
+			RemovedBy:      954497896847212548,
+			OrganizationID: 954560000000000002,
+			MemberID:       954497896847212548,
+			Expected:       errorhandling.LastOrganizationOwner,
+		},
+		{
+			TestCaseName:   "Member Owns Teams of Organization.",
+			RemovedBy:      954488202459119617,
+			OrganizationID: 954560000000000001,
+			MemberID:       954497896847212547,
+			Expected:       errorhandling.OrganizationMemberOwnsTeams,
+		},
+		{
+			TestCaseName:   "Member Owns Projects of Organization.",
+			RemovedBy:      954488202459119617,
+			OrganizationID: 954560000000000001,
+			MemberID:       954497896847212545,
+			Expected:       errorhandling.OrganizationMemberOwnsProjects,
+		},
+		{
+			TestCaseName:   "User is Not Member of Organization.",
+			RemovedBy:      954497896847212548,
+			OrganizationID: 954560000000000002,
+			MemberID:       954488202459119617,
+			Expected:       errorhandling.NotMemberOfOrganization,
+		},
+		{
+			TestCaseName:   "Member Removed Successfully.",
+			RemovedBy:      954497896847212548,
+			OrganizationID: 954560000000000002,
+			MemberID:       954497896847212545,
+			Expected:       nil,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := NewOrganizationRepo(dbConn, redisClient, socketServer).RemoveMemberFromOrganization(v.RemovedBy, v.OrganizationID, v.MemberID)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+
+	var memberCount int
+	dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM team_members WHERE team_id = 954507580144451588 AND member_id = 954497896847212545`).Scan(&memberCount)
+	assert.Equal(t, 0, memberCount)
+}
+
+func TestCheckOrganizationMembers(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserIDs      []int64
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "All Users are Members of Organization.",
+			UserIDs:      []int64{954488202459119617, 954497896847212545, 954488202459119617},
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "User of Other Organization.",
+			UserIDs:      []int64{954488202459119617, 954497896847212548},
+			Expected:     errorhandling.NotMemberOfOrganization,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := CheckOrganizationMembers(context.Background(), dbConn, 954560000000000001, v.UserIDs)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
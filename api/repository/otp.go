@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"crypto/subtle"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/dto"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/jackc/pgx/v5"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// CreateOTP generates new otp for given email and purpose within given transaction and returns it, only its hash is stored in database.
+// previous unused otps of same email and purpose are expired, so that only latest otp sent to the user can be used.
+func CreateOTP(ctx context.Context, tx pgx.Tx, email string, purpose string) (string, error) {
+	now := time.Now().UTC()
+	_, err := tx.Exec(ctx, `UPDATE otps SET expires_at = $1 WHERE email = $2 AND purpose = $3 AND used_at IS NULL AND expires_at > $1`, now, email, purpose)
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+
+	otp, err := utils.GenerateOTP(utils.OTPLength())
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO otps (email, purpose, code_hash, expires_at) VALUES ($1, $2, $3, $4)`,
+		email, purpose, utils.HashOTP(otp, email, purpose), now.Add(constant.OTP_LIFETIME))
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	return otp, nil
+}
+
+// SendOTPEmail creates otp for given email and purpose within given transaction and queues email carrying it,
+// transaction should be rolled back if it fails so that no otp is stored which user never received.
+func SendOTPEmail(ctx context.Context, tx pgx.Tx, rabbitmqConn *amqp.Connection, email string, purpose string, subject string) error {
+	otp, err := CreateOTP(ctx, tx, email, purpose)
+	if err != nil {
+		return err
+	}
+	return utils.ProduceEmail(rabbitmqConn, dto.Email{
+		To:      email,
+		Subject: subject,
+		Body:    utils.PrepareEmailBody(otp, constant.OTP_LIFETIME),
+	})
+}
+
+// ConsumeOTP verifies otp against latest active otp of given email and purpose within given transaction and marks it as used, so it can't be used again.
+// id of the active otp is returned along with OTPNotMatched error so that wrong attempts can be counted for it.
+func ConsumeOTP(ctx context.Context, tx pgx.Tx, email string, purpose string, otp string) (int64, error) {
+	var otpID int64
+	var codeHash string
+	now := time.Now().UTC()
+	rows := tx.QueryRow(ctx, `SELECT id, code_hash FROM otps WHERE email = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > $3
+	ORDER BY created_at DESC LIMIT 1 FOR UPDATE`, email, purpose, now)
+	err := rows.Scan(&otpID, &codeHash)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return 0, errorhandling.OTPVerificationTimeExpired
+		}
+		return 0, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(codeHash), []byte(utils.HashOTP(otp, email, purpose))) != 1 {
+		return otpID, errorhandling.OTPNotMatched
+	}
+	_, err = tx.Exec(ctx, `UPDATE otps SET used_at = $1 WHERE id = $2`, now, otpID)
+	if err != nil {
+		return 0, err
+	}
+	return otpID, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+// CheckPasswordReuse compares plain text password with current password and last passwords of the user within given transaction
+// and returns RequestDataValidationError for given parameter name if it matches any of them, check is disabled if history size is not positive.
+func CheckPasswordReuse(ctx context.Context, tx pgx.Tx, userID int64, password string, parameterName string) error {
+	historySize := config.Config.PasswordPolicy.HistorySize
+	if historySize <= 0 {
+		return nil
+	}
+
+	var passwordHashes []string
+	var currentPassword *string
+	err := tx.QueryRow(ctx, `SELECT password FROM users WHERE id = $1`, userID).Scan(&currentPassword)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return errorhandling.NoUserFound
+		}
+		return err
+	}
+	if currentPassword != nil {
+		passwordHashes = append(passwordHashes, *currentPassword)
+	}
+
+	rows, err := tx.Query(ctx, `SELECT password_hash FROM password_history WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2`, userID, historySize)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var passwordHash string
+		if err := rows.Scan(&passwordHash); err != nil {
+			return err
+		}
+		passwordHashes = append(passwordHashes, passwordHash)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, passwordHash := range passwordHashes {
+		if utils.VerifyPassword(password, passwordHash) {
+			return errorhandling.CreateRequestDataValidationError([]errorhandling.InvalidRequestData{{
+				ParameterName: parameterName,
+				ErrorMessage:  fmt.Sprintf(constant.PASSWORD_REUSED, parameterName, historySize),
+			}}, http.StatusBadRequest)
+		}
+	}
+	return nil
+}
+
+// SavePasswordHistory stores hash of new password of the user within given transaction and prunes history to configured size.
+func SavePasswordHistory(ctx context.Context, tx pgx.Tx, userID int64, passwordHash string) error {
+	_, err := tx.Exec(ctx, `INSERT INTO password_history (user_id, password_hash) VALUES ($1, $2)`, userID, passwordHash)
+	if err != nil {
+		return err
+	}
+
+	historySize := config.Config.PasswordPolicy.HistorySize
+	if historySize < 1 {
+		historySize = 1
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM password_history WHERE user_id = $1 AND id NOT IN
+	(SELECT id FROM password_history WHERE user_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2)`, userID, historySize)
+	return err
+}
@@ -0,0 +1,439 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/go-redis/redis/v8"
+	"github.com/jackc/pgx/v5"
+)
+
+type ProjectRepository interface {
+	CreateProject(projectToCreate request.Project) (int64, error)
+	GetAllProjects(organizationId int64, userId int64, queryParams request.ProjectQueryParams) ([]response.Project, error)
+	GetProject(organizationId int64, userId int64, projectId int64) (response.Project, error)
+	UpdateProject(organizationId int64, userId int64, projectToUpdate request.UpdateProject) error
+	DeleteProject(organizationId int64, userId int64, projectId int64) error
+	GetTasksOfProject(organizationId int64, userId int64, projectId int64, queryParams request.TaskQueryParams) ([]response.Task, error)
+	CreateMilestone(organizationId int64, userId int64, milestoneToCreate request.Milestone) (int64, error)
+	GetMilestonesOfProject(organizationId int64, userId int64, projectId int64) ([]response.Milestone, error)
+	UpdateMilestone(organizationId int64, userId int64, milestoneToUpdate request.UpdateMilestone) error
+	DeleteMilestone(organizationId int64, userId int64, projectId int64, milestoneId int64) error
+}
+
+type projectRepository struct {
+	dbConn      *pgx.Conn
+	redisClient *redis.Client
+}
+
+func NewProjectRepo(dbConn *pgx.Conn, redisClient *redis.Client) ProjectRepository {
+	return projectRepository{
+		dbConn:      dbConn,
+		redisClient: redisClient,
+	}
+}
+
+// projectColumns are columns of project p in the order in which scanProject reads them, ids of members and teams having access are read along with them.
+const projectColumns = `p.id, p.organization_id, p.name, p.description, p.owner_id, p.status, p.created_at, p.updated_at,
+ARRAY(SELECT member_id FROM project_members WHERE project_id = p.id ORDER BY member_id), ARRAY(SELECT team_id FROM project_teams WHERE project_id = p.id ORDER BY team_id)`
+
+// ProjectAccessibleBy returns sql condition which tells whether user in given query parameter can access project p,
+// owner, members of the project and members of teams of the project can access it.
+func ProjectAccessibleBy(userParam string) string {
+	return `(p.owner_id = ` + userParam + ` OR EXISTS (SELECT 1 FROM project_members WHERE project_id = p.id AND member_id = ` + userParam + `)
+	OR EXISTS (SELECT 1 FROM project_teams as pt JOIN team_members as ptm ON ptm.team_id = pt.team_id WHERE pt.project_id = p.id AND ptm.member_id = ` + userParam + `))`
+}
+
+// CreateProject creates project with the user who created it as owner, members and teams given to access the project must be of the same organization.
+func (p projectRepository) CreateProject(projectToCreate request.Project) (int64, error) {
+	ctx := context.Background()
+	err := p.checkProjectAccessors(ctx, projectToCreate.OrganizationID, projectToCreate.MemberIDs, projectToCreate.TeamIDs)
+	if err != nil {
+		return 0, err
+	}
+	if projectToCreate.Status == constant.EMPTY_STRING {
+		projectToCreate.Status = constant.PROJECT_STATUS_ACTIVE
+	}
+
+	tx, err := p.dbConn.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var projectId int64
+	err = tx.QueryRow(ctx, `INSERT INTO projects (organization_id, name, description, owner_id, status) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		projectToCreate.OrganizationID, projectToCreate.Name, projectToCreate.Description, projectToCreate.OwnerID, projectToCreate.Status).Scan(&projectId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	err = setProjectAccessors(ctx, tx, projectId, projectToCreate.MemberIDs, projectToCreate.TeamIDs)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	return projectId, nil
+}
+
+// GetAllProjects returns projects of the organization which user can access.
+func (p projectRepository) GetAllProjects(organizationId int64, userId int64, queryParams request.ProjectQueryParams) ([]response.Project, error) {
+	projectsSlice := make([]response.Project, 0)
+	query := `SELECT ` + projectColumns + ` FROM projects as p WHERE p.organization_id = $2 AND ` + ProjectAccessibleBy("$1")
+	query = CreateQueryForParamsOfGetProject(query, queryParams)
+	projects, err := p.dbConn.Query(context.Background(), query, userId, organizationId)
+	if err != nil {
+		return projectsSlice, err
+	}
+	defer projects.Close()
+
+	var project response.Project
+	for projects.Next() {
+		if err := scanProject(projects, &project); err != nil {
+			return projectsSlice, err
+		}
+		projectsSlice = append(projectsSlice, project)
+	}
+	return projectsSlice, projects.Err()
+}
+
+func CreateQueryForParamsOfGetProject(query string, queryParams request.ProjectQueryParams) string {
+	if queryParams.Search != constant.EMPTY_STRING {
+		query += fmt.Sprintf(" AND p.name ILIKE '%%%s%%'", queryParams.Search)
+	}
+	if queryParams.Status != constant.EMPTY_STRING {
+		query += fmt.Sprintf(" AND p.status = '%s'", queryParams.Status)
+	}
+	query += " ORDER BY p.created_at DESC"
+	query += fmt.Sprintf(" LIMIT %d", queryParams.Limit)
+	query += fmt.Sprintf(" OFFSET %d", queryParams.Offset)
+	return query
+}
+
+func (p projectRepository) GetProject(organizationId int64, userId int64, projectId int64) (response.Project, error) {
+	var project response.Project
+	ctx := context.Background()
+	err := CheckProjectAccess(ctx, p.dbConn, organizationId, userId, projectId)
+	if err != nil {
+		return project, err
+	}
+	err = scanProject(p.dbConn.QueryRow(ctx, `SELECT `+projectColumns+` FROM projects as p WHERE p.id = $1`, projectId), &project)
+	return project, err
+}
+
+// UpdateProject updates fields of project which are sent, members and teams having access are replaced when their lists are sent. only owner can update the project.
+func (p projectRepository) UpdateProject(organizationId int64, userId int64, projectToUpdate request.UpdateProject) error {
+	ctx := context.Background()
+	err := p.checkProjectOwner(ctx, organizationId, userId, projectToUpdate.ID)
+	if err != nil {
+		return err
+	}
+	err = p.checkProjectAccessors(ctx, organizationId, projectToUpdate.MemberIDs, projectToUpdate.TeamIDs)
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `UPDATE projects SET name = COALESCE(NULLIF($1, ''), name), description = COALESCE($2, description),
+	status = COALESCE(NULLIF($3, '')::projectstatus, status), updated_at = $4 WHERE id = $5`, projectToUpdate.Name, projectToUpdate.Description, projectToUpdate.Status,
+		projectToUpdate.UpdatedAt, projectToUpdate.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if projectToUpdate.MemberIDs != nil {
+		_, err = tx.Exec(ctx, `DELETE FROM project_members WHERE project_id = $1`, projectToUpdate.ID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return err
+		}
+	}
+	if projectToUpdate.TeamIDs != nil {
+		_, err = tx.Exec(ctx, `DELETE FROM project_teams WHERE project_id = $1`, projectToUpdate.ID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return err
+		}
+	}
+	err = setProjectAccessors(ctx, tx, projectToUpdate.ID, projectToUpdate.MemberIDs, projectToUpdate.TeamIDs)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return nil
+}
+
+// DeleteProject deletes project along with it's milestones, tasks of the project are kept without project and milestone. only owner can delete the project.
+func (p projectRepository) DeleteProject(organizationId int64, userId int64, projectId int64) error {
+	ctx := context.Background()
+	err := p.checkProjectOwner(ctx, organizationId, userId, projectId)
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	taskIds, err := queryIDs(ctx, tx, `UPDATE tasks SET project_id = NULL, milestone_id = NULL WHERE project_id = $1 RETURNING id`, projectId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	for _, query := range []string{`DELETE FROM milestones WHERE project_id = $1`, `DELETE FROM project_members WHERE project_id = $1`,
+		`DELETE FROM project_teams WHERE project_id = $1`, `DELETE FROM projects WHERE id = $1`} {
+		if _, err := tx.Exec(ctx, query, projectId); err != nil {
+			tx.Rollback(ctx)
+			return err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return CacheTasksByID(ctx, p.dbConn, p.redisClient, taskIds)
+}
+
+// GetTasksOfProject returns tasks of the project filtered by query params in the same way as other task lists, anyone who can access the project can see all of it's tasks.
+func (p projectRepository) GetTasksOfProject(organizationId int64, userId int64, projectId int64, queryParams request.TaskQueryParams) ([]response.Task, error) {
+	tasksSlice := make([]response.Task, 0)
+	ctx := context.Background()
+	err := CheckProjectAccess(ctx, p.dbConn, organizationId, userId, projectId)
+	if err != nil {
+		return tasksSlice, err
+	}
+
+	query := `SELECT ` + TaskColumns + ` FROM tasks WHERE project_id = $1 AND organization_id = $2`
+	query = CreateQueryForParamsOfGetTask(query, queryParams)
+	tasks, err := p.dbConn.Query(ctx, query, projectId, organizationId)
+	if err != nil {
+		return tasksSlice, err
+	}
+	defer tasks.Close()
+
+	var task response.Task
+	for tasks.Next() {
+		if err := ScanTask(tasks, &task); err != nil {
+			return tasksSlice, err
+		}
+		tasksSlice = append(tasksSlice, task)
+	}
+	return tasksSlice, tasks.Err()
+}
+
+func (p projectRepository) CreateMilestone(organizationId int64, userId int64, milestoneToCreate request.Milestone) (int64, error) {
+	ctx := context.Background()
+	err := p.checkProjectOwner(ctx, organizationId, userId, milestoneToCreate.ProjectID)
+	if err != nil {
+		return 0, err
+	}
+
+	var milestoneId int64
+	err = p.dbConn.QueryRow(ctx, `INSERT INTO milestones (project_id, name, description, target_date) VALUES ($1, $2, $3, $4) RETURNING id`, milestoneToCreate.ProjectID,
+		milestoneToCreate.Name, milestoneToCreate.Description, milestoneToCreate.TargetDate).Scan(&milestoneId)
+	if err != nil {
+		return 0, err
+	}
+	return milestoneId, nil
+}
+
+// GetMilestonesOfProject returns milestones of the project in order of target date along with their progress, completed and closed tasks are counted as completed
+// and open tasks due after target date make the milestone at risk.
+func (p projectRepository) GetMilestonesOfProject(organizationId int64, userId int64, projectId int64) ([]response.Milestone, error) {
+	milestonesSlice := make([]response.Milestone, 0)
+	ctx := context.Background()
+	err := CheckProjectAccess(ctx, p.dbConn, organizationId, userId, projectId)
+	if err != nil {
+		return milestonesSlice, err
+	}
+
+	milestones, err := p.dbConn.Query(ctx, `SELECT m.id, m.project_id, m.name, m.description, m.target_date, m.created_at, COUNT(t.id),
+	COUNT(t.id) FILTER (WHERE t.status IN ('COMPLETED', 'CLOSED')), COUNT(t.id) FILTER (WHERE t.status IN ('TO-DO', 'IN-PROGRESS') AND t.deadline > m.target_date)
+	FROM milestones as m LEFT JOIN tasks as t ON t.milestone_id = m.id WHERE m.project_id = $1
+	GROUP BY m.id, m.project_id, m.name, m.description, m.target_date, m.created_at ORDER BY m.target_date, m.id`, projectId)
+	if err != nil {
+		return milestonesSlice, err
+	}
+	defer milestones.Close()
+
+	var milestone response.Milestone
+	for milestones.Next() {
+		if err := milestones.Scan(&milestone.ID, &milestone.ProjectID, &milestone.Name, &milestone.Description, &milestone.TargetDate, &milestone.CreatedAt,
+			&milestone.TotalTasks, &milestone.CompletedTasks, &milestone.AtRiskTasks); err != nil {
+			return milestonesSlice, err
+		}
+		milestone.AtRisk = milestone.AtRiskTasks != 0
+		milestonesSlice = append(milestonesSlice, milestone)
+	}
+	return milestonesSlice, milestones.Err()
+}
+
+func (p projectRepository) UpdateMilestone(organizationId int64, userId int64, milestoneToUpdate request.UpdateMilestone) error {
+	ctx := context.Background()
+	err := p.checkProjectOwner(ctx, organizationId, userId, milestoneToUpdate.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	a, err := p.dbConn.Exec(ctx, `UPDATE milestones SET name = COALESCE(NULLIF($1, ''), name), description = COALESCE($2, description), target_date = COALESCE($3, target_date)
+	WHERE id = $4 AND project_id = $5`, milestoneToUpdate.Name, milestoneToUpdate.Description, milestoneToUpdate.TargetDate, milestoneToUpdate.ID, milestoneToUpdate.ProjectID)
+	if err != nil {
+		return err
+	}
+	if a.RowsAffected() == 0 {
+		return errorhandling.NoMilestoneFound
+	}
+	return nil
+}
+
+// DeleteMilestone deletes milestone of the project, it's tasks stay in the project without milestone.
+func (p projectRepository) DeleteMilestone(organizationId int64, userId int64, projectId int64, milestoneId int64) error {
+	ctx := context.Background()
+	err := p.checkProjectOwner(ctx, organizationId, userId, projectId)
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	taskIds, err := queryIDs(ctx, tx, `UPDATE tasks SET milestone_id = NULL WHERE milestone_id = $1 AND project_id = $2 RETURNING id`, milestoneId, projectId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	a, err := tx.Exec(ctx, `DELETE FROM milestones WHERE id = $1 AND project_id = $2`, milestoneId, projectId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if a.RowsAffected() == 0 {
+		tx.Rollback(ctx)
+		return errorhandling.NoMilestoneFound
+	}
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return CacheTasksByID(ctx, p.dbConn, p.redisClient, taskIds)
+}
+
+// checkProjectOwner returns NotAllowed error if user is not owner of the project.
+func (p projectRepository) checkProjectOwner(ctx context.Context, organizationId int64, userId int64, projectId int64) error {
+	ownerId, _, err := getProjectAccess(ctx, p.dbConn, organizationId, userId, projectId)
+	if err != nil {
+		return err
+	}
+	if ownerId != userId {
+		return errorhandling.NotAllowed
+	}
+	return nil
+}
+
+// checkProjectAccessors makes sure that members and teams to be given access of project belong to the organization.
+func (p projectRepository) checkProjectAccessors(ctx context.Context, organizationId int64, memberIds []int64, teamIds []int64) error {
+	if len(memberIds) != 0 {
+		err := CheckOrganizationMembers(ctx, p.dbConn, organizationId, memberIds)
+		if err != nil {
+			return err
+		}
+	}
+	for _, teamId := range teamIds {
+		err := CheckTeamOfOrganization(ctx, p.dbConn, organizationId, teamId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setProjectAccessors gives access of the project to given members and teams within given transaction.
+func setProjectAccessors(ctx context.Context, tx pgx.Tx, projectId int64, memberIds []int64, teamIds []int64) error {
+	if len(memberIds) != 0 {
+		_, err := tx.Exec(ctx, `INSERT INTO project_members (project_id, member_id) SELECT $1, unnest($2::INT8[]) ON CONFLICT DO NOTHING`, projectId, memberIds)
+		if err != nil {
+			return err
+		}
+	}
+	if len(teamIds) != 0 {
+		_, err := tx.Exec(ctx, `INSERT INTO project_teams (project_id, team_id) SELECT $1, unnest($2::INT8[]) ON CONFLICT DO NOTHING`, projectId, teamIds)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanProject(row pgx.Row, project *response.Project) error {
+	return row.Scan(&project.ID, &project.OrganizationID, &project.Name, &project.Description, &project.OwnerID, &project.Status, &project.CreatedAt, &project.UpdatedAt,
+		&project.MemberIDs, &project.TeamIDs)
+}
+
+// getProjectAccess returns owner of the project and whether user can access it, project of other organization is reported as not found.
+func getProjectAccess(ctx context.Context, dbConn *pgx.Conn, organizationId int64, userId int64, projectId int64) (int64, bool, error) {
+	var ownerId int64
+	var hasAccess bool
+	err := dbConn.QueryRow(ctx, `SELECT p.owner_id, `+ProjectAccessibleBy("$1")+` FROM projects as p WHERE p.id = $2 AND p.organization_id = $3`, userId, projectId,
+		organizationId).Scan(&ownerId, &hasAccess)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return 0, false, errorhandling.NoProjectFound
+		}
+		return 0, false, err
+	}
+	return ownerId, hasAccess, nil
+}
+
+// CheckProjectAccess returns NoProjectFound error if project doesn't exist in the organization and NotAllowed error if user can't access it.
+func CheckProjectAccess(ctx context.Context, dbConn *pgx.Conn, organizationId int64, userId int64, projectId int64) error {
+	_, hasAccess, err := getProjectAccess(ctx, dbConn, organizationId, userId, projectId)
+	if err != nil {
+		return err
+	}
+	if !hasAccess {
+		return errorhandling.NotAllowed
+	}
+	return nil
+}
+
+// CheckTaskProject validates project and milestone given for the task and returns project which task belongs to. milestone decides the project
+// when project is not given, and user must be able to access the project to put tasks in it.
+func CheckTaskProject(ctx context.Context, dbConn *pgx.Conn, organizationId int64, userId int64, projectId *int64, milestoneId *int64) (*int64, error) {
+	if milestoneId != nil {
+		var milestoneProjectId int64
+		err := dbConn.QueryRow(ctx, `SELECT m.project_id FROM milestones as m JOIN projects as p ON p.id = m.project_id WHERE m.id = $1 AND p.organization_id = $2`,
+			*milestoneId, organizationId).Scan(&milestoneProjectId)
+		if err != nil {
+			if err.Error() == constant.PG_NO_ROWS {
+				return nil, errorhandling.NoMilestoneFound
+			}
+			return nil, err
+		}
+		if projectId != nil && *projectId != milestoneProjectId {
+			return nil, errorhandling.MilestoneNotInProject
+		}
+		projectId = &milestoneProjectId
+	}
+	if projectId == nil {
+		return nil, nil
+	}
+	err := CheckProjectAccess(ctx, dbConn, organizationId, userId, *projectId)
+	if err != nil {
+		return nil, err
+	}
+	return projectId, nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateProject(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		Project      request.Project
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Project Created Successfully.",
+			Project: request.Project{Name: "Q4 Launch", MemberIDs: []int64{954497896847212547}, TeamIDs: []int64{954507580144451587}, OwnerID: 954488202459119617,
+				OrganizationID: 954560000000000001},
+			Expected: nil,
+		},
+		{
+			TestCaseName: "Member of Other Organization.",
+			Project:      request.Project{Name: "Q4 Launch", MemberIDs: []int64{954497896847212548}, OwnerID: 954488202459119617, OrganizationID: 954560000000000001},
+			Expected:     errorhandling.NotMemberOfOrganization,
+		},
+		{
+			TestCaseName: "Team of Other Organization.",
+			Project:      request.Project{Name: "Q4 Launch", TeamIDs: []int64{954507580144451588}, OwnerID: 954488202459119617, OrganizationID: 954560000000000001},
+			Expected:     errorhandling.NoTeamFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			_, err := NewProjectRepo(dbConn, redisClient).CreateProject(v.Project)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestGetAllProjects(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		ProjectID    int64
+		Found        bool
+	}{
+		{
+			TestCaseName: "Owner Can See Project.",
+			UserID:       954488202459119617,
+			ProjectID:    954570000000000001,
+			Found:        true,
+		},
+		{
+			TestCaseName: "Member of Team of Project Can See Project.",
+			UserID:       954497896847212547,
+			ProjectID:    954570000000000001,
+			Found:        true,
+		},
+		{
+			TestCaseName: "User Without Access Can't See Project.",
+			UserID:       954497896847212546,
+			ProjectID:    954570000000000001,
+			Found:        false,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			projects, err := NewProjectRepo(dbConn, redisClient).GetAllProjects(954560000000000001, v.UserID, request.ProjectQueryParams{Limit: 10})
+			assert.NoError(t, err)
+			found := false
+			for _, project := range projects {
+				found = found || project.ID == v.ProjectID
+			}
+			assert.Equal(t, v.Found, found)
+		})
+	}
+}
+
+func TestCreateQueryForParamsOfGetProject(t *testing.T) {
+	query := CreateQueryForParamsOfGetProject("SELECT * FROM projects as p WHERE true", request.ProjectQueryParams{Limit: 10, Offset: 5, Search: "Launch", Status: "ACTIVE"})
+	assert.Equal(t, "SELECT * FROM projects as p WHERE true AND p.name ILIKE '%Launch%' AND p.status = 'ACTIVE' ORDER BY p.created_at DESC LIMIT 10 OFFSET 5", query)
+}
+
+func TestGetProject(t *testing.T) {
+	testCases := []struct {
+		TestCaseName   string
+		OrganizationID int64
+		UserID         int64
+		ProjectID      int64
+		Expected       interface{}
+	}{
+		{
+			TestCaseName:   "Project Fetched by Member of Project.",
+			OrganizationID: 954560000000000001,
+			UserID:         954497896847212545,
+			ProjectID:      954570000000000001,
+			Expected:       nil,
+		},
+		{
+			TestCaseName:   "User Without Access.",
+			OrganizationID: 954560000000000001,
+			UserID:         954497896847212546,
+			ProjectID:      954570000000000001,
+			Expected:       errorhandling.NotAllowed,
+		},
+		{
+			TestCaseName:   "Project of Other Organization.",
+			OrganizationID: 954560000000000001,
+			UserID:         954488202459119617,
+			ProjectID:      954570000000000002,
+			Expected:       errorhandling.NoProjectFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			project, err := NewProjectRepo(dbConn, redisClient).GetProject(v.OrganizationID, v.UserID, v.ProjectID)
+			assert.Equal(t, v.Expected, err)
+			if err == nil {
+				assert.Equal(t, []int64{954497896847212545}, project.MemberIDs)
+				assert.Equal(t, []int64{954507580144451585}, project.TeamIDs)
+			}
+		})
+	}
+}
+
+func TestUpdateProject(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		Project      request.UpdateProject
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Only Owner Can Update Project.",
+			UserID:       954497896847212545,
+			Project:      request.UpdateProject{ID: 954570000000000001, Status: "ON-HOLD"},
+			Expected:     errorhandling.NotAllowed,
+		},
+		{
+			TestCaseName: "Project Updated Successfully.",
+			UserID:       954488202459119617,
+			Project:      request.UpdateProject{ID: 954570000000000001, Status: "ACTIVE", MemberIDs: []int64{954497896847212545}},
+			Expected:     nil,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			updatedAt := time.Now().UTC()
+			v.Project.UpdatedAt = &updatedAt
+			err := NewProjectRepo(dbConn, redisClient).UpdateProject(954560000000000001, v.UserID, v.Project)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestGetTasksOfProject(t *testing.T) {
+	tasks, err := NewProjectRepo(dbConn, redisClient).GetTasksOfProject(954560000000000001, 954497896847212545, 954570000000000001, request.TaskQueryParams{Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 3)
+	for _, task := range tasks {
+		assert.Equal(t, int64(954570000000000001), *task.ProjectID)
+	}
+}
+
+func TestGetMilestonesOfProject(t *testing.T) {
+	milestones, err := NewProjectRepo(dbConn, redisClient).GetMilestonesOfProject(954560000000000001, 954497896847212547, 954570000000000001)
+	assert.NoError(t, err)
+	assert.Len(t, milestones, 2)
+
+	// milestones are ordered by target date, task of alpha release is due after it's target date.
+	assert.Equal(t, int64(954570000000000102), milestones[0].ID)
+	assert.Equal(t, 1, milestones[0].TotalTasks)
+	assert.True(t, milestones[0].AtRisk)
+	assert.Equal(t, int64(954570000000000101), milestones[1].ID)
+	assert.Equal(t, 2, milestones[1].TotalTasks)
+	assert.Equal(t, 1, milestones[1].CompletedTasks)
+	assert.False(t, milestones[1].AtRisk)
+}
+
+func TestUpdateMilestone(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		Milestone    request.UpdateMilestone
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Milestone Updated Successfully.",
+			Milestone:    request.UpdateMilestone{ID: 954570000000000101, ProjectID: 954570000000000001, Name: "Beta Release"},
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "Project Not Found.",
+			Milestone:    request.UpdateMilestone{ID: 954570000000000101, ProjectID: 954570000000000003, Name: "Beta Release"},
+			Expected:     errorhandling.NoProjectFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := NewProjectRepo(dbConn, redisClient).UpdateMilestone(954560000000000001, 954488202459119617, v.Milestone)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestDeleteProject(t *testing.T) {
+	projectRepository := NewProjectRepo(dbConn, redisClient)
+	projectId, err := projectRepository.CreateProject(request.Project{Name: "Temporary Project", OwnerID: 954488202459119617, OrganizationID: 954560000000000001})
+	assert.NoError(t, err)
+	milestoneId, err := projectRepository.CreateMilestone(954560000000000001, 954488202459119617, request.Milestone{ProjectID: projectId, Name: "Temporary Milestone",
+		TargetDate: time.Now().Add(24 * time.Hour)})
+	assert.NoError(t, err)
+
+	err = projectRepository.DeleteMilestone(954560000000000001, 954488202459119617, projectId, milestoneId)
+	assert.NoError(t, err)
+	err = projectRepository.DeleteMilestone(954560000000000001, 954488202459119617, projectId, milestoneId)
+	assert.Equal(t, errorhandling.NoMilestoneFound, err)
+
+	err = projectRepository.DeleteProject(954560000000000001, 954497896847212545, projectId)
+	assert.Equal(t, errorhandling.NotAllowed, err)
+	err = projectRepository.DeleteProject(954560000000000001, 954488202459119617, projectId)
+	assert.NoError(t, err)
+
+	var projectCount int
+	dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM projects WHERE id = $1`, projectId).Scan(&projectCount)
+	assert.Equal(t, 0, projectCount)
+}
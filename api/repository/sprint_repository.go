@@ -0,0 +1,511 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/dto"
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type SprintRepository interface {
+	CreateSprint(organizationId int64, sprintToCreate request.Sprint) (int64, error)
+	GetSprintsOfTeam(organizationId int64, userId int64, teamId int64, queryParams request.SprintQueryParams) ([]response.Sprint, error)
+	UpdateSprint(organizationId int64, userId int64, sprintToUpdate request.UpdateSprint) error
+	AddTasksToSprint(organizationId int64, userId int64, sprintTasks request.SprintTasks) error
+	RemoveTasksFromSprint(organizationId int64, userId int64, sprintTasks request.SprintTasks) error
+	GetTasksOfSprint(organizationId int64, userId int64, teamId int64, sprintId int64, queryParams request.TaskQueryParams) ([]response.Task, error)
+	CompleteSprint(organizationId int64, userId int64, sprintToComplete request.CompleteSprint) error
+	GetSprintBurndown(organizationId int64, userId int64, teamId int64, sprintId int64) (response.SprintBurndown, error)
+	GetSprintVelocity(organizationId int64, userId int64, teamId int64, queryParams request.SprintVelocityQueryParams) (response.SprintVelocity, error)
+}
+
+type sprintRepository struct {
+	dbConn *pgx.Conn
+}
+
+func NewSprintRepo(dbConn *pgx.Conn) SprintRepository {
+	return sprintRepository{
+		dbConn: dbConn,
+	}
+}
+
+// sprintColumns are columns of sprint s in the order in which scanSprint reads them, task counts of sprint which is not completed yet are counted live.
+const sprintColumns = `s.id, s.team_id, s.name, s.goal, s.start_date, s.end_date, s.status, s.created_by, s.created_at, s.completed_at,
+COALESCE(s.planned_tasks, (SELECT COUNT(*) FROM sprint_tasks WHERE sprint_id = s.id AND removed_at IS NULL)),
+COALESCE(s.completed_tasks, (SELECT COUNT(*) FROM sprint_tasks as st JOIN tasks as t ON t.id = st.task_id WHERE st.sprint_id = s.id AND st.removed_at IS NULL
+AND t.status IN ('COMPLETED', 'CLOSED')))`
+
+// CreateSprint creates sprint in PLANNED status, only owner of the team can create it's sprints.
+func (s sprintRepository) CreateSprint(organizationId int64, sprintToCreate request.Sprint) (int64, error) {
+	ctx := context.Background()
+	err := s.checkTeamOwner(ctx, organizationId, sprintToCreate.CreatedBy, sprintToCreate.TeamID)
+	if err != nil {
+		return 0, err
+	}
+	if !sprintToCreate.EndDate.After(sprintToCreate.StartDate) {
+		return 0, errorhandling.InvalidSprintDates
+	}
+
+	var sprintId int64
+	err = s.dbConn.QueryRow(ctx, `INSERT INTO sprints (team_id, name, goal, start_date, end_date, created_by) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		sprintToCreate.TeamID, sprintToCreate.Name, sprintToCreate.Goal, sprintToCreate.StartDate, sprintToCreate.EndDate, sprintToCreate.CreatedBy).Scan(&sprintId)
+	if err != nil {
+		return 0, err
+	}
+	return sprintId, nil
+}
+
+func (s sprintRepository) GetSprintsOfTeam(organizationId int64, userId int64, teamId int64, queryParams request.SprintQueryParams) ([]response.Sprint, error) {
+	sprintsSlice := make([]response.Sprint, 0)
+	ctx := context.Background()
+	err := s.checkTeamMember(ctx, organizationId, userId, teamId)
+	if err != nil {
+		return sprintsSlice, err
+	}
+
+	query := CreateQueryForParamsOfGetSprint(`SELECT `+sprintColumns+` FROM sprints as s WHERE s.team_id = $1`, queryParams)
+	sprints, err := s.dbConn.Query(ctx, query, teamId)
+	if err != nil {
+		return sprintsSlice, err
+	}
+	defer sprints.Close()
+
+	var sprint response.Sprint
+	for sprints.Next() {
+		if err := scanSprint(sprints, &sprint); err != nil {
+			return sprintsSlice, err
+		}
+		sprintsSlice = append(sprintsSlice, sprint)
+	}
+	return sprintsSlice, sprints.Err()
+}
+
+func CreateQueryForParamsOfGetSprint(query string, queryParams request.SprintQueryParams) string {
+	if queryParams.Status != constant.EMPTY_STRING {
+		query += fmt.Sprintf(" AND s.status = '%s'", queryParams.Status)
+	}
+	query += " ORDER BY s.start_date DESC, s.id DESC"
+	query += fmt.Sprintf(" LIMIT %d", queryParams.Limit)
+	query += fmt.Sprintf(" OFFSET %d", queryParams.Offset)
+	return query
+}
+
+// UpdateSprint updates fields of sprint which are sent, completed sprint can't be updated and team can have only one active sprint. only owner of the team can update it's sprints.
+func (s sprintRepository) UpdateSprint(organizationId int64, userId int64, sprintToUpdate request.UpdateSprint) error {
+	ctx := context.Background()
+	err := s.checkTeamOwner(ctx, organizationId, userId, sprintToUpdate.TeamID)
+	if err != nil {
+		return err
+	}
+	sprint, err := s.getSprint(ctx, sprintToUpdate.TeamID, sprintToUpdate.ID)
+	if err != nil {
+		return err
+	}
+	if sprint.Status == constant.SPRINT_STATUS_COMPLETED {
+		return errorhandling.SprintCompleted
+	}
+	if sprintToUpdate.StartDate != nil {
+		sprint.StartDate = *sprintToUpdate.StartDate
+	}
+	if sprintToUpdate.EndDate != nil {
+		sprint.EndDate = *sprintToUpdate.EndDate
+	}
+	if !sprint.EndDate.After(sprint.StartDate) {
+		return errorhandling.InvalidSprintDates
+	}
+
+	_, err = s.dbConn.Exec(ctx, `UPDATE sprints SET name = COALESCE(NULLIF($1, ''), name), goal = COALESCE($2, goal), start_date = $3, end_date = $4,
+	status = COALESCE(NULLIF($5, '')::sprintstatus, status) WHERE id = $6`, sprintToUpdate.Name, sprintToUpdate.Goal, sprint.StartDate, sprint.EndDate, sprintToUpdate.Status,
+		sprintToUpdate.ID)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == constant.PG_Duplicate_Error_Code {
+			return errorhandling.SprintAlreadyActive
+		}
+		return err
+	}
+	return nil
+}
+
+// AddTasksToSprint plans open tasks assigned to the team into it's sprint, tasks are taken out of other sprints of the team which are not completed yet.
+// any member of the team can plan tasks.
+func (s sprintRepository) AddTasksToSprint(organizationId int64, userId int64, sprintTasks request.SprintTasks) error {
+	ctx := context.Background()
+	err := s.checkTeamMember(ctx, organizationId, userId, sprintTasks.TeamID)
+	if err != nil {
+		return err
+	}
+	sprint, err := s.getSprint(ctx, sprintTasks.TeamID, sprintTasks.SprintID)
+	if err != nil {
+		return err
+	}
+	if sprint.Status == constant.SPRINT_STATUS_COMPLETED {
+		return errorhandling.SprintCompleted
+	}
+
+	distinctTaskIds := make(map[int64]bool)
+	for _, v := range sprintTasks.TaskIDs {
+		distinctTaskIds[v] = true
+	}
+	var taskCount int
+	err = s.dbConn.QueryRow(ctx, `SELECT COUNT(*) FROM tasks WHERE id = ANY($1) AND assignee_team = $2 AND organization_id = $3 AND status IN ('TO-DO', 'IN-PROGRESS')`,
+		sprintTasks.TaskIDs, sprintTasks.TeamID, organizationId).Scan(&taskCount)
+	if err != nil {
+		return err
+	}
+	if taskCount != len(distinctTaskIds) {
+		return errorhandling.OnlyOpenTeamTasksInSprint
+	}
+
+	tx, err := s.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	_, err = tx.Exec(ctx, `UPDATE sprint_tasks SET removed_at = $1 WHERE task_id = ANY($2) AND sprint_id <> $3 AND removed_at IS NULL
+	AND sprint_id IN (SELECT id FROM sprints WHERE status <> 'COMPLETED')`, now, sprintTasks.TaskIDs, sprintTasks.SprintID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = planTasksIntoSprint(ctx, tx, sprintTasks.SprintID, sprintTasks.TaskIDs, now)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return nil
+}
+
+// RemoveTasksFromSprint moves tasks of the sprint back to backlog of the team, any member of the team can do it.
+func (s sprintRepository) RemoveTasksFromSprint(organizationId int64, userId int64, sprintTasks request.SprintTasks) error {
+	ctx := context.Background()
+	err := s.checkTeamMember(ctx, organizationId, userId, sprintTasks.TeamID)
+	if err != nil {
+		return err
+	}
+	sprint, err := s.getSprint(ctx, sprintTasks.TeamID, sprintTasks.SprintID)
+	if err != nil {
+		return err
+	}
+	if sprint.Status == constant.SPRINT_STATUS_COMPLETED {
+		return errorhandling.SprintCompleted
+	}
+
+	a, err := s.dbConn.Exec(ctx, `UPDATE sprint_tasks SET removed_at = $1 WHERE sprint_id = $2 AND task_id = ANY($3) AND removed_at IS NULL`, time.Now().UTC(),
+		sprintTasks.SprintID, sprintTasks.TaskIDs)
+	if err != nil {
+		return err
+	}
+	if a.RowsAffected() == 0 {
+		return errorhandling.NoTaskFound
+	}
+	return nil
+}
+
+func (s sprintRepository) GetTasksOfSprint(organizationId int64, userId int64, teamId int64, sprintId int64, queryParams request.TaskQueryParams) ([]response.Task, error) {
+	tasksSlice := make([]response.Task, 0)
+	ctx := context.Background()
+	err := s.checkTeamMember(ctx, organizationId, userId, teamId)
+	if err != nil {
+		return tasksSlice, err
+	}
+	_, err = s.getSprint(ctx, teamId, sprintId)
+	if err != nil {
+		return tasksSlice, err
+	}
+
+	query := `SELECT ` + TaskColumns + ` FROM tasks WHERE id IN (SELECT task_id FROM sprint_tasks WHERE sprint_id = $1 AND removed_at IS NULL)`
+	query = CreateQueryForParamsOfGetTask(query, queryParams)
+	tasks, err := s.dbConn.Query(ctx, query, sprintId)
+	if err != nil {
+		return tasksSlice, err
+	}
+	defer tasks.Close()
+
+	var task response.Task
+	for tasks.Next() {
+		if err := ScanTask(tasks, &task); err != nil {
+			return tasksSlice, err
+		}
+		tasksSlice = append(tasksSlice, task)
+	}
+	return tasksSlice, tasks.Err()
+}
+
+// CompleteSprint completes the sprint and moves it's unfinished tasks to given next sprint of the team or to backlog when it's not given,
+// number of planned and completed tasks are saved with sprint for velocity. only owner of the team can complete it's sprints.
+func (s sprintRepository) CompleteSprint(organizationId int64, userId int64, sprintToComplete request.CompleteSprint) error {
+	ctx := context.Background()
+	err := s.checkTeamOwner(ctx, organizationId, userId, sprintToComplete.TeamID)
+	if err != nil {
+		return err
+	}
+	sprint, err := s.getSprint(ctx, sprintToComplete.TeamID, sprintToComplete.SprintID)
+	if err != nil {
+		return err
+	}
+	if sprint.Status == constant.SPRINT_STATUS_COMPLETED {
+		return errorhandling.SprintCompleted
+	}
+	if sprintToComplete.NextSprintID != nil {
+		if *sprintToComplete.NextSprintID == sprint.ID {
+			return errorhandling.NoSprintFound
+		}
+		nextSprint, err := s.getSprint(ctx, sprintToComplete.TeamID, *sprintToComplete.NextSprintID)
+		if err != nil {
+			return err
+		}
+		if nextSprint.Status == constant.SPRINT_STATUS_COMPLETED {
+			return errorhandling.SprintCompleted
+		}
+	}
+
+	tx, err := s.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	unfinishedTaskIds, err := queryIDs(ctx, tx, `UPDATE sprint_tasks SET removed_at = $1 WHERE sprint_id = $2 AND removed_at IS NULL
+	AND task_id IN (SELECT id FROM tasks WHERE status IN ('TO-DO', 'IN-PROGRESS')) RETURNING task_id`, now, sprint.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if sprintToComplete.NextSprintID != nil && len(unfinishedTaskIds) != 0 {
+		err = planTasksIntoSprint(ctx, tx, *sprintToComplete.NextSprintID, unfinishedTaskIds, now)
+		if err != nil {
+			tx.Rollback(ctx)
+			return err
+		}
+	}
+	_, err = tx.Exec(ctx, `UPDATE sprints SET status = 'COMPLETED', completed_at = $1, planned_tasks = $2, completed_tasks = $3 WHERE id = $4`, now,
+		sprint.PlannedTasks, sprint.PlannedTasks-len(unfinishedTaskIds), sprint.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return nil
+}
+
+// GetSprintBurndown returns burndown of the sprint reconstructed from status changes of it's tasks recorded in team activities.
+func (s sprintRepository) GetSprintBurndown(organizationId int64, userId int64, teamId int64, sprintId int64) (response.SprintBurndown, error) {
+	var burndown response.SprintBurndown
+	ctx := context.Background()
+	err := s.checkTeamMember(ctx, organizationId, userId, teamId)
+	if err != nil {
+		return burndown, err
+	}
+	sprint, err := s.getSprint(ctx, teamId, sprintId)
+	if err != nil {
+		return burndown, err
+	}
+
+	rows, err := s.dbConn.Query(ctx, `SELECT st.task_id, t.status, st.added_at, st.removed_at FROM sprint_tasks as st JOIN tasks as t ON t.id = st.task_id
+	WHERE st.sprint_id = $1`, sprintId)
+	if err != nil {
+		return burndown, err
+	}
+	sprintTasks := make([]dto.SprintTask, 0)
+	taskIds := make([]int64, 0)
+	for rows.Next() {
+		var sprintTask dto.SprintTask
+		if err := rows.Scan(&sprintTask.TaskID, &sprintTask.Status, &sprintTask.AddedAt, &sprintTask.RemovedAt); err != nil {
+			rows.Close()
+			return burndown, err
+		}
+		sprintTasks = append(sprintTasks, sprintTask)
+		taskIds = append(taskIds, sprintTask.TaskID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return burndown, err
+	}
+
+	rows, err = s.dbConn.Query(ctx, `SELECT task_id, details->>'from', created_at FROM team_activities WHERE activity_type = $1 AND task_id = ANY($2) AND created_at >= $3
+	ORDER BY created_at, id`, constant.TEAM_ACTIVITY_TASK_STATUS_CHANGED, taskIds, sprint.StartDate.Truncate(24*time.Hour))
+	if err != nil {
+		return burndown, err
+	}
+	defer rows.Close()
+	statusChanges := make([]dto.TaskStatusChange, 0)
+	for rows.Next() {
+		var statusChange dto.TaskStatusChange
+		if err := rows.Scan(&statusChange.TaskID, &statusChange.From, &statusChange.ChangedAt); err != nil {
+			return burndown, err
+		}
+		statusChanges = append(statusChanges, statusChange)
+	}
+	if err := rows.Err(); err != nil {
+		return burndown, err
+	}
+
+	return CreateSprintBurndown(sprint, sprintTasks, statusChanges, time.Now().UTC()), nil
+}
+
+// CreateSprintBurndown counts tasks which were in the sprint at the end of each day of the sprint up to given time. status of task at the end of the day
+// is found by undoing it's status changes made after that, starting from it's current status. ideal line burns tasks of the first day evenly till the last day.
+func CreateSprintBurndown(sprint response.Sprint, sprintTasks []dto.SprintTask, statusChanges []dto.TaskStatusChange, now time.Time) response.SprintBurndown {
+	burndown := response.SprintBurndown{SprintID: sprint.ID, StartDate: sprint.StartDate, EndDate: sprint.EndDate, Days: make([]response.SprintBurndownDay, 0)}
+
+	statusChangesOfTask := make(map[int64][]dto.TaskStatusChange)
+	for _, v := range statusChanges {
+		statusChangesOfTask[v.TaskID] = append(statusChangesOfTask[v.TaskID], v)
+	}
+
+	until := now
+	if sprint.CompletedAt != nil && sprint.CompletedAt.Before(until) {
+		until = *sprint.CompletedAt
+	}
+	firstDay := sprint.StartDate.Truncate(24 * time.Hour)
+	totalDays := int(sprint.EndDate.Sub(firstDay)/(24*time.Hour)) + 1
+
+	var firstDayTasks int
+	for i := 0; i < totalDays; i++ {
+		day := firstDay.Add(time.Duration(i) * 24 * time.Hour)
+		if day.After(until) {
+			break
+		}
+		endOfDay := day.Add(24 * time.Hour)
+		if endOfDay.After(until) {
+			endOfDay = until
+		}
+
+		burndownDay := response.SprintBurndownDay{Date: day}
+		for _, v := range sprintTasks {
+			if v.AddedAt.After(endOfDay) || (v.RemovedAt != nil && v.RemovedAt.Before(endOfDay)) {
+				continue
+			}
+			burndownDay.TotalTasks++
+			status := taskStatusAt(v.Status, statusChangesOfTask[v.TaskID], endOfDay)
+			if status == "COMPLETED" || status == "CLOSED" {
+				burndownDay.CompletedTasks++
+			} else {
+				burndownDay.RemainingTasks++
+			}
+		}
+		if i == 0 {
+			firstDayTasks = burndownDay.TotalTasks
+		}
+		if totalDays > 1 {
+			burndownDay.IdealRemainingTasks = float64(firstDayTasks) * float64(totalDays-1-i) / float64(totalDays-1)
+		}
+		burndown.Days = append(burndown.Days, burndownDay)
+	}
+	return burndown
+}
+
+// taskStatusAt undoes status changes of the task made after given time, status changes must be in order in which they were made.
+func taskStatusAt(currentStatus string, statusChanges []dto.TaskStatusChange, at time.Time) string {
+	status := currentStatus
+	for i := len(statusChanges) - 1; i >= 0 && statusChanges[i].ChangedAt.After(at); i-- {
+		status = statusChanges[i].From
+	}
+	return status
+}
+
+// GetSprintVelocity returns planned and completed tasks of last completed sprints of the team along with average number of completed tasks.
+func (s sprintRepository) GetSprintVelocity(organizationId int64, userId int64, teamId int64, queryParams request.SprintVelocityQueryParams) (response.SprintVelocity, error) {
+	velocity := response.SprintVelocity{Sprints: make([]response.Sprint, 0)}
+	ctx := context.Background()
+	err := s.checkTeamMember(ctx, organizationId, userId, teamId)
+	if err != nil {
+		return velocity, err
+	}
+
+	sprints, err := s.dbConn.Query(ctx, `SELECT `+sprintColumns+` FROM sprints as s WHERE s.team_id = $1 AND s.status = 'COMPLETED' ORDER BY s.completed_at DESC LIMIT $2`,
+		teamId, queryParams.Limit)
+	if err != nil {
+		return velocity, err
+	}
+	defer sprints.Close()
+
+	var completedTasks int
+	for sprints.Next() {
+		var sprint response.Sprint
+		if err := scanSprint(sprints, &sprint); err != nil {
+			return velocity, err
+		}
+		completedTasks += sprint.CompletedTasks
+		velocity.Sprints = append(velocity.Sprints, sprint)
+	}
+	if len(velocity.Sprints) != 0 {
+		velocity.AverageCompletedTasks = float64(completedTasks) / float64(len(velocity.Sprints))
+	}
+	return velocity, sprints.Err()
+}
+
+// getTeamOwnerAndMembership returns owner of the team and whether user is member of it, team of other organization is reported as not found.
+func (s sprintRepository) getTeamOwnerAndMembership(ctx context.Context, organizationId int64, userId int64, teamId int64) (int64, bool, error) {
+	var teamCreatedBy int64
+	var isMember bool
+	err := s.dbConn.QueryRow(ctx, `SELECT created_by, EXISTS (SELECT 1 FROM team_members WHERE team_id = $1 AND member_id = $2) FROM teams WHERE id = $1 AND organization_id = $3`,
+		teamId, userId, organizationId).Scan(&teamCreatedBy, &isMember)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return 0, false, errorhandling.NoTeamFound
+		}
+		return 0, false, err
+	}
+	return teamCreatedBy, isMember, nil
+}
+
+func (s sprintRepository) checkTeamOwner(ctx context.Context, organizationId int64, userId int64, teamId int64) error {
+	teamCreatedBy, _, err := s.getTeamOwnerAndMembership(ctx, organizationId, userId, teamId)
+	if err != nil {
+		return err
+	}
+	if teamCreatedBy != userId {
+		return errorhandling.NotAllowed
+	}
+	return nil
+}
+
+func (s sprintRepository) checkTeamMember(ctx context.Context, organizationId int64, userId int64, teamId int64) error {
+	teamCreatedBy, isMember, err := s.getTeamOwnerAndMembership(ctx, organizationId, userId, teamId)
+	if err != nil {
+		return err
+	}
+	if !isMember && teamCreatedBy != userId {
+		return errorhandling.NotAllowed
+	}
+	return nil
+}
+
+func (s sprintRepository) getSprint(ctx context.Context, teamId int64, sprintId int64) (response.Sprint, error) {
+	var sprint response.Sprint
+	err := scanSprint(s.dbConn.QueryRow(ctx, `SELECT `+sprintColumns+` FROM sprints as s WHERE s.id = $1 AND s.team_id = $2`, sprintId, teamId), &sprint)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return sprint, errorhandling.NoSprintFound
+		}
+		return sprint, err
+	}
+	return sprint, nil
+}
+
+func scanSprint(row pgx.Row, sprint *response.Sprint) error {
+	return row.Scan(&sprint.ID, &sprint.TeamID, &sprint.Name, &sprint.Goal, &sprint.StartDate, &sprint.EndDate, &sprint.Status, &sprint.CreatedBy, &sprint.CreatedAt,
+		&sprint.CompletedAt, &sprint.PlannedTasks, &sprint.CompletedTasks)
+}
+
+// planTasksIntoSprint adds tasks to the sprint within given transaction, task which was removed from the sprint earlier is added again.
+func planTasksIntoSprint(ctx context.Context, tx pgx.Tx, sprintId int64, taskIds []int64, addedAt time.Time) error {
+	_, err := tx.Exec(ctx, `INSERT INTO sprint_tasks (sprint_id, task_id, added_at) SELECT $1, unnest($2::INT8[]), $3 ON CONFLICT (sprint_id, task_id)
+	DO UPDATE SET added_at = excluded.added_at, removed_at = NULL WHERE sprint_tasks.removed_at IS NOT NULL`, sprintId, taskIds, addedAt)
+	return err
+}
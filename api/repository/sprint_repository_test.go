@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/dto"
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSprint(t *testing.T) {
+	startDate := time.Now().UTC().Add(30 * 24 * time.Hour)
+	testCases := []struct {
+		TestCaseName string
+		Sprint       request.Sprint
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Sprint Created Successfully.",
+			Sprint: request.Sprint{TeamID: 954507580144451585, Name: "Sprint 4", StartDate: startDate, EndDate: startDate.Add(14 * 24 * time.Hour),
+				CreatedBy: 954488202459119617},
+			Expected: nil,
+		},
+		{
+			TestCaseName: "Only Owner of Team Can Create Sprint.",
+			Sprint: request.Sprint{TeamID: 954507580144451585, Name: "Sprint 4", StartDate: startDate, EndDate: startDate.Add(14 * 24 * time.Hour),
+				CreatedBy: 954497896847212547},
+			Expected: errorhandling.NotAllowed,
+		},
+		{
+			TestCaseName: "End Date Before Start Date.",
+			Sprint: request.Sprint{TeamID: 954507580144451585, Name: "Sprint 4", StartDate: startDate, EndDate: startDate.Add(-24 * time.Hour),
+				CreatedBy: 954488202459119617},
+			Expected: errorhandling.InvalidSprintDates,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			_, err := NewSprintRepo(dbConn).CreateSprint(954560000000000001, v.Sprint)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestGetSprintsOfTeam(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Sprints Fetched by Member of Team.",
+			UserID:       954497896847212547,
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "User is Not Member of Team.",
+			UserID:       954497896847212545,
+			Expected:     errorhandling.NotAllowed,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			_, err := NewSprintRepo(dbConn).GetSprintsOfTeam(954560000000000001, v.UserID, 954507580144451585, request.SprintQueryParams{Limit: 10})
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestCreateQueryForParamsOfGetSprint(t *testing.T) {
+	query := CreateQueryForParamsOfGetSprint("SELECT * FROM sprints as s WHERE true", request.SprintQueryParams{Limit: 10, Offset: 5, Status: "ACTIVE"})
+	assert.Equal(t, "SELECT * FROM sprints as s WHERE true AND s.status = 'ACTIVE' ORDER BY s.start_date DESC, s.id DESC LIMIT 10 OFFSET 5", query)
+}
+
+func TestUpdateSprint(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		Sprint       request.UpdateSprint
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Team Already Has Active Sprint.",
+			Sprint:       request.UpdateSprint{ID: 954580000000000003, TeamID: 954507580144451585, Status: "ACTIVE"},
+			Expected:     errorhandling.SprintAlreadyActive,
+		},
+		{
+			TestCaseName: "Completed Sprint Can't be Updated.",
+			Sprint:       request.UpdateSprint{ID: 954580000000000001, TeamID: 954507580144451585, Status: "ACTIVE"},
+			Expected:     errorhandling.SprintCompleted,
+		},
+		{
+			TestCaseName: "Sprint of Other Team.",
+			Sprint:       request.UpdateSprint{ID: 954580000000000003, TeamID: 954507580144451586, Status: "ACTIVE"},
+			Expected:     errorhandling.NoSprintFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := NewSprintRepo(dbConn).UpdateSprint(954560000000000001, 954488202459119617, v.Sprint)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestAddTasksToSprint(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		SprintTasks  request.SprintTasks
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Task is Not Assigned to Team.",
+			SprintTasks:  request.SprintTasks{TeamID: 954507580144451585, SprintID: 954580000000000003, TaskIDs: []int64{954511608047501315}},
+			Expected:     errorhandling.OnlyOpenTeamTasksInSprint,
+		},
+		{
+			TestCaseName: "Task is Closed.",
+			SprintTasks:  request.SprintTasks{TeamID: 954507580144451585, SprintID: 954580000000000003, TaskIDs: []int64{954511608047501314}},
+			Expected:     errorhandling.OnlyOpenTeamTasksInSprint,
+		},
+		{
+			TestCaseName: "Sprint is Completed.",
+			SprintTasks:  request.SprintTasks{TeamID: 954507580144451585, SprintID: 954580000000000001, TaskIDs: []int64{954511608047501313}},
+			Expected:     errorhandling.SprintCompleted,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := NewSprintRepo(dbConn).AddTasksToSprint(954560000000000001, 954497896847212547, v.SprintTasks)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestGetSprintVelocity(t *testing.T) {
+	velocity, err := NewSprintRepo(dbConn).GetSprintVelocity(954560000000000001, 954497896847212547, 954507580144451585, request.SprintVelocityQueryParams{Limit: 5})
+	assert.NoError(t, err)
+	assert.Len(t, velocity.Sprints, 1)
+	assert.Equal(t, 3, velocity.Sprints[0].CompletedTasks)
+	assert.Equal(t, float64(3), velocity.AverageCompletedTasks)
+}
+
+func TestCreateSprintBurndown(t *testing.T) {
+	startDate := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	sprint := response.Sprint{ID: 1, StartDate: startDate, EndDate: time.Date(2024, 3, 8, 18, 0, 0, 0, time.UTC)}
+	removedAt := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	sprintTasks := []dto.SprintTask{
+		{TaskID: 1, Status: "COMPLETED", AddedAt: startDate},
+		{TaskID: 2, Status: "TO-DO", AddedAt: startDate},
+		{TaskID: 3, Status: "IN-PROGRESS", AddedAt: startDate, RemovedAt: &removedAt},
+		{TaskID: 4, Status: "TO-DO", AddedAt: time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)},
+	}
+	statusChanges := []dto.TaskStatusChange{
+		{TaskID: 1, From: "TO-DO", ChangedAt: time.Date(2024, 3, 5, 15, 0, 0, 0, time.UTC)},
+		{TaskID: 1, From: "IN-PROGRESS", ChangedAt: time.Date(2024, 3, 6, 15, 0, 0, 0, time.UTC)},
+	}
+
+	burndown := CreateSprintBurndown(sprint, sprintTasks, statusChanges, time.Date(2024, 3, 7, 8, 0, 0, 0, time.UTC))
+	assert.Len(t, burndown.Days, 4)
+
+	expected := []response.SprintBurndownDay{
+		{Date: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), TotalTasks: 3, RemainingTasks: 3, CompletedTasks: 0, IdealRemainingTasks: 3},
+		{Date: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), TotalTasks: 4, RemainingTasks: 4, CompletedTasks: 0, IdealRemainingTasks: 2.25},
+		{Date: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), TotalTasks: 3, RemainingTasks: 2, CompletedTasks: 1, IdealRemainingTasks: 1.5},
+		{Date: time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC), TotalTasks: 3, RemainingTasks: 2, CompletedTasks: 1, IdealRemainingTasks: 0.75},
+	}
+	assert.Equal(t, expected, burndown.Days)
+}
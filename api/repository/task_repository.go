@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/chirag1807/task-management-system/api/model/dto"
 	"github.com/chirag1807/task-management-system/api/model/request"
 	"github.com/chirag1807/task-management-system/api/model/response"
 	"github.com/chirag1807/task-management-system/constant"
@@ -14,48 +15,54 @@ import (
 	"github.com/go-redis/redis/v8"
 	socketio "github.com/googollee/go-socket.io"
 	"github.com/jackc/pgx/v5"
+	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// TaskColumns are columns of tasks table in the order in which ScanTask reads them.
+const TaskColumns = `id, title, description, deadline, assignee_individual, assignee_team, status, priority, created_by, created_at, updated_by, updated_at,
+owner_individual, organization_id, project_id, milestone_id`
+
 type TaskRepository interface {
 	CreateTask(taskToCreate request.Task) (int64, error)
-	GetAllTasks(userId int64, queryParams request.TaskQueryParams) ([]response.Task, error)
+	GetAllTasks(organizationId int64, userId int64, queryParams request.TaskQueryParams) ([]response.Task, error)
 	//flag is used for get my created tasks and get tasks assigned to me.
-	GetTasksofTeam(teamId int64, queryParams request.TaskQueryParams) ([]response.Task, error)
-	UpdateTask(taskToUpdate request.UpdateTask) error
+	GetTasksofTeam(organizationId int64, teamId int64, queryParams request.TaskQueryParams) ([]response.Task, error)
+	UpdateTask(organizationId int64, taskToUpdate request.UpdateTask) error
+	AddCommentToTask(organizationId int64, commentToAdd request.TaskComment) (int64, error)
 }
 
 type taskRepository struct {
 	dbConn       *pgx.Conn
 	redisClient  *redis.Client
+	rabbitmqConn *amqp.Connection
 	socketServer *socketio.Server
 }
 
-func NewTaskRepo(dbConn *pgx.Conn, redisClient *redis.Client, socketServer *socketio.Server) TaskRepository {
+func NewTaskRepo(dbConn *pgx.Conn, redisClient *redis.Client, rabbitmqConn *amqp.Connection, socketServer *socketio.Server) TaskRepository {
 	return taskRepository{
 		dbConn:       dbConn,
 		redisClient:  redisClient,
+		rabbitmqConn: rabbitmqConn,
 		socketServer: socketServer,
 	}
 }
 
 func (t taskRepository) CreateTask(taskToCreate request.Task) (int64, error) {
-	var dbUserPrivacy string
 	var dbTeamPrivacy string
 	
 	fmt.Println(taskToCreate.AssigneeTeam)
 	if taskToCreate.AssigneeIndividual != nil {
-		rows := t.dbConn.QueryRow(context.Background(), `SELECT privacy FROM users WHERE id = $1`, *taskToCreate.AssigneeIndividual)
-		err := rows.Scan(&dbUserPrivacy)
+		err := CheckTaskAssignee(context.Background(), t.dbConn, taskToCreate.OrganizationID, taskToCreate.CreatedBy, *taskToCreate.AssigneeIndividual)
 		if err != nil {
 			return 0, err
 		}
-		if dbUserPrivacy != "PUBLIC" {
-			return 0, errorhandling.OnlyPublicUserAssignne
-		}
 	} else if taskToCreate.AssigneeTeam != nil {
-		rows := t.dbConn.QueryRow(context.Background(), `SELECT team_privacy FROM teams WHERE id = $1`, taskToCreate.AssigneeTeam)
+		rows := t.dbConn.QueryRow(context.Background(), `SELECT team_privacy FROM teams WHERE id = $1 AND organization_id = $2`, taskToCreate.AssigneeTeam, taskToCreate.OrganizationID)
 		err := rows.Scan(&dbTeamPrivacy)
 		if err != nil {
+			if err.Error() == constant.PG_NO_ROWS {
+				return 0, errorhandling.NoTeamFound
+			}
 			return 0, err
 		}
 		if dbTeamPrivacy != "PUBLIC" {
@@ -63,13 +70,55 @@ func (t taskRepository) CreateTask(taskToCreate request.Task) (int64, error) {
 		}
 	}
 
+	ctx := context.Background()
+	if taskToCreate.ProjectID != nil || taskToCreate.MilestoneID != nil {
+		var err error
+		taskToCreate.ProjectID, err = CheckTaskProject(ctx, t.dbConn, taskToCreate.OrganizationID, taskToCreate.CreatedBy, taskToCreate.ProjectID, taskToCreate.MilestoneID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	tx, err := t.dbConn.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	taskToCreate.OwnerIndividual = nil
+	if taskToCreate.AssigneeTeam != nil {
+		taskToCreate.OwnerIndividual, err = PickTeamMemberForTask(ctx, tx, *taskToCreate.AssigneeTeam, taskToCreate.CreatedBy, taskToCreate.CreatedAt)
+		if err != nil {
+			tx.Rollback(ctx)
+			return 0, err
+		}
+	}
 
 	var taskId int64
-	err := t.dbConn.QueryRow(context.Background(), `INSERT INTO tasks (title, description, deadline, assignee_individual, assignee_team, status, priority,
-			created_by, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`, taskToCreate.Title, taskToCreate.Description, taskToCreate.Deadline,
-		taskToCreate.AssigneeIndividual, taskToCreate.AssigneeTeam, taskToCreate.Status, taskToCreate.Priority, taskToCreate.CreatedBy, taskToCreate.CreatedAt).
-		Scan(&taskId)
+	err = tx.QueryRow(ctx, `INSERT INTO tasks (title, description, deadline, assignee_individual, assignee_team, status, priority,
+			created_by, created_at, owner_individual, organization_id, project_id, milestone_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`,
+		taskToCreate.Title, taskToCreate.Description, taskToCreate.Deadline, taskToCreate.AssigneeIndividual, taskToCreate.AssigneeTeam, taskToCreate.Status, taskToCreate.Priority,
+		taskToCreate.CreatedBy, taskToCreate.CreatedAt, taskToCreate.OwnerIndividual, taskToCreate.OrganizationID, taskToCreate.ProjectID, taskToCreate.MilestoneID).Scan(&taskId)
 	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+
+	var activities []response.TeamActivity
+	if taskToCreate.AssigneeTeam != nil {
+		details := map[string]interface{}{}
+		if taskToCreate.OwnerIndividual != nil {
+			details["ownerIndividual"] = strconv.FormatInt(*taskToCreate.OwnerIndividual, 10)
+		}
+		activities, err = InsertTeamActivities(ctx, tx, []request.TeamActivity{{TeamID: *taskToCreate.AssigneeTeam, ActorID: taskToCreate.CreatedBy,
+			Type: constant.TEAM_ACTIVITY_TASK_CREATED, TaskID: &taskId, Details: details}})
+		if err != nil {
+			tx.Rollback(ctx)
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
 		return 0, err
 	}
 
@@ -98,17 +147,24 @@ func (t taskRepository) CreateTask(taskToCreate request.Task) (int64, error) {
 		return 0, err
 	}
 
+	taskNotification := dto.TaskNotification{TaskID: taskId, Title: taskToCreate.Title, Deadline: taskToCreate.Deadline}
 	if taskToCreate.AssigneeIndividual != nil {
-		socket.EmitCreateAndUpdateTaskEvents(t.socketServer, strconv.FormatInt(*taskToCreate.AssigneeIndividual, 10), constant.EMPTY_STRING, taskToCreate, 0)
+		t.notifyUserOfTask(*taskToCreate.AssigneeIndividual, constant.NOTIFY_TASK_ASSIGNED, taskNotification, taskToCreate)
 	}
 	if taskToCreate.AssigneeTeam != nil {
 		socket.EmitCreateAndUpdateTaskEvents(t.socketServer, "task-created", strconv.FormatInt(*taskToCreate.AssigneeTeam, 10), taskToCreate, 1)
 	}
+	if taskToCreate.OwnerIndividual != nil {
+		t.notifyUserOfTask(*taskToCreate.OwnerIndividual, constant.NOTIFY_TASK_ASSIGNED, taskNotification, taskToCreate)
+	}
+	EmitTeamActivities(t.socketServer, activities)
 
 	return taskId, nil
 }
 
-func (t taskRepository) GetAllTasks(userId int64, queryParams request.TaskQueryParams) ([]response.Task, error) {
+// GetAllTasks returns tasks of the organization which are created by user or assigned to user or their teams,
+// cached tasks of other organizations are left out and tasks are read from database if none of them remains.
+func (t taskRepository) GetAllTasks(organizationId int64, userId int64, queryParams request.TaskQueryParams) ([]response.Task, error) {
 	var tasks pgx.Rows
 	var err error
 	tasksSlice := make([]response.Task, 0)
@@ -117,12 +173,13 @@ func (t taskRepository) GetAllTasks(userId int64, queryParams request.TaskQueryP
 	if !queryParams.CreatedByMe {
 		taskIds, _ := t.redisClient.SMembers(context.Background(), "tasks:created_by:"+strconv.FormatInt(userId, 10)).Result()
 		tasksSlice, _ = GetTasksFromRedisByIDList(t.redisClient, taskIds)
+		tasksSlice = TasksOfOrganization(tasksSlice, organizationId)
 		if len(tasksSlice) != 0 {
 			return tasksSlice, nil
 		} else {
-			query = `SELECT * FROM tasks WHERE created_by = $1 AND true`
+			query = `SELECT ` + TaskColumns + ` FROM tasks WHERE created_by = $1 AND organization_id = $2`
 			query = CreateQueryForParamsOfGetTask(query, queryParams)
-			tasks, err = t.dbConn.Query(context.Background(), query, userId)
+			tasks, err = t.dbConn.Query(context.Background(), query, userId, organizationId)
 			if err != nil {
 				return tasksSlice, err
 			}
@@ -139,12 +196,13 @@ func (t taskRepository) GetAllTasks(userId int64, queryParams request.TaskQueryP
 		if err != nil {
 			return nil, err
 		}
+		tasksSlice = TasksOfOrganization(tasksSlice, organizationId)
 		if len(tasksSlice) != 0 {
 			return tasksSlice, nil
 		} else {
-			query = `SELECT * FROM tasks WHERE (assignee_individual = $1 OR assignee_team IN (SELECT team_id from team_members where member_id = $2))`
+			query = `SELECT ` + TaskColumns + ` FROM tasks WHERE (assignee_individual = $1 OR assignee_team IN (SELECT team_id from team_members where member_id = $2)) AND organization_id = $3`
 			query = CreateQueryForParamsOfGetTask(query, queryParams)
-			tasks, err = t.dbConn.Query(context.Background(), query, userId, userId)
+			tasks, err = t.dbConn.Query(context.Background(), query, userId, userId, organizationId)
 			if err != nil {
 				return tasksSlice, err
 			}
@@ -155,8 +213,7 @@ func (t taskRepository) GetAllTasks(userId int64, queryParams request.TaskQueryP
 
 	var task response.Task
 	for tasks.Next() {
-		if err := tasks.Scan(&task.ID, &task.Title, &task.Description, &task.Deadline, &task.AssigneeIndividual, &task.AssigneeTeam, &task.Status,
-			&task.Priority, &task.CreatedBy, &task.CreatedAt, &task.UpdatedBy, &task.UpdatedAt); err != nil {
+		if err := ScanTask(tasks, &task); err != nil {
 			return tasksSlice, err
 		}
 		tasksSlice = append(tasksSlice, task)
@@ -165,16 +222,22 @@ func (t taskRepository) GetAllTasks(userId int64, queryParams request.TaskQueryP
 	return tasksSlice, nil
 }
 
-func (t taskRepository) GetTasksofTeam(teamId int64, queryParams request.TaskQueryParams) ([]response.Task, error) {
+func (t taskRepository) GetTasksofTeam(organizationId int64, teamId int64, queryParams request.TaskQueryParams) ([]response.Task, error) {
+	err := CheckTeamOfOrganization(context.Background(), t.dbConn, organizationId, teamId)
+	if err != nil {
+		return make([]response.Task, 0), err
+	}
+
 	teamTaskIDs, _ := t.redisClient.SMembers(context.Background(), "tasks:assigned_to_team:"+strconv.FormatInt(teamId, 10)).Result()
 	tasksSlice, _ := GetTasksFromRedisByIDList(t.redisClient, teamTaskIDs)
+	tasksSlice = TasksOfOrganization(tasksSlice, organizationId)
 	if len(tasksSlice) != 0 {
 		return tasksSlice, nil
 	}
 
-	query := `SELECT * FROM tasks WHERE assignee_team = $1 AND true`
+	query := `SELECT ` + TaskColumns + ` FROM tasks WHERE assignee_team = $1 AND organization_id = $2`
 	query = CreateQueryForParamsOfGetTask(query, queryParams)
-	tasks, err := t.dbConn.Query(context.Background(), query, teamId)
+	tasks, err := t.dbConn.Query(context.Background(), query, teamId, organizationId)
 	if err != nil {
 		return tasksSlice, err
 	}
@@ -182,8 +245,7 @@ func (t taskRepository) GetTasksofTeam(teamId int64, queryParams request.TaskQue
 
 	var task response.Task
 	for tasks.Next() {
-		if err := tasks.Scan(&task.ID, &task.Title, &task.Description, &task.Deadline, &task.AssigneeIndividual, &task.AssigneeTeam, &task.Status,
-			&task.Priority, &task.CreatedBy, &task.CreatedAt, &task.UpdatedBy, &task.UpdatedAt); err != nil {
+		if err := ScanTask(tasks, &task); err != nil {
 			return tasksSlice, err
 		}
 		tasksSlice = append(tasksSlice, task)
@@ -208,12 +270,11 @@ func CreateQueryForParamsOfGetTask(query string, queryParams request.TaskQueryPa
 	return query
 }
 
-func (t taskRepository) UpdateTask(taskToUpdate request.UpdateTask) error {
+func (t taskRepository) UpdateTask(organizationId int64, taskToUpdate request.UpdateTask) error {
 	var dbTask response.Task
-	rows := t.dbConn.QueryRow(context.Background(), `SELECT * FROM tasks WHERE id = $1`, taskToUpdate.ID)
+	rows := t.dbConn.QueryRow(context.Background(), `SELECT `+TaskColumns+` FROM tasks WHERE id = $1 AND organization_id = $2`, taskToUpdate.ID, organizationId)
 
-	err := rows.Scan(&dbTask.ID, &dbTask.Title, &dbTask.Description, &dbTask.Deadline, &dbTask.AssigneeIndividual, &dbTask.AssigneeTeam, &dbTask.Status,
-		&dbTask.Priority, &dbTask.CreatedBy, &dbTask.CreatedAt, &dbTask.UpdatedBy, &dbTask.UpdatedAt)
+	err := ScanTask(rows, &dbTask)
 
 	if err != nil {
 		if err.Error() == constant.PG_NO_ROWS {
@@ -243,20 +304,88 @@ func (t taskRepository) UpdateTask(taskToUpdate request.UpdateTask) error {
 	}
 
 	if dbTask.CreatedBy != *taskToUpdate.UpdatedBy && (taskToUpdate.Priority != constant.EMPTY_STRING || taskToUpdate.Title != constant.EMPTY_STRING || taskToUpdate.Description != constant.EMPTY_STRING ||
-		taskToUpdate.AssigneeIndividual != nil || taskToUpdate.AssigneeTeam != nil) {
+		taskToUpdate.AssigneeIndividual != nil || taskToUpdate.AssigneeTeam != nil || taskToUpdate.ProjectID != nil || taskToUpdate.MilestoneID != nil) {
 		return errorhandling.NotAllowed
 	}
 
+	if taskToUpdate.AssigneeIndividual != nil && (dbTask.AssigneeIndividual == nil || *dbTask.AssigneeIndividual != *taskToUpdate.AssigneeIndividual) {
+		err := CheckTaskAssignee(context.Background(), t.dbConn, organizationId, *taskToUpdate.UpdatedBy, *taskToUpdate.AssigneeIndividual)
+		if err != nil {
+			return err
+		}
+	}
+	if taskToUpdate.AssigneeTeam != nil && (dbTask.AssigneeTeam == nil || *dbTask.AssigneeTeam != *taskToUpdate.AssigneeTeam) {
+		err := CheckTeamOfOrganization(context.Background(), t.dbConn, organizationId, *taskToUpdate.AssigneeTeam)
+		if err != nil {
+			return err
+		}
+	}
+
+	// milestone of previous project doesn't apply to task which is moved to other project without milestone.
+	clearMilestone := false
+	if taskToUpdate.ProjectID != nil || taskToUpdate.MilestoneID != nil {
+		taskToUpdate.ProjectID, err = CheckTaskProject(context.Background(), t.dbConn, organizationId, *taskToUpdate.UpdatedBy, taskToUpdate.ProjectID, taskToUpdate.MilestoneID)
+		if err != nil {
+			return err
+		}
+		clearMilestone = taskToUpdate.MilestoneID == nil && dbTask.MilestoneID != nil && (dbTask.ProjectID == nil || *dbTask.ProjectID != *taskToUpdate.ProjectID)
+	}
+
 	query, args, err := UpdateQuery("tasks", taskToUpdate, taskToUpdate.ID, 1)
 	if err != nil {
 		return err
 	}
-	_, err = t.dbConn.Exec(context.Background(), query, args...)
+
+	ctx := context.Background()
+	tx, err := t.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if clearMilestone {
+		_, err = tx.Exec(ctx, `UPDATE tasks SET milestone_id = NULL WHERE id = $1`, taskToUpdate.ID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return err
+		}
+	}
+	reassigned := IsTaskReassigned(dbTask, taskToUpdate)
+	var ownerIndividual *int64
+	if reassigned {
+		if taskToUpdate.AssigneeTeam != nil {
+			ownerIndividual, err = PickTeamMemberForTask(ctx, tx, *taskToUpdate.AssigneeTeam, *taskToUpdate.UpdatedBy, *taskToUpdate.UpdatedAt)
+			if err != nil {
+				tx.Rollback(ctx)
+				return err
+			}
+		}
+		_, err = tx.Exec(ctx, `UPDATE tasks SET owner_individual = $1 WHERE id = $2`, ownerIndividual, taskToUpdate.ID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return err
+		}
+	}
+	activities, err := InsertTeamActivities(ctx, tx, CreateTeamActivitiesOfTaskUpdate(dbTask, taskToUpdate))
 	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
 		return err
 	}
 
 	taskToUpdateinRedis := UpdateTaskFields(dbTask, taskToUpdate)
+	if reassigned {
+		taskToUpdateinRedis.OwnerIndividual = ownerIndividual
+	}
+	if clearMilestone {
+		taskToUpdateinRedis.MilestoneID = nil
+	}
 	taskJSON, err := json.Marshal(taskToUpdateinRedis)
 	if err != nil {
 		return err
@@ -284,28 +413,149 @@ func (t taskRepository) UpdateTask(taskToUpdate request.UpdateTask) error {
 		}
 	}
 
+	taskNotification := dto.TaskNotification{TaskID: taskToUpdateinRedis.ID, Title: taskToUpdateinRedis.Title, Deadline: taskToUpdateinRedis.Deadline}
+	individualEvent := constant.NOTIFY_TASK_UPDATED
+	if reassigned {
+		individualEvent = constant.NOTIFY_TASK_ASSIGNED
+	}
 	if dbTask.AssigneeIndividual != nil {
 		if taskToUpdate.AssigneeIndividual != nil {
-			socket.EmitCreateAndUpdateTaskEvents(t.socketServer, strconv.FormatInt(*taskToUpdate.AssigneeIndividual, 10), constant.EMPTY_STRING, taskToUpdateinRedis, 0)
+			t.notifyUserOfTask(*taskToUpdate.AssigneeIndividual, individualEvent, taskNotification, taskToUpdateinRedis)
 		} else if taskToUpdate.AssigneeTeam != nil {
 			socket.EmitCreateAndUpdateTaskEvents(t.socketServer, "task-updated", strconv.FormatInt(*taskToUpdate.AssigneeTeam, 10), taskToUpdateinRedis, 1)
 		} else {
-			socket.EmitCreateAndUpdateTaskEvents(t.socketServer, strconv.FormatInt(*dbTask.AssigneeIndividual, 10), constant.EMPTY_STRING, taskToUpdateinRedis, 0)
+			t.notifyUserOfTask(*dbTask.AssigneeIndividual, constant.NOTIFY_TASK_UPDATED, taskNotification, taskToUpdateinRedis)
 		}
 	}
 	if dbTask.AssigneeTeam != nil {
 		if taskToUpdate.AssigneeIndividual != nil {
-			socket.EmitCreateAndUpdateTaskEvents(t.socketServer, strconv.FormatInt(*taskToUpdate.AssigneeIndividual, 10), constant.EMPTY_STRING, taskToUpdateinRedis, 0)
+			t.notifyUserOfTask(*taskToUpdate.AssigneeIndividual, constant.NOTIFY_TASK_ASSIGNED, taskNotification, taskToUpdateinRedis)
 		} else if taskToUpdate.AssigneeTeam != nil {
 			socket.EmitCreateAndUpdateTaskEvents(t.socketServer, "task-updated", strconv.FormatInt(*taskToUpdate.AssigneeTeam, 10), taskToUpdateinRedis, 1)
 		} else {
 			socket.EmitCreateAndUpdateTaskEvents(t.socketServer, "task-updated", strconv.FormatInt(*dbTask.AssigneeTeam, 10), taskToUpdateinRedis, 1)
 		}
 	}
+	EmitTeamActivities(t.socketServer, activities)
 
 	return nil
 }
 
+// notifyUserOfTask notifies given user about the task over channel chosen by user in preferences for given event.
+func (t taskRepository) notifyUserOfTask(userId int64, event string, taskNotification dto.TaskNotification, payload interface{}) {
+	NotifyUserOfTask(context.Background(), t.dbConn, t.redisClient, t.socketServer, t.rabbitmqConn, userId, event, taskNotification, payload)
+}
+
+// IsTaskReassigned reports whether requested update moves the task to an assignee other than the one stored in database.
+func IsTaskReassigned(dbTask response.Task, taskToUpdate request.UpdateTask) bool {
+	return (taskToUpdate.AssigneeIndividual != nil && (dbTask.AssigneeIndividual == nil || *dbTask.AssigneeIndividual != *taskToUpdate.AssigneeIndividual)) ||
+		(taskToUpdate.AssigneeTeam != nil && (dbTask.AssigneeTeam == nil || *dbTask.AssigneeTeam != *taskToUpdate.AssigneeTeam))
+}
+
+// CreateTeamActivitiesOfTaskUpdate compares task stored in database with requested update and returns reassignment and status change activities
+// for the team which held the task before update and the team which holds it after update.
+// ids are kept as strings in details because json numbers can not hold them without losing precision.
+func CreateTeamActivitiesOfTaskUpdate(dbTask response.Task, taskToUpdate request.UpdateTask) []request.TeamActivity {
+	var activities []request.TeamActivity
+	var teamIds []int64
+	if dbTask.AssigneeTeam != nil {
+		teamIds = append(teamIds, *dbTask.AssigneeTeam)
+	}
+	if taskToUpdate.AssigneeTeam != nil && (dbTask.AssigneeTeam == nil || *dbTask.AssigneeTeam != *taskToUpdate.AssigneeTeam) {
+		teamIds = append(teamIds, *taskToUpdate.AssigneeTeam)
+	}
+
+	reassigned := IsTaskReassigned(dbTask, taskToUpdate)
+	statusChanged := taskToUpdate.Status != constant.EMPTY_STRING && taskToUpdate.Status != dbTask.Status
+
+	for _, teamId := range teamIds {
+		if reassigned {
+			details := map[string]interface{}{}
+			if dbTask.AssigneeIndividual != nil {
+				details["fromAssigneeIndividual"] = strconv.FormatInt(*dbTask.AssigneeIndividual, 10)
+			}
+			if dbTask.AssigneeTeam != nil {
+				details["fromAssigneeTeam"] = strconv.FormatInt(*dbTask.AssigneeTeam, 10)
+			}
+			if taskToUpdate.AssigneeIndividual != nil {
+				details["toAssigneeIndividual"] = strconv.FormatInt(*taskToUpdate.AssigneeIndividual, 10)
+			}
+			if taskToUpdate.AssigneeTeam != nil {
+				details["toAssigneeTeam"] = strconv.FormatInt(*taskToUpdate.AssigneeTeam, 10)
+			}
+			activities = append(activities, request.TeamActivity{TeamID: teamId, ActorID: *taskToUpdate.UpdatedBy, Type: constant.TEAM_ACTIVITY_TASK_REASSIGNED,
+				TaskID: &dbTask.ID, Details: details})
+		}
+		if statusChanged {
+			activities = append(activities, request.TeamActivity{TeamID: teamId, ActorID: *taskToUpdate.UpdatedBy, Type: constant.TEAM_ACTIVITY_TASK_STATUS_CHANGED,
+				TaskID: &dbTask.ID, Details: map[string]interface{}{"from": dbTask.Status, "to": taskToUpdate.Status}})
+		}
+	}
+	return activities
+}
+
+func (t taskRepository) AddCommentToTask(organizationId int64, commentToAdd request.TaskComment) (int64, error) {
+	var assigneeIndividual, assigneeTeam *int64
+	var createdBy int64
+	err := t.dbConn.QueryRow(context.Background(), `SELECT assignee_individual, assignee_team, created_by FROM tasks WHERE id = $1 AND organization_id = $2`, commentToAdd.TaskID, organizationId).
+		Scan(&assigneeIndividual, &assigneeTeam, &createdBy)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return 0, errorhandling.NoTaskFound
+		}
+		return 0, err
+	}
+
+	if createdBy != commentToAdd.CreatedBy {
+		if assigneeIndividual != nil && *assigneeIndividual != commentToAdd.CreatedBy {
+			return 0, errorhandling.NotAllowed
+		}
+		if assigneeTeam != nil {
+			var userCount int
+			err := t.dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM team_members WHERE team_id = $1 AND member_id = $2`, *assigneeTeam, commentToAdd.CreatedBy).
+				Scan(&userCount)
+			if err != nil {
+				return 0, err
+			}
+			if userCount == 0 {
+				return 0, errorhandling.NotAllowed
+			}
+		}
+	}
+
+	ctx := context.Background()
+	tx, err := t.dbConn.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var commentId int64
+	err = tx.QueryRow(ctx, `INSERT INTO task_comments (task_id, comment, created_by, created_at) VALUES ($1, $2, $3, $4) RETURNING id`, commentToAdd.TaskID,
+		commentToAdd.Comment, commentToAdd.CreatedBy, commentToAdd.CreatedAt).Scan(&commentId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+
+	var activities []response.TeamActivity
+	if assigneeTeam != nil {
+		activities, err = InsertTeamActivities(ctx, tx, []request.TeamActivity{{TeamID: *assigneeTeam, ActorID: commentToAdd.CreatedBy, Type: constant.TEAM_ACTIVITY_COMMENT_ADDED,
+			TaskID: &commentToAdd.TaskID, Details: map[string]interface{}{"commentId": strconv.FormatInt(commentId, 10)}}})
+		if err != nil {
+			tx.Rollback(ctx)
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	EmitTeamActivities(t.socketServer, activities)
+
+	return commentId, nil
+}
+
 func GetTasksFromRedisByIDList(redisClient *redis.Client, taskIds []string) ([]response.Task, error) {
 	var tasks []response.Task
 	for _, taskId := range taskIds {
@@ -322,3 +572,45 @@ func GetTasksFromRedisByIDList(redisClient *redis.Client, taskIds []string) ([]r
 
 	return tasks, nil
 }
+
+// TasksOfOrganization returns those of given tasks which belong to the organization.
+func TasksOfOrganization(tasks []response.Task, organizationId int64) []response.Task {
+	tasksOfOrganization := make([]response.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.OrganizationID == organizationId {
+			tasksOfOrganization = append(tasksOfOrganization, task)
+		}
+	}
+	return tasksOfOrganization
+}
+
+// ScanTask scans task selected with TaskColumns from given row.
+func ScanTask(row pgx.Row, task *response.Task) error {
+	return row.Scan(&task.ID, &task.Title, &task.Description, &task.Deadline, &task.AssigneeIndividual, &task.AssigneeTeam, &task.Status, &task.Priority,
+		&task.CreatedBy, &task.CreatedAt, &task.UpdatedBy, &task.UpdatedAt, &task.OwnerIndividual, &task.OrganizationID, &task.ProjectID, &task.MilestoneID)
+}
+
+// CacheTasksByID reads given tasks from database and stores them in cache again, it is used after tasks are changed by queries other than UpdateTask.
+func CacheTasksByID(ctx context.Context, dbConn *pgx.Conn, redisClient *redis.Client, taskIds []int64) error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	rows, err := dbConn.Query(ctx, `SELECT `+TaskColumns+` FROM tasks WHERE id = ANY($1)`, taskIds)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var task response.Task
+	for rows.Next() {
+		if err := ScanTask(rows, &task); err != nil {
+			return err
+		}
+		taskJSON, _ := json.Marshal(task)
+		err = redisClient.Set(ctx, "tasks:"+strconv.FormatInt(task.ID, 10), taskJSON, 0).Err()
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
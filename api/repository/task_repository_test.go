@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
 	errorhandling "github.com/chirag1807/task-management-system/error"
 	"github.com/stretchr/testify/assert"
 )
@@ -18,6 +19,8 @@ func TestCreateTask(t *testing.T) {
 		Deadline           time.Time
 		AssigneeIndividual *int64
 		AssigneeTeam       *int64
+		ProjectID          *int64
+		MilestoneID        *int64
 		Status             string
 		Priority           string
 		CreatedBy          int64
@@ -72,6 +75,70 @@ func TestCreateTask(t *testing.T) {
 			Expected:     errorhandling.OnlyPublicTeamAssignne,
 			StatusCode:   400,
 		},
+		{
+			TestCaseName:       "Assignee is Not Member of Organization",
+			Title:              "Task1",
+			Description:        "This is Dummy Task For Test-Cases.",
+			Deadline:           time.Now().Add(3 * 24 * time.Hour),
+			AssigneeIndividual: func() *int64 { id := int64(954497896847212548); return &id }(),
+			Status:             "TO-DO",
+			Priority:           "HIGH",
+			CreatedBy:          954488202459119617,
+			Expected:           errorhandling.NotMemberOfOrganization,
+			StatusCode:         400,
+		},
+		{
+			TestCaseName: "Team of Other Organization",
+			Title:        "Task1",
+			Description:  "This is Dummy Task For Test-Cases.",
+			Deadline:     time.Now().Add(3 * 24 * time.Hour),
+			AssigneeTeam: func() *int64 { id := int64(954507580144451588); return &id }(),
+			Status:       "TO-DO",
+			Priority:     "HIGH",
+			CreatedBy:    954488202459119617,
+			Expected:     errorhandling.NoTeamFound,
+			StatusCode:   404,
+		},
+		{
+			TestCaseName: "Task Created in Milestone of Project Successfully",
+			Title:        "Task1",
+			Description:  "This is Dummy Task For Test-Cases.",
+			Deadline:     time.Now().Add(3 * 24 * time.Hour),
+			AssigneeTeam: func() *int64 { id := int64(954507580144451585); return &id }(),
+			MilestoneID:  func() *int64 { id := int64(954570000000000101); return &id }(),
+			Status:       "TO-DO",
+			Priority:     "HIGH",
+			CreatedBy:    954488202459119617,
+			Expected:     nil,
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Milestone of Other Project",
+			Title:        "Task1",
+			Description:  "This is Dummy Task For Test-Cases.",
+			Deadline:     time.Now().Add(3 * 24 * time.Hour),
+			AssigneeTeam: func() *int64 { id := int64(954507580144451585); return &id }(),
+			ProjectID:    func() *int64 { id := int64(954570000000000002); return &id }(),
+			MilestoneID:  func() *int64 { id := int64(954570000000000101); return &id }(),
+			Status:       "TO-DO",
+			Priority:     "HIGH",
+			CreatedBy:    954488202459119617,
+			Expected:     errorhandling.MilestoneNotInProject,
+			StatusCode:   400,
+		},
+		{
+			TestCaseName: "Project of Other Organization",
+			Title:        "Task1",
+			Description:  "This is Dummy Task For Test-Cases.",
+			Deadline:     time.Now().Add(3 * 24 * time.Hour),
+			AssigneeTeam: func() *int64 { id := int64(954507580144451585); return &id }(),
+			ProjectID:    func() *int64 { id := int64(954570000000000002); return &id }(),
+			Status:       "TO-DO",
+			Priority:     "HIGH",
+			CreatedBy:    954488202459119617,
+			Expected:     errorhandling.NoProjectFound,
+			StatusCode:   404,
+		},
 	}
 
 	for _, v := range testCases {
@@ -83,13 +150,16 @@ func TestCreateTask(t *testing.T) {
 				Deadline:           v.Deadline,
 				AssigneeIndividual: v.AssigneeIndividual,
 				AssigneeTeam:       v.AssigneeTeam,
+				ProjectID:          v.ProjectID,
+				MilestoneID:        v.MilestoneID,
 				Status:             v.Status,
 				Priority:           v.Priority,
 				CreatedBy:          v.CreatedBy,
 				CreatedAt:          time.Now(),
+				OrganizationID:     954560000000000001,
 			}
 
-			_, err := NewTaskRepo(dbConn, redisClient, socketServer).CreateTask(task)
+			_, err := NewTaskRepo(dbConn, redisClient, rabbitmqConn, socketServer).CreateTask(task)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
@@ -136,7 +206,7 @@ func TestGetAllTasks(t *testing.T) {
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
 
-			_, err := NewTaskRepo(dbConn, redisClient, socketServer).GetAllTasks(v.UserId, v.QueryParams)
+			_, err := NewTaskRepo(dbConn, redisClient, rabbitmqConn, socketServer).GetAllTasks(954560000000000001, v.UserId, v.QueryParams)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
@@ -189,11 +259,24 @@ func TestGetTasksofTeam(t *testing.T) {
 			},
 			StatusCode: 200,
 		},
+		{
+			TestCaseName: "Team of Other Organization",
+			TeamID:       954507580144451588,
+			QueryParams: request.TaskQueryParams{
+				Limit:        1,
+				Offset:       0,
+				Search:       "",
+				Status:       "",
+				SortByFilter: true,
+			},
+			Expected:   errorhandling.NoTeamFound,
+			StatusCode: 404,
+		},
 	}
 
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
-			_, err := NewTaskRepo(dbConn, redisClient, socketServer).GetTasksofTeam(v.TeamID, v.QueryParams)
+			_, err := NewTaskRepo(dbConn, redisClient, rabbitmqConn, socketServer).GetTasksofTeam(954560000000000001, v.TeamID, v.QueryParams)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
@@ -256,6 +339,16 @@ func TestUpdateTask(t *testing.T) {
 			Expected:     errorhandling.TaskClosed,
 			StatusCode:   400,
 		},
+		{
+			TestCaseName: "Task of Other Organization",
+			ID:           954511608047501317,
+			Title:        "Task123",
+			CreatedBy:    954497896847212548,
+			UpdatedBy:    954488202459119617,
+			UpdatedAt:    time.Now(),
+			Expected:     errorhandling.NoTaskFound,
+			StatusCode:   404,
+		},
 		{
 			TestCaseName:       "Assignee Team to Assignee Individual",
 			ID:                 954511608047501313,
@@ -280,9 +373,99 @@ func TestUpdateTask(t *testing.T) {
 				UpdatedAt:          &v.UpdatedAt,
 			}
 
-			err := NewTaskRepo(dbConn, redisClient, socketServer).UpdateTask(task)
+			err := NewTaskRepo(dbConn, redisClient, rabbitmqConn, socketServer).UpdateTask(954560000000000001, task)
 			fmt.Println(err)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
 }
+
+func TestAddCommentToTask(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		TaskID       int64
+		Comment      string
+		CreatedBy    int64
+		Expected     interface{}
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Comment Added Successfully",
+			TaskID:       954511608047501313,
+			Comment:      "Started working on this task.",
+			CreatedBy:    954488202459119617,
+			Expected:     nil,
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Not Allowed to Comment on Task",
+			TaskID:       954511608047501314,
+			Comment:      "Started working on this task.",
+			CreatedBy:    954497896847212546,
+			Expected:     errorhandling.NotAllowed,
+			StatusCode:   403,
+		},
+		{
+			TestCaseName: "No Task Found",
+			TaskID:       1,
+			Comment:      "Started working on this task.",
+			CreatedBy:    954488202459119617,
+			Expected:     errorhandling.NoTaskFound,
+			StatusCode:   404,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+
+			comment := request.TaskComment{
+				TaskID:    v.TaskID,
+				Comment:   v.Comment,
+				CreatedBy: v.CreatedBy,
+				CreatedAt: time.Now(),
+			}
+
+			_, err := NewTaskRepo(dbConn, redisClient, rabbitmqConn, socketServer).AddCommentToTask(954560000000000001, comment)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestCreateTeamActivitiesOfTaskUpdate(t *testing.T) {
+	oldTeam, newTeam, updatedBy := int64(954507580144451585), int64(954507580144451586), int64(954488202459119617)
+	testCases := []struct {
+		TestCaseName string
+		DBTask       response.Task
+		TaskToUpdate request.UpdateTask
+		Expected     []string
+	}{
+		{
+			TestCaseName: "Status Changed",
+			DBTask:       response.Task{ID: 954511608047501313, AssigneeTeam: &oldTeam, Status: "TO-DO"},
+			TaskToUpdate: request.UpdateTask{ID: 954511608047501313, Status: "COMPLETED", UpdatedBy: &updatedBy},
+			Expected:     []string{"TASK-STATUS-CHANGED"},
+		},
+		{
+			TestCaseName: "Reassigned to Another Team",
+			DBTask:       response.Task{ID: 954511608047501313, AssigneeTeam: &oldTeam, Status: "TO-DO"},
+			TaskToUpdate: request.UpdateTask{ID: 954511608047501313, AssigneeTeam: &newTeam, UpdatedBy: &updatedBy},
+			Expected:     []string{"TASK-REASSIGNED", "TASK-REASSIGNED"},
+		},
+		{
+			TestCaseName: "Individual Task Changed",
+			DBTask:       response.Task{ID: 954511608047501313, AssigneeIndividual: &updatedBy, Status: "TO-DO"},
+			TaskToUpdate: request.UpdateTask{ID: 954511608047501313, Status: "COMPLETED", UpdatedBy: &updatedBy},
+			Expected:     nil,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			var activityTypes []string
+			for _, activity := range CreateTeamActivitiesOfTaskUpdate(v.DBTask, v.TaskToUpdate) {
+				activityTypes = append(activityTypes, activity.Type)
+			}
+			assert.Equal(t, v.Expected, activityTypes)
+		})
+	}
+}
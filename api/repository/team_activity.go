@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/chirag1807/task-management-system/utils/socket"
+	socketio "github.com/googollee/go-socket.io"
+	"github.com/jackc/pgx/v5"
+)
+
+// InsertTeamActivities writes given activities using the transaction of the change they describe,
+// so that an activity is persisted only if that change is committed.
+func InsertTeamActivities(ctx context.Context, tx pgx.Tx, activities []request.TeamActivity) ([]response.TeamActivity, error) {
+	insertedActivities := make([]response.TeamActivity, 0, len(activities))
+	for _, v := range activities {
+		activity := response.TeamActivity{
+			TeamID:   v.TeamID,
+			ActorID:  v.ActorID,
+			Type:     v.Type,
+			TaskID:   v.TaskID,
+			MemberID: v.MemberID,
+			Details:  v.Details,
+		}
+		err := tx.QueryRow(ctx, `INSERT INTO team_activities (team_id, actor_id, activity_type, task_id, member_id, details) VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, created_at`, v.TeamID, v.ActorID, v.Type, v.TaskID, v.MemberID, v.Details).Scan(&activity.ID, &activity.CreatedAt)
+		if err != nil {
+			return insertedActivities, err
+		}
+		insertedActivities = append(insertedActivities, activity)
+	}
+	return insertedActivities, nil
+}
+
+// EmitTeamActivities pushes committed activities to the socket room of their team.
+func EmitTeamActivities(socketServer *socketio.Server, activities []response.TeamActivity) {
+	if socketServer == nil {
+		return
+	}
+	for _, v := range activities {
+		socket.EmitTeamActivityEvent(socketServer, constant.TEAM_ACTIVITY_EVENT, strconv.FormatInt(v.TeamID, 10), v)
+	}
+}
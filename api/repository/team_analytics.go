@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+)
+
+// GetTeamAnalytics returns throughput, cycle time, overdue rate and distribution of tasks assigned to the team for the date range. only owner of the team
+// and admins can see it, isAdmin tells whether user is admin of the organization or of the system. analytics is cached for a short time as it scans
+// all tasks and status changes of the team in the range.
+func (t teamRepository) GetTeamAnalytics(organizationId int64, userId int64, isAdmin bool, teamId int64, queryParams request.TeamAnalyticsQueryParams) (response.TeamAnalytics, error) {
+	var analytics response.TeamAnalytics
+	from, to, err := GetAnalyticsRange(queryParams, time.Now().UTC())
+	if err != nil {
+		return analytics, err
+	}
+	teamCreatedBy, err := t.getTeamCreatedBy(organizationId, teamId)
+	if err != nil {
+		return analytics, err
+	}
+	if teamCreatedBy != userId && !isAdmin {
+		return analytics, errorhandling.TeamAnalyticsNotAllowed
+	}
+
+	ctx := context.Background()
+	cacheKey := "team_analytics:" + strconv.FormatInt(teamId, 10) + ":" + from.Format(constant.ANALYTICS_DATE_FORMAT) + ":" + to.Format(constant.ANALYTICS_DATE_FORMAT)
+	cachedAnalytics, err := t.redisClient.Get(ctx, cacheKey).Result()
+	if err == nil && json.Unmarshal([]byte(cachedAnalytics), &analytics) == nil {
+		return analytics, nil
+	}
+
+	analytics = response.TeamAnalytics{TeamID: teamId, From: from, To: to, Throughput: make([]response.TeamWeeklyThroughput, 0),
+		ByPriority: make([]response.TeamPriorityDistribution, 0), ByAssignee: make([]response.TeamAssigneeDistribution, 0), GeneratedAt: time.Now().UTC()}
+
+	// weeks of the range are generated so that weeks without any task are also returned, tasks are completed when their status changes to COMPLETED.
+	weeks, err := t.dbConn.Query(ctx, `SELECT w.week_start, COALESCE(c.tasks, 0), COALESCE(d.tasks, 0)
+	FROM generate_series(date_trunc('week', $3::TIMESTAMP), $4::TIMESTAMP - INTERVAL '1 day', INTERVAL '1 week') AS w(week_start)
+	LEFT JOIN (SELECT date_trunc('week', created_at) AS week_start, COUNT(*) AS tasks FROM tasks WHERE assignee_team = $1 AND organization_id = $2
+	AND created_at >= $3 AND created_at < $4 GROUP BY 1) AS c ON c.week_start = w.week_start
+	LEFT JOIN (SELECT date_trunc('week', created_at) AS week_start, COUNT(DISTINCT task_id) AS tasks FROM team_activities WHERE team_id = $1 AND activity_type = $5
+	AND details->>'to' = 'COMPLETED' AND created_at >= $3 AND created_at < $4 GROUP BY 1) AS d ON d.week_start = w.week_start
+	ORDER BY w.week_start`, teamId, organizationId, from, to, constant.TEAM_ACTIVITY_TASK_STATUS_CHANGED)
+	if err != nil {
+		return analytics, err
+	}
+	for weeks.Next() {
+		var week response.TeamWeeklyThroughput
+		if err := weeks.Scan(&week.WeekStart, &week.CreatedTasks, &week.CompletedTasks); err != nil {
+			weeks.Close()
+			return analytics, err
+		}
+		analytics.Throughput = append(analytics.Throughput, week)
+	}
+	weeks.Close()
+	if err := weeks.Err(); err != nil {
+		return analytics, err
+	}
+
+	// cycle time of the task runs from it's first move to IN-PROGRESS till it's last completion in the range.
+	err = t.dbConn.QueryRow(ctx, `SELECT COUNT(*), percentile_cont(0.5) WITHIN GROUP (ORDER BY cycle_hours), percentile_cont(0.85) WITHIN GROUP (ORDER BY cycle_hours)
+	FROM (SELECT EXTRACT(EPOCH FROM (c.completed_at - s.started_at)) / 3600 AS cycle_hours
+	FROM (SELECT task_id, MAX(created_at) AS completed_at FROM team_activities WHERE team_id = $1 AND activity_type = $2 AND details->>'to' = 'COMPLETED'
+	AND created_at >= $3 AND created_at < $4 GROUP BY task_id) AS c
+	JOIN (SELECT task_id, MIN(created_at) AS started_at FROM team_activities WHERE team_id = $1 AND activity_type = $2 AND details->>'to' = 'IN-PROGRESS'
+	GROUP BY task_id) AS s ON s.task_id = c.task_id AND s.started_at < c.completed_at)`, teamId, constant.TEAM_ACTIVITY_TASK_STATUS_CHANGED, from, to).
+		Scan(&analytics.CycleTime.CompletedTasks, &analytics.CycleTime.MedianHours, &analytics.CycleTime.P85Hours)
+	if err != nil {
+		return analytics, err
+	}
+
+	err = t.dbConn.QueryRow(ctx, `SELECT COUNT(*), COUNT(*) FILTER (WHERE t.deadline < $5 AND (t.status IN ('TO-DO', 'IN-PROGRESS')
+	OR EXISTS (SELECT 1 FROM team_activities AS a WHERE a.team_id = $1 AND a.task_id = t.id AND a.activity_type = $6 AND a.details->>'to' = 'COMPLETED'
+	AND a.created_at > t.deadline))) FROM tasks AS t WHERE t.assignee_team = $1 AND t.organization_id = $2 AND t.deadline >= $3 AND t.deadline < $4`,
+		teamId, organizationId, from, to, analytics.GeneratedAt, constant.TEAM_ACTIVITY_TASK_STATUS_CHANGED).Scan(&analytics.Overdue.DueTasks, &analytics.Overdue.OverdueTasks)
+	if err != nil {
+		return analytics, err
+	}
+	if analytics.Overdue.DueTasks != 0 {
+		analytics.Overdue.OverduePercentage = math.Round(float64(analytics.Overdue.OverdueTasks)*10000/float64(analytics.Overdue.DueTasks)) / 100
+	}
+
+	priorities, err := t.dbConn.Query(ctx, `SELECT priority, COUNT(*), COUNT(*) FILTER (WHERE status IN ('TO-DO', 'IN-PROGRESS')), COUNT(*) FILTER (WHERE status = 'COMPLETED')
+	FROM tasks WHERE assignee_team = $1 AND organization_id = $2 AND created_at >= $3 AND created_at < $4 GROUP BY priority ORDER BY priority`, teamId, organizationId, from, to)
+	if err != nil {
+		return analytics, err
+	}
+	for priorities.Next() {
+		var priority response.TeamPriorityDistribution
+		if err := priorities.Scan(&priority.Priority, &priority.TotalTasks, &priority.OpenTasks, &priority.CompletedTasks); err != nil {
+			priorities.Close()
+			return analytics, err
+		}
+		analytics.ByPriority = append(analytics.ByPriority, priority)
+	}
+	priorities.Close()
+	if err := priorities.Err(); err != nil {
+		return analytics, err
+	}
+
+	// task assigned to the team is owned by the member picked by auto assignment, if any.
+	assignees, err := t.dbConn.Query(ctx, `SELECT t.owner_individual, COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), COUNT(*),
+	COUNT(*) FILTER (WHERE t.status IN ('TO-DO', 'IN-PROGRESS')), COUNT(*) FILTER (WHERE t.status = 'COMPLETED')
+	FROM tasks AS t LEFT JOIN users AS u ON u.id = t.owner_individual WHERE t.assignee_team = $1 AND t.organization_id = $2 AND t.created_at >= $3 AND t.created_at < $4
+	GROUP BY t.owner_individual, u.first_name, u.last_name ORDER BY COUNT(*) DESC, t.owner_individual`, teamId, organizationId, from, to)
+	if err != nil {
+		return analytics, err
+	}
+	defer assignees.Close()
+	for assignees.Next() {
+		var assignee response.TeamAssigneeDistribution
+		if err := assignees.Scan(&assignee.MemberID, &assignee.FirstName, &assignee.LastName, &assignee.TotalTasks, &assignee.OpenTasks,
+			&assignee.CompletedTasks); err != nil {
+			return analytics, err
+		}
+		analytics.ByAssignee = append(analytics.ByAssignee, assignee)
+	}
+	if err := assignees.Err(); err != nil {
+		return analytics, err
+	}
+
+	analyticsJSON, err := json.Marshal(analytics)
+	if err == nil {
+		t.redisClient.Set(ctx, cacheKey, analyticsJSON, constant.TEAM_ANALYTICS_CACHE_TTL)
+	}
+	return analytics, nil
+}
+
+// GetAnalyticsRange returns start of from date and end of to date of the range, to date defaults to today and from date to
+// ANALYTICS_DEFAULT_RANGE_DAYS days before it. range must not be reversed or longer than ANALYTICS_MAX_RANGE_DAYS days.
+func GetAnalyticsRange(queryParams request.TeamAnalyticsQueryParams, now time.Time) (time.Time, time.Time, error) {
+	to := now.Truncate(24 * time.Hour)
+	if queryParams.To != constant.EMPTY_STRING {
+		toDate, err := time.Parse(constant.ANALYTICS_DATE_FORMAT, queryParams.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, errorhandling.InvalidAnalyticsRange
+		}
+		to = toDate
+	}
+	to = to.Add(24 * time.Hour)
+
+	from := to.Add(-constant.ANALYTICS_DEFAULT_RANGE_DAYS * 24 * time.Hour)
+	if queryParams.From != constant.EMPTY_STRING {
+		fromDate, err := time.Parse(constant.ANALYTICS_DATE_FORMAT, queryParams.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, errorhandling.InvalidAnalyticsRange
+		}
+		from = fromDate
+	}
+
+	if !from.Before(to) || to.Sub(from) > constant.ANALYTICS_MAX_RANGE_DAYS*24*time.Hour {
+		return time.Time{}, time.Time{}, errorhandling.InvalidAnalyticsRange
+	}
+	return from, to, nil
+}
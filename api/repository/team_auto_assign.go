@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/jackc/pgx/v5"
+)
+
+// PickTeamMemberForTask selects the member who will own a task assigned to the team by assignerId as per team's auto assign strategy.
+// team row is locked for the rest of the transaction, so concurrent task creations for the same team are picked one after another.
+// members who are unavailable at given time or can't be assigned task by the assigner as per CheckTaskAssignee are skipped,
+// and nil is returned if strategy is NONE or no member is available.
+func PickTeamMemberForTask(ctx context.Context, tx pgx.Tx, teamId int64, assignerId int64, now time.Time) (*int64, error) {
+	var strategy string
+	var organizationId int64
+	var lastAssignedMember *int64
+	err := tx.QueryRow(ctx, `SELECT auto_assign_strategy, organization_id, last_auto_assigned_member FROM teams WHERE id = $1 FOR UPDATE`, teamId).Scan(&strategy,
+		&organizationId, &lastAssignedMember)
+	if err != nil {
+		return nil, err
+	}
+
+	// members are joined with users (aliased as u) for assignable_by and email_verified, assigner is $1 for TeammateOfViewer.
+	assignableMember := `(u.id = $1 OR u.assignable_by = '` + constant.VISIBILITY_EVERYONE + `' OR (u.assignable_by = '` + constant.VISIBILITY_TEAMMATES + `' AND ` + TeammateOfViewer("$2") + `))`
+	if config.Config.EmailVerification.RequiredForTasks {
+		assignableMember += ` AND u.email_verified`
+	}
+
+	var pickedMember int64
+	switch strategy {
+	case constant.AUTO_ASSIGN_ROUND_ROBIN:
+		var lastMember int64
+		if lastAssignedMember != nil {
+			lastMember = *lastAssignedMember
+		}
+		// next available member after the last picked one in order of member id, wrapping around to the first one.
+		err = tx.QueryRow(ctx, `SELECT m.member_id FROM team_members AS m JOIN users AS u ON u.id = m.member_id
+			WHERE m.team_id = $3 AND (m.unavailable_until IS NULL OR m.unavailable_until <= $4) AND `+assignableMember+`
+			ORDER BY m.member_id <= $5, m.member_id LIMIT 1`, assignerId, organizationId, teamId, now, lastMember).Scan(&pickedMember)
+	case constant.AUTO_ASSIGN_LEAST_LOADED:
+		// only open tasks of the organization of the team are counted, work of the member in other organizations doesn't matter here.
+		err = tx.QueryRow(ctx, `SELECT m.member_id FROM team_members AS m JOIN users AS u ON u.id = m.member_id
+			LEFT JOIN tasks AS t ON (t.assignee_individual = m.member_id OR t.owner_individual = m.member_id) AND t.status IN ('TO-DO', 'IN-PROGRESS')
+			AND t.organization_id = $2
+			WHERE m.team_id = $3 AND (m.unavailable_until IS NULL OR m.unavailable_until <= $4) AND `+assignableMember+`
+			GROUP BY m.member_id ORDER BY COUNT(t.id), m.member_id LIMIT 1`, assignerId, organizationId, teamId, now).Scan(&pickedMember)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE teams SET last_auto_assigned_member = $1 WHERE id = $2`, pickedMember, teamId)
+	if err != nil {
+		return nil, err
+	}
+	return &pickedMember, nil
+}
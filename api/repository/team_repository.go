@@ -7,43 +7,64 @@ import (
 
 	"github.com/chirag1807/task-management-system/api/model/request"
 	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/config"
 	"github.com/chirag1807/task-management-system/constant"
 	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
 	"github.com/go-redis/redis/v8"
+	socketio "github.com/googollee/go-socket.io"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type TeamRepository interface {
 	CreateTeam(teamToCreate request.Team, teamMembers []int64) (int64, error)
-	AddMembersToTeam(teamCreatedBy int64, teamMembersToAdd request.TeamMembersWithTeamID) error
-	RemoveMembersFromTeam(teamCreatedBy int64, teamMembersToRemove request.TeamMembersWithTeamID) error
-	GetAllTeams(userID int64, queryParams request.TeamQueryParams) ([]response.Team, error)
+	AddMembersToTeam(organizationId int64, teamCreatedBy int64, teamMembersToAdd request.TeamMembersWithTeamID) error
+	RemoveMembersFromTeam(organizationId int64, teamCreatedBy int64, teamMembersToRemove request.TeamMembersWithTeamID) error
+	GetAllTeams(organizationId int64, userID int64, queryParams request.TeamQueryParams) ([]response.Team, error)
 	//flag is used for get my created teams and get teams in which i was added.
-	GetTeamMembers(teamId int64, queryParams request.TeamQueryParams) ([]response.User, error)
-	LeaveTeam(userID int64, teamId int64) error
+	GetTeamMembers(organizationId int64, userId int64, teamId int64, queryParams request.TeamQueryParams) ([]response.User, error)
+	LeaveTeam(organizationId int64, userID int64, teamId int64) error
+	GetTeamActivity(organizationId int64, userID int64, teamId int64, queryParams request.TeamActivityQueryParams) ([]response.TeamActivity, error)
+	UpdateTeamAutoAssignStrategy(organizationId int64, teamCreatedBy int64, autoAssignStrategy request.TeamAutoAssignStrategy) error
+	UpdateTeamMemberAvailability(organizationId int64, userID int64, memberAvailability request.TeamMemberAvailability) error
+	GetTeamAnalytics(organizationId int64, userId int64, isAdmin bool, teamId int64, queryParams request.TeamAnalyticsQueryParams) (response.TeamAnalytics, error)
 }
 
 type teamRepository struct {
-	dbConn      *pgx.Conn
-	redisClient *redis.Client
+	dbConn       *pgx.Conn
+	redisClient  *redis.Client
+	socketServer *socketio.Server
 }
 
-func NewTeamRepo(dbConn *pgx.Conn, redisClient *redis.Client) TeamRepository {
+func NewTeamRepo(dbConn *pgx.Conn, redisClient *redis.Client, socketServer *socketio.Server) TeamRepository {
 	return teamRepository{
-		dbConn:      dbConn,
-		redisClient: redisClient,
+		dbConn:       dbConn,
+		redisClient:  redisClient,
+		socketServer: socketServer,
 	}
 }
 
+// CreateTeam creates team in the organization of teamToCreate, all members of the team must be members of the organization
+// and are checked same way as members added by AddMembersToTeam.
 func (t teamRepository) CreateTeam(teamToCreate request.Team, teamMembers []int64) (int64, error) {
 	ctx := context.Background()
+	err := CheckOrganizationMembers(ctx, t.dbConn, teamToCreate.OrganizationID, teamMembers)
+	if err != nil {
+		return 0, err
+	}
+	err = t.checkMembersToAdd(ctx, teamToCreate.OrganizationID, teamToCreate.CreatedBy, teamMembers)
+	if err != nil {
+		return 0, err
+	}
+
 	tx, err := t.dbConn.Begin(ctx)
 	if err != nil {
 		return 0, err
 	}
 	var teamId int64
-	rows := tx.QueryRow(ctx, `INSERT INTO teams (name, team_privacy, created_by) VALUES ($1, $2, $3) RETURNING id`, teamToCreate.Name, teamToCreate.Privacy, teamToCreate.CreatedBy)
+	rows := tx.QueryRow(ctx, `INSERT INTO teams (name, team_privacy, created_by, organization_id) VALUES ($1, $2, $3, $4) RETURNING id`, teamToCreate.Name, teamToCreate.Privacy,
+		teamToCreate.CreatedBy, teamToCreate.OrganizationID)
 	err = rows.Scan(&teamId)
 	if err != nil {
 		tx.Rollback(ctx)
@@ -61,6 +82,21 @@ func (t teamRepository) CreateTeam(teamToCreate request.Team, teamMembers []int6
 		tx.Rollback(ctx)
 		return teamId, err
 	}
+
+	var activitiesToInsert []request.TeamActivity
+	for _, v := range teamMembers {
+		if v == teamToCreate.CreatedBy {
+			continue
+		}
+		memberId := v
+		activitiesToInsert = append(activitiesToInsert, request.TeamActivity{TeamID: teamId, ActorID: teamToCreate.CreatedBy, Type: constant.TEAM_ACTIVITY_MEMBER_ADDED, MemberID: &memberId})
+	}
+	activities, err := InsertTeamActivities(ctx, tx, activitiesToInsert)
+	if err != nil {
+		tx.Rollback(ctx)
+		return teamId, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		tx.Rollback(ctx)
 		return teamId, err
@@ -69,47 +105,65 @@ func (t teamRepository) CreateTeam(teamToCreate request.Team, teamMembers []int6
 	for _, v := range teamMembers {
 		t.redisClient.SAdd(ctx, "user:"+strconv.FormatInt(v, 10)+":teams", teamId)
 	}
+	EmitTeamActivities(t.socketServer, activities)
 
 	return teamId, nil
 }
 
-func (t teamRepository) AddMembersToTeam(teamCreatedBy int64, teamMembersToAdd request.TeamMembersWithTeamID) error {
-	var dbTeamCreatedBy int64
-	rows := t.dbConn.QueryRow(context.Background(), `SELECT created_by FROM teams WHERE id = $1`, teamMembersToAdd.TeamID)
-	err := rows.Scan(&dbTeamCreatedBy)
-	if err != nil {
-		return err
-	}
-
-	if dbTeamCreatedBy != teamCreatedBy {
-		return errorhandling.NotAllowed
-	}
-
-	var args []interface{}
-	query := `SELECT privacy FROM users WHERE id IN (`
-	for i, v := range teamMembersToAdd.MemberIDs {
-		query += `$` + strconv.Itoa(i+1) + `, `
+// checkMembersToAdd returns error if any of given users can't be added to team by addedBy, users who are not discoverable can be added
+// only by someone who already shares a team of the organization with them and unverified users can't be added while verified members are required.
+func (t teamRepository) checkMembersToAdd(ctx context.Context, organizationId int64, addedBy int64, memberIds []int64) error {
+	args := []interface{}{addedBy, organizationId}
+	query := `SELECT u.id, u.discoverable, u.email_verified, ` + TeammateOfViewer("$2") + ` FROM users as u WHERE u.id IN (`
+	for i, v := range memberIds {
+		query += `$` + strconv.Itoa(i+3) + `, `
 		args = append(args, v)
 	}
-	if len(teamMembersToAdd.MemberIDs) > 0 {
+	if len(memberIds) > 0 {
 		query = query[:len(query)-2]
 	}
 	query += `)`
 
-	users, err := t.dbConn.Query(context.Background(), query, args...)
+	users, err := t.dbConn.Query(ctx, query, args...)
 	if err != nil {
 		return err
 	}
 	defer users.Close()
 
 	var user response.User
+	var isTeammate bool
 	for users.Next() {
-		if err := users.Scan(&user.Privacy); err != nil {
+		if err := users.Scan(&user.ID, &user.Privacy.Discoverable, &user.EmailVerified, &isTeammate); err != nil {
 			return err
 		}
-		if user.Privacy == "PRIVATE" {
+		if !user.Privacy.Discoverable && !isTeammate && user.ID != addedBy {
 			return errorhandling.OnlyPublicMemberAllowed
 		}
+		if config.Config.EmailVerification.RequiredForTeams && !user.EmailVerified {
+			return errorhandling.OnlyVerifiedMemberAllowed
+		}
+	}
+	return users.Err()
+}
+
+func (t teamRepository) AddMembersToTeam(organizationId int64, teamCreatedBy int64, teamMembersToAdd request.TeamMembersWithTeamID) error {
+	dbTeamCreatedBy, err := t.getTeamCreatedBy(organizationId, teamMembersToAdd.TeamID)
+	if err != nil {
+		return err
+	}
+
+	if dbTeamCreatedBy != teamCreatedBy {
+		return errorhandling.NotAllowed
+	}
+
+	err = CheckOrganizationMembers(context.Background(), t.dbConn, organizationId, teamMembersToAdd.MemberIDs)
+	if err != nil {
+		return err
+	}
+
+	err = t.checkMembersToAdd(context.Background(), organizationId, teamCreatedBy, teamMembersToAdd.MemberIDs)
+	if err != nil {
+		return err
 	}
 
 	ctx := context.Background()
@@ -133,6 +187,18 @@ func (t teamRepository) AddMembersToTeam(teamCreatedBy int64, teamMembersToAdd r
 		}
 		return err
 	}
+
+	var activitiesToInsert []request.TeamActivity
+	for _, v := range teamMembersToAdd.MemberIDs {
+		memberId := v
+		activitiesToInsert = append(activitiesToInsert, request.TeamActivity{TeamID: teamMembersToAdd.TeamID, ActorID: teamCreatedBy, Type: constant.TEAM_ACTIVITY_MEMBER_ADDED, MemberID: &memberId})
+	}
+	activities, err := InsertTeamActivities(ctx, tx, activitiesToInsert)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		tx.Rollback(ctx)
 		pgErr, ok := err.(*pgconn.PgError)
@@ -145,14 +211,13 @@ func (t teamRepository) AddMembersToTeam(teamCreatedBy int64, teamMembersToAdd r
 	for _, v := range teamMembersToAdd.MemberIDs {
 		t.redisClient.SAdd(ctx, "user:"+strconv.FormatInt(v, 10)+":teams", teamMembersToAdd.TeamID)
 	}
+	EmitTeamActivities(t.socketServer, activities)
 
 	return nil
 }
 
-func (t teamRepository) RemoveMembersFromTeam(teamCreatedBy int64, teamMembersToRemove request.TeamMembersWithTeamID) error {
-	var dbTeamCreatedBy int64
-	rows := t.dbConn.QueryRow(context.Background(), `SELECT created_by FROM teams WHERE id = $1`, teamMembersToRemove.TeamID)
-	err := rows.Scan(&dbTeamCreatedBy)
+func (t teamRepository) RemoveMembersFromTeam(organizationId int64, teamCreatedBy int64, teamMembersToRemove request.TeamMembersWithTeamID) error {
+	dbTeamCreatedBy, err := t.getTeamCreatedBy(organizationId, teamMembersToRemove.TeamID)
 	if err != nil {
 		return err
 	}
@@ -160,30 +225,69 @@ func (t teamRepository) RemoveMembersFromTeam(teamCreatedBy int64, teamMembersTo
 		return errorhandling.NotAllowed
 	}
 
-	var args []interface{}
-	query := `DELETE FROM team_members WHERE member_id IN (`
+	args := []interface{}{teamMembersToRemove.TeamID}
+	query := `DELETE FROM team_members WHERE team_id = $1 AND member_id IN (`
 	for i, v := range teamMembersToRemove.MemberIDs {
-		query += `$` + strconv.Itoa(i+1) + `, `
+		query += `$` + strconv.Itoa(i+2) + `, `
 		args = append(args, v)
 	}
 	if len(teamMembersToRemove.MemberIDs) > 0 {
 		query = query[:len(query)-2]
 	}
-	query += `)`
+	query += `) RETURNING member_id`
+
+	ctx := context.Background()
+	tx, err := t.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	removedMembers, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	var removedMemberIDs []int64
+	for removedMembers.Next() {
+		var memberId int64
+		if err := removedMembers.Scan(&memberId); err != nil {
+			removedMembers.Close()
+			tx.Rollback(ctx)
+			return err
+		}
+		removedMemberIDs = append(removedMemberIDs, memberId)
+	}
+	removedMembers.Close()
+	if err := removedMembers.Err(); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
 
-	_, err = t.dbConn.Exec(context.Background(), query, args...)
+	var activitiesToInsert []request.TeamActivity
+	for _, v := range removedMemberIDs {
+		memberId := v
+		activitiesToInsert = append(activitiesToInsert, request.TeamActivity{TeamID: teamMembersToRemove.TeamID, ActorID: teamCreatedBy, Type: constant.TEAM_ACTIVITY_MEMBER_REMOVED, MemberID: &memberId})
+	}
+	activities, err := InsertTeamActivities(ctx, tx, activitiesToInsert)
 	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
 		return err
 	}
 
-	for _, v := range teamMembersToRemove.MemberIDs {
-		t.redisClient.SRem(context.Background(), "user:"+strconv.FormatInt(v, 10)+":teams", teamMembersToRemove.TeamID)
+	for _, v := range removedMemberIDs {
+		t.redisClient.SRem(ctx, "user:"+strconv.FormatInt(v, 10)+":teams", teamMembersToRemove.TeamID)
 	}
+	EmitTeamActivities(t.socketServer, activities)
 
 	return nil
 }
 
-func (t teamRepository) GetAllTeams(userID int64, queryParams request.TeamQueryParams) ([]response.Team, error) {
+func (t teamRepository) GetAllTeams(organizationId int64, userID int64, queryParams request.TeamQueryParams) ([]response.Team, error) {
 	//flag = 0 => created by me, flag = 1 => i am member
 	var teams pgx.Rows
 	var err error
@@ -191,14 +295,15 @@ func (t teamRepository) GetAllTeams(userID int64, queryParams request.TeamQueryP
 
 	var query string
 	if !queryParams.CreatedByMe {
-		query = `SELECT * FROM teams WHERE created_by = $1 AND true`
+		query = `SELECT id, name, created_by, created_at, team_privacy, auto_assign_strategy, organization_id FROM teams WHERE created_by = $1 AND organization_id = $2`
 		query = CreateQueryForParamsOfGetTeam(query, queryParams)
-		teams, err = t.dbConn.Query(context.Background(), query, userID)
+		teams, err = t.dbConn.Query(context.Background(), query, userID, organizationId)
 	}
 	if queryParams.CreatedByMe {
-		query = `SELECT * FROM teams WHERE id IN (SELECT team_id from team_members where member_id = $1)`
+		query = `SELECT id, name, created_by, created_at, team_privacy, auto_assign_strategy, organization_id FROM teams WHERE id IN (SELECT team_id from team_members where member_id = $1)
+		AND organization_id = $2`
 		query = CreateQueryForParamsOfGetTeam(query, queryParams)
-		teams, err = t.dbConn.Query(context.Background(), query, userID)
+		teams, err = t.dbConn.Query(context.Background(), query, userID, organizationId)
 	}
 
 	if err != nil {
@@ -208,7 +313,7 @@ func (t teamRepository) GetAllTeams(userID int64, queryParams request.TeamQueryP
 
 	var team response.Team
 	for teams.Next() {
-		if err := teams.Scan(&team.ID, &team.Name, &team.CreatedBy, &team.CreatedAt, &team.TeamPrivacy); err != nil {
+		if err := teams.Scan(&team.ID, &team.Name, &team.CreatedBy, &team.CreatedAt, &team.TeamPrivacy, &team.AutoAssignStrategy, &team.OrganizationID); err != nil {
 			return teamsSlice, err
 		}
 		teamsSlice = append(teamsSlice, team)
@@ -217,14 +322,23 @@ func (t teamRepository) GetAllTeams(userID int64, queryParams request.TeamQueryP
 	return teamsSlice, nil
 }
 
-func (t teamRepository) GetTeamMembers(teamId int64, queryParams request.TeamQueryParams) ([]response.User, error) {
+// GetTeamMembers returns members of the team as seen by given user, members of the team always see each other
+// while others see only members who have not hidden their teams from them. email and bio are left out as per privacy settings of each member.
+func (t teamRepository) GetTeamMembers(organizationId int64, userId int64, teamId int64, queryParams request.TeamQueryParams) ([]response.User, error) {
 	var teamMembers pgx.Rows
-	var err error
 	teamMembersSlice := make([]response.User, 0)
 
-	query := `SELECT id, first_name, last_name, bio, email, privacy FROM users WHERE id IN (SELECT member_id from team_members where team_id = $1)`
+	err := CheckTeamOfOrganization(context.Background(), t.dbConn, organizationId, teamId)
+	if err != nil {
+		return teamMembersSlice, err
+	}
+
+	query := `SELECT u.id, u.first_name, u.last_name, u.bio, u.email, u.email_visibility, u.bio_visibility, u.teams_visibility, u.discoverable, u.assignable_by,
+	u.email_verified, u.avatar_key, ` + TeammateOfViewer("$3") + ` FROM users as u WHERE u.id IN (SELECT member_id from team_members where team_id = $2)
+	AND (u.id = $1 OR u.teams_visibility = 'EVERYONE' OR EXISTS (SELECT 1 FROM team_members WHERE team_id = $2 AND member_id = $1)
+	OR (u.teams_visibility = 'TEAMMATES' AND ` + TeammateOfViewer("$3") + `))`
 	query = CreateQueryForParamsOfGetTeam(query, queryParams)
-	teamMembers, err = t.dbConn.Query(context.Background(), query, teamId)
+	teamMembers, err = t.dbConn.Query(context.Background(), query, userId, teamId, organizationId)
 
 	if err != nil {
 		return teamMembersSlice, err
@@ -233,9 +347,15 @@ func (t teamRepository) GetTeamMembers(teamId int64, queryParams request.TeamQue
 
 	var teamMember response.User
 	for teamMembers.Next() {
-		if err := teamMembers.Scan(&teamMember.ID, &teamMember.FirstName, &teamMember.LastName, &teamMember.Bio, &teamMember.Email, &teamMember.Privacy); err != nil {
+		var avatarKey *string
+		var isTeammate bool
+		if err := teamMembers.Scan(&teamMember.ID, &teamMember.FirstName, &teamMember.LastName, &teamMember.Bio, &teamMember.Email, &teamMember.Privacy.EmailVisibility,
+			&teamMember.Privacy.BioVisibility, &teamMember.Privacy.TeamsVisibility, &teamMember.Privacy.Discoverable, &teamMember.Privacy.AssignableBy,
+			&teamMember.EmailVerified, &avatarKey, &isTeammate); err != nil {
 			return teamMembersSlice, err
 		}
+		teamMember.Avatar = utils.AvatarOf(avatarKey)
+		HideInvisibleFields(&teamMember, userId, isTeammate)
 		teamMembersSlice = append(teamMembersSlice, teamMember)
 	}
 
@@ -254,14 +374,127 @@ func CreateQueryForParamsOfGetTeam(query string, queryParams request.TeamQueryPa
 	return query
 }
 
-func (t teamRepository) LeaveTeam(userID int64, teamId int64) error {
-	a, err := t.dbConn.Exec(context.Background(), "DELETE FROM team_members WHERE member_id = $1 AND team_id = $2", userID, teamId)
+func (t teamRepository) LeaveTeam(organizationId int64, userID int64, teamId int64) error {
+	ctx := context.Background()
+	tx, err := t.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	a, err := tx.Exec(ctx, "DELETE FROM team_members WHERE member_id = $1 AND team_id = $2 AND team_id IN (SELECT id FROM teams WHERE organization_id = $3)", userID, teamId, organizationId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
 	if a.RowsAffected() == 0 {
+		tx.Rollback(ctx)
 		return errorhandling.NotAMember
 	}
+
+	activities, err := InsertTeamActivities(ctx, tx, []request.TeamActivity{{TeamID: teamId, ActorID: userID, Type: constant.TEAM_ACTIVITY_MEMBER_LEFT, MemberID: &userID}})
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	t.redisClient.SRem(ctx, "user:"+strconv.FormatInt(userID, 10)+":teams", teamId)
+	EmitTeamActivities(t.socketServer, activities)
+	return nil
+}
+
+func (t teamRepository) GetTeamActivity(organizationId int64, userID int64, teamId int64, queryParams request.TeamActivityQueryParams) ([]response.TeamActivity, error) {
+	activitiesSlice := make([]response.TeamActivity, 0)
+
+	err := CheckTeamOfOrganization(context.Background(), t.dbConn, organizationId, teamId)
+	if err != nil {
+		return activitiesSlice, err
+	}
+
+	var memberCount int
+	err = t.dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM team_members WHERE team_id = $1 AND member_id = $2`, teamId, userID).Scan(&memberCount)
+	if err != nil {
+		return activitiesSlice, err
+	}
+	if memberCount == 0 {
+		return activitiesSlice, errorhandling.NotAllowed
+	}
+
+	query, args := CreateQueryForParamsOfGetTeamActivity(`SELECT id, team_id, actor_id, activity_type, task_id, member_id, details, created_at FROM team_activities WHERE team_id = $1`,
+		[]interface{}{teamId}, queryParams)
+	activities, err := t.dbConn.Query(context.Background(), query, args...)
+	if err != nil {
+		return activitiesSlice, err
+	}
+	defer activities.Close()
+
+	for activities.Next() {
+		var activity response.TeamActivity
+		if err := activities.Scan(&activity.ID, &activity.TeamID, &activity.ActorID, &activity.Type, &activity.TaskID, &activity.MemberID, &activity.Details,
+			&activity.CreatedAt); err != nil {
+			return activitiesSlice, err
+		}
+		activitiesSlice = append(activitiesSlice, activity)
+	}
+
+	return activitiesSlice, nil
+}
+
+// CreateQueryForParamsOfGetTeamActivity appends activity type and actor filters as query arguments,
+// and orders the feed from latest to oldest activity with id as tie breaker so that pagination stays stable.
+func CreateQueryForParamsOfGetTeamActivity(query string, args []interface{}, queryParams request.TeamActivityQueryParams) (string, []interface{}) {
+	if queryParams.Type != constant.EMPTY_STRING {
+		args = append(args, queryParams.Type)
+		query += " AND activity_type = $" + strconv.Itoa(len(args))
+	}
+	if queryParams.ActorID != 0 {
+		args = append(args, queryParams.ActorID)
+		query += " AND actor_id = $" + strconv.Itoa(len(args))
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	query += fmt.Sprintf(" LIMIT %d", queryParams.Limit)
+	query += fmt.Sprintf(" OFFSET %d", queryParams.Offset)
+	return query, args
+}
+
+func (t teamRepository) UpdateTeamAutoAssignStrategy(organizationId int64, teamCreatedBy int64, autoAssignStrategy request.TeamAutoAssignStrategy) error {
+	dbTeamCreatedBy, err := t.getTeamCreatedBy(organizationId, autoAssignStrategy.TeamID)
+	if err != nil {
+		return err
+	}
+	if dbTeamCreatedBy != teamCreatedBy {
+		return errorhandling.NotAllowed
+	}
+
+	_, err = t.dbConn.Exec(context.Background(), `UPDATE teams SET auto_assign_strategy = $1 WHERE id = $2`, autoAssignStrategy.Strategy, autoAssignStrategy.TeamID)
+	return err
+}
+
+func (t teamRepository) UpdateTeamMemberAvailability(organizationId int64, userID int64, memberAvailability request.TeamMemberAvailability) error {
+	a, err := t.dbConn.Exec(context.Background(), `UPDATE team_members SET unavailable_until = $1 WHERE team_id = $2 AND member_id = $3
+	AND team_id IN (SELECT id FROM teams WHERE organization_id = $4)`, memberAvailability.UnavailableUntil, memberAvailability.TeamID, userID, organizationId)
 	if err != nil {
 		return err
 	}
-	t.redisClient.SRem(context.Background(), "user:"+strconv.FormatInt(userID, 10)+":teams", teamId)
+	if a.RowsAffected() == 0 {
+		return errorhandling.NotMemberOfTeam
+	}
 	return nil
 }
+
+// getTeamCreatedBy returns id of the user who created the team, team of other organization is reported as not found.
+func (t teamRepository) getTeamCreatedBy(organizationId int64, teamId int64) (int64, error) {
+	var teamCreatedBy int64
+	err := t.dbConn.QueryRow(context.Background(), `SELECT created_by FROM teams WHERE id = $1 AND organization_id = $2`, teamId, organizationId).Scan(&teamCreatedBy)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return 0, errorhandling.NoTeamFound
+		}
+		return 0, err
+	}
+	return teamCreatedBy, nil
+}
@@ -24,17 +24,44 @@ func TestCreateTeam(t *testing.T) {
 				Privacy: func() *string { team_privacy := string("PUBLIC"); return &team_privacy }(),
 				CreatedBy:   954488202459119617,
 				CreatedAt:   time.Now(),
+				OrganizationID: 954560000000000001,
 			},
 			TeamMembers: []int64{954488202459119617},
 			Expected:   nil,
 			StatusCode: 200,
 		},
+		{
+			TestCaseName: "Team Member is Not Member of Organization",
+			TeamDetails: request.Team{
+				Name:        "Team Ladder",
+				Privacy: func() *string { team_privacy := string("PUBLIC"); return &team_privacy }(),
+				CreatedBy:   954488202459119617,
+				CreatedAt:   time.Now(),
+				OrganizationID: 954560000000000001,
+			},
+			TeamMembers: []int64{954488202459119617, 954497896847212548},
+			Expected:   errorhandling.NotMemberOfOrganization,
+			StatusCode: 400,
+		},
+		{
+			TestCaseName: "Team Member is Not Discoverable",
+			TeamDetails: request.Team{
+				Name:        "Team Rope",
+				Privacy: func() *string { team_privacy := string("PUBLIC"); return &team_privacy }(),
+				CreatedBy:   954488202459119617,
+				CreatedAt:   time.Now(),
+				OrganizationID: 954560000000000001,
+			},
+			TeamMembers: []int64{954488202459119617, 954497896847212546},
+			Expected:   errorhandling.OnlyPublicMemberAllowed,
+			StatusCode: 400,
+		},
 	}
 
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
 
-			_, err := NewTeamRepo(dbConn, redisClient).CreateTeam(v.TeamDetails, v.TeamMembers)
+			_, err := NewTeamRepo(dbConn, redisClient, socketServer).CreateTeam(v.TeamDetails, v.TeamMembers)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
@@ -78,12 +105,32 @@ func TestAddMembersToTeam(t *testing.T) {
 			Expected:   errorhandling.NotAllowed,
 			StatusCode: 401,
 		},
+		{
+			TestCaseName:  "Team Member is Not Member of Organization",
+			TeamCreatedBy: 954488202459119617,
+			TeamMembers: request.TeamMembersWithTeamID{
+				TeamID:   954507580144451585,
+				MemberIDs: []int64{954497896847212548},
+			},
+			Expected:   errorhandling.NotMemberOfOrganization,
+			StatusCode: 400,
+		},
+		{
+			TestCaseName:  "Team of Other Organization",
+			TeamCreatedBy: 954497896847212548,
+			TeamMembers: request.TeamMembersWithTeamID{
+				TeamID:   954507580144451588,
+				MemberIDs: []int64{954497896847212545},
+			},
+			Expected:   errorhandling.NoTeamFound,
+			StatusCode: 404,
+		},
 	}
 
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
 
-			err := NewTeamRepo(dbConn, redisClient).AddMembersToTeam(v.TeamCreatedBy, v.TeamMembers)
+			err := NewTeamRepo(dbConn, redisClient, socketServer).AddMembersToTeam(954560000000000001, v.TeamCreatedBy, v.TeamMembers)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
@@ -122,7 +169,7 @@ func TestRemoveMembersFromTeam(t *testing.T) {
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
 
-			err := NewTeamRepo(dbConn, redisClient).RemoveMembersFromTeam(v.TeamCreatedBy, v.TeamMembers)
+			err := NewTeamRepo(dbConn, redisClient, socketServer).RemoveMembersFromTeam(954560000000000001, v.TeamCreatedBy, v.TeamMembers)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
@@ -165,7 +212,7 @@ func TestGetAllTeams(t *testing.T) {
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
 
-			_, err := NewTeamRepo(dbConn, redisClient).GetAllTeams(v.UserId, v.QueryParams)
+			_, err := NewTeamRepo(dbConn, redisClient, socketServer).GetAllTeams(954560000000000001, v.UserId, v.QueryParams)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
@@ -174,6 +221,7 @@ func TestGetAllTeams(t *testing.T) {
 func TestGetTeamMembers(t *testing.T) {
 	testCases := []struct {
 		TestCaseName string
+		UserID       int64
 		TeamID       int64
 		QueryParams  request.TeamQueryParams
 		Expected     interface{}
@@ -181,6 +229,7 @@ func TestGetTeamMembers(t *testing.T) {
 	}{
 		{
 			TestCaseName: "Team Created By Me - Success",
+			UserID:       954488202459119617,
 			TeamID:       954507580144451585,
 			QueryParams: request.TeamQueryParams{
 				Limit:  1,
@@ -195,7 +244,7 @@ func TestGetTeamMembers(t *testing.T) {
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
 
-			_, err := NewTeamRepo(dbConn, redisClient).GetTeamMembers(v.TeamID, v.QueryParams)
+			_, err := NewTeamRepo(dbConn, redisClient, socketServer).GetTeamMembers(954560000000000001, v.UserID, v.TeamID, v.QueryParams)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
@@ -221,8 +270,269 @@ func TestLeftTeam(t *testing.T) {
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
 
-			err := NewTeamRepo(dbConn, redisClient).LeaveTeam(v.UserID, v.TeamID)
+			err := NewTeamRepo(dbConn, redisClient, socketServer).LeaveTeam(954560000000000001, v.UserID, v.TeamID)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestGetTeamActivity(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		TeamID       int64
+		QueryParams  request.TeamActivityQueryParams
+		Expected     interface{}
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "Team Activity Fetched Successfully",
+			UserID:       954488202459119617,
+			TeamID:       954507580144451586,
+			QueryParams: request.TeamActivityQueryParams{
+				Limit:  10,
+				Offset: 0,
+				Type:   "MEMBER-ADDED",
+			},
+			Expected:   nil,
+			StatusCode: 200,
+		},
+		{
+			TestCaseName: "Not a Member of Team",
+			UserID:       954497896847212546,
+			TeamID:       954507580144451586,
+			QueryParams: request.TeamActivityQueryParams{
+				Limit:  10,
+				Offset: 0,
+			},
+			Expected:   errorhandling.NotAllowed,
+			StatusCode: 403,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+
+			_, err := NewTeamRepo(dbConn, redisClient, socketServer).GetTeamActivity(954560000000000001, v.UserID, v.TeamID, v.QueryParams)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestCreateQueryForParamsOfGetTeamActivity(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		QueryParams  request.TeamActivityQueryParams
+		Expected     interface{}
+		ExpectedArgs []interface{}
+	}{
+		{
+			TestCaseName: "Query Based on Query Params Created.",
+			QueryParams: request.TeamActivityQueryParams{
+				Limit:   10,
+				Offset:  20,
+				Type:    "TASK-CREATED",
+				ActorID: 954488202459119617,
+			},
+			Expected:     ` AND activity_type = $2 AND actor_id = $3 ORDER BY created_at DESC, id DESC LIMIT 10 OFFSET 20`,
+			ExpectedArgs: []interface{}{int64(954507580144451585), "TASK-CREATED", int64(954488202459119617)},
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			query, args := CreateQueryForParamsOfGetTeamActivity("", []interface{}{int64(954507580144451585)}, v.QueryParams)
+			assert.Equal(t, v.Expected, query)
+			assert.Equal(t, v.ExpectedArgs, args)
+		})
+	}
+}
+
+func TestUpdateTeamAutoAssignStrategy(t *testing.T) {
+	testCases := []struct {
+		TestCaseName       string
+		TeamCreatedBy      int64
+		AutoAssignStrategy request.TeamAutoAssignStrategy
+		Expected           interface{}
+		StatusCode         int
+	}{
+		{
+			TestCaseName:  "Auto Assign Strategy Updated Successfully",
+			TeamCreatedBy: 954488202459119617,
+			AutoAssignStrategy: request.TeamAutoAssignStrategy{
+				TeamID:   954507580144451585,
+				Strategy: "ROUND-ROBIN",
+			},
+			Expected:   nil,
+			StatusCode: 200,
+		},
+		{
+			TestCaseName:  "Not Allowed to Update Auto Assign Strategy",
+			TeamCreatedBy: 954497896847212545,
+			AutoAssignStrategy: request.TeamAutoAssignStrategy{
+				TeamID:   954507580144451585,
+				Strategy: "LEAST-LOADED",
+			},
+			Expected:   errorhandling.NotAllowed,
+			StatusCode: 403,
+		},
+		{
+			TestCaseName:  "No Team Found",
+			TeamCreatedBy: 954488202459119617,
+			AutoAssignStrategy: request.TeamAutoAssignStrategy{
+				TeamID:   1,
+				Strategy: "LEAST-LOADED",
+			},
+			Expected:   errorhandling.NoTeamFound,
+			StatusCode: 404,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+
+			err := NewTeamRepo(dbConn, redisClient, socketServer).UpdateTeamAutoAssignStrategy(954560000000000001, v.TeamCreatedBy, v.AutoAssignStrategy)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestUpdateTeamMemberAvailability(t *testing.T) {
+	testCases := []struct {
+		TestCaseName       string
+		UserID             int64
+		MemberAvailability request.TeamMemberAvailability
+		Expected           interface{}
+		StatusCode         int
+	}{
+		{
+			TestCaseName: "Availability Updated Successfully",
+			UserID:       954488202459119617,
+			MemberAvailability: request.TeamMemberAvailability{
+				TeamID:           954507580144451586,
+				UnavailableUntil: func() *time.Time { until := time.Now().Add(2 * 24 * time.Hour); return &until }(),
+			},
+			Expected:   nil,
+			StatusCode: 200,
+		},
+		{
+			TestCaseName: "Not a Member of Team",
+			UserID:       954497896847212546,
+			MemberAvailability: request.TeamMemberAvailability{
+				TeamID: 954507580144451586,
+			},
+			Expected:   errorhandling.NotMemberOfTeam,
+			StatusCode: 400,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+
+			err := NewTeamRepo(dbConn, redisClient, socketServer).UpdateTeamMemberAvailability(954560000000000001, v.UserID, v.MemberAvailability)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestGetTeamAnalytics(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		IsAdmin      bool
+		TeamID       int64
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Analytics Fetched by Owner of Team.",
+			UserID:       954488202459119617,
+			TeamID:       954507580144451585,
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "Analytics Fetched by Admin.",
+			UserID:       954497896847212545,
+			IsAdmin:      true,
+			TeamID:       954507580144451585,
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "Member of Team is Not Allowed.",
+			UserID:       954497896847212547,
+			TeamID:       954507580144451585,
+			Expected:     errorhandling.TeamAnalyticsNotAllowed,
+		},
+		{
+			TestCaseName: "Team of Other Organization.",
+			UserID:       954488202459119617,
+			IsAdmin:      true,
+			TeamID:       954507580144451588,
+			Expected:     errorhandling.NoTeamFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			analytics, err := NewTeamRepo(dbConn, redisClient, socketServer).GetTeamAnalytics(954560000000000001, v.UserID, v.IsAdmin, v.TeamID, request.TeamAnalyticsQueryParams{})
+			assert.Equal(t, v.Expected, err)
+			if err == nil {
+				assert.NotEmpty(t, analytics.Throughput)
+				assert.NotEmpty(t, analytics.ByPriority)
+
+				// both distributions cover the same tasks.
+				var priorityTasks, assigneeTasks int
+				for _, priority := range analytics.ByPriority {
+					priorityTasks += priority.TotalTasks
+				}
+				for _, assignee := range analytics.ByAssignee {
+					assigneeTasks += assignee.TotalTasks
+				}
+				assert.Equal(t, priorityTasks, assigneeTasks)
+			}
+		})
+	}
+}
+
+func TestGetAnalyticsRange(t *testing.T) {
+	now := time.Date(2024, 3, 25, 15, 30, 0, 0, time.UTC)
+	testCases := []struct {
+		TestCaseName string
+		QueryParams  request.TeamAnalyticsQueryParams
+		From         time.Time
+		To           time.Time
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Default Range.",
+			From:         time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			To:           time.Date(2024, 3, 26, 0, 0, 0, 0, time.UTC),
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "Given Range.",
+			QueryParams:  request.TeamAnalyticsQueryParams{From: "2024-02-01", To: "2024-02-29"},
+			From:         time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			To:           time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "From Date After To Date.",
+			QueryParams:  request.TeamAnalyticsQueryParams{From: "2024-03-01", To: "2024-02-29"},
+			Expected:     errorhandling.InvalidAnalyticsRange,
+		},
+		{
+			TestCaseName: "Range Longer Than Limit.",
+			QueryParams:  request.TeamAnalyticsQueryParams{From: "2022-01-01", To: "2024-02-29"},
+			Expected:     errorhandling.InvalidAnalyticsRange,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			from, to, err := GetAnalyticsRange(v.QueryParams, now)
 			assert.Equal(t, v.Expected, err)
+			assert.Equal(t, v.From, from)
+			assert.Equal(t, v.To, to)
 		})
 	}
 }
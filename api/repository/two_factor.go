@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+// VerifyTwoFactorCode verifies code of authenticator app or one of the unused recovery codes of the user within given transaction.
+// matched totp time step is stored so that code can't be replayed and matched recovery code is marked as used.
+func VerifyTwoFactorCode(ctx context.Context, tx pgx.Tx, userID int64, code string) error {
+	var secret string
+	var lastUsedStep int64
+	rows := tx.QueryRow(ctx, `SELECT secret, last_used_step FROM user_two_factor WHERE user_id = $1 AND enabled = true FOR UPDATE`, userID)
+	err := rows.Scan(&secret, &lastUsedStep)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return errorhandling.TwoFactorNotEnabled
+		}
+		return err
+	}
+
+	if len(code) == constant.TOTP_DIGITS {
+		timeStep, valid := utils.VerifyTOTPCode(secret, code, time.Now(), lastUsedStep)
+		if !valid {
+			return errorhandling.InvalidTwoFactorCode
+		}
+		_, err = tx.Exec(ctx, `UPDATE user_two_factor SET last_used_step = $1 WHERE user_id = $2`, timeStep, userID)
+		return err
+	}
+
+	result, err := tx.Exec(ctx, `UPDATE two_factor_recovery_codes SET used_at = $1 WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL`,
+		time.Now(), userID, utils.HashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errorhandling.InvalidTwoFactorCode
+	}
+	return nil
+}
+
+// InsertRecoveryCodes replaces recovery codes of the user with hashes of given codes within given transaction.
+func InsertRecoveryCodes(ctx context.Context, tx pgx.Tx, userID int64, recoveryCodes []string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM two_factor_recovery_codes WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	batch := &pgx.Batch{}
+	for _, recoveryCode := range recoveryCodes {
+		batch.Queue(`INSERT INTO two_factor_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, utils.HashRecoveryCode(recoveryCode))
+	}
+	return tx.SendBatch(ctx, batch).Close()
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/dto"
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultUserPreferences returns preferences of the user who has not saved any, dates are shown in UTC
+// and only security alerts are sent by email while task notifications are sent over socket.
+func DefaultUserPreferences() response.UserPreferences {
+	return response.UserPreferences{
+		Timezone:   constant.DEFAULT_TIMEZONE,
+		Locale:     constant.DEFAULT_LOCALE,
+		DateFormat: constant.DATE_FORMAT_ISO,
+		WeekStart:  constant.WEEK_START_MONDAY,
+		Notifications: response.NotificationPreferences{
+			TaskAssigned:  constant.NOTIFICATION_CHANNEL_SOCKET,
+			TaskUpdated:   constant.NOTIFICATION_CHANNEL_SOCKET,
+			SecurityAlert: constant.NOTIFICATION_CHANNEL_EMAIL,
+		},
+	}
+}
+
+// LoadNotificationRecipient returns email and preferences of the user, default preferences are returned if user has not saved any.
+func LoadNotificationRecipient(ctx context.Context, dbConn *pgx.Conn, userID int64) (dto.NotificationRecipient, error) {
+	recipient := dto.NotificationRecipient{UserID: userID, Preferences: DefaultUserPreferences()}
+	var timezone, locale, dateFormat, weekStart, taskAssigned, taskUpdated, securityAlert *string
+	rows := dbConn.QueryRow(ctx, `SELECT u.email, p.timezone, p.locale, p.date_format, p.week_start, p.notify_task_assigned, p.notify_task_updated, p.notify_security_alert
+	FROM users as u LEFT JOIN user_preferences as p ON p.user_id = u.id WHERE u.id = $1`, userID)
+	err := rows.Scan(&recipient.Email, &timezone, &locale, &dateFormat, &weekStart, &taskAssigned, &taskUpdated, &securityAlert)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return recipient, errorhandling.NoUserFound
+		}
+		return recipient, err
+	}
+
+	// all columns are either null or set together, as row of preferences is saved as whole.
+	if timezone != nil {
+		recipient.Preferences = response.UserPreferences{
+			Timezone:   *timezone,
+			Locale:     *locale,
+			DateFormat: *dateFormat,
+			WeekStart:  *weekStart,
+			Notifications: response.NotificationPreferences{
+				TaskAssigned:  *taskAssigned,
+				TaskUpdated:   *taskUpdated,
+				SecurityAlert: *securityAlert,
+			},
+		}
+	}
+	return recipient, nil
+}
+
+// MergeUserPreferences returns current preferences with fields given in update replacing them.
+func MergeUserPreferences(current response.UserPreferences, preferencesToUpdate request.UserPreferences) response.UserPreferences {
+	for _, field := range []struct {
+		target *string
+		value  string
+	}{
+		{&current.Timezone, preferencesToUpdate.Timezone},
+		{&current.Locale, preferencesToUpdate.Locale},
+		{&current.DateFormat, preferencesToUpdate.DateFormat},
+		{&current.WeekStart, preferencesToUpdate.WeekStart},
+		{&current.Notifications.TaskAssigned, preferencesToUpdate.Notifications.TaskAssigned},
+		{&current.Notifications.TaskUpdated, preferencesToUpdate.Notifications.TaskUpdated},
+		{&current.Notifications.SecurityAlert, preferencesToUpdate.Notifications.SecurityAlert},
+	} {
+		if field.value != constant.EMPTY_STRING {
+			*field.target = field.value
+		}
+	}
+	return current
+}
+
+func (u userRepository) GetUserPreferences(userId int64) (response.UserPreferences, error) {
+	recipient, err := LoadNotificationRecipient(context.Background(), u.dbConn, userId)
+	if err != nil {
+		return response.UserPreferences{}, err
+	}
+	return recipient.Preferences, nil
+}
+
+func (u userRepository) UpdateUserPreferences(userId int64, preferencesToUpdate request.UserPreferences) (response.UserPreferences, error) {
+	ctx := context.Background()
+	recipient, err := LoadNotificationRecipient(ctx, u.dbConn, userId)
+	if err != nil {
+		return response.UserPreferences{}, err
+	}
+	preferences := MergeUserPreferences(recipient.Preferences, preferencesToUpdate)
+
+	_, err = u.dbConn.Exec(ctx, `INSERT INTO user_preferences (user_id, timezone, locale, date_format, week_start, notify_task_assigned, notify_task_updated,
+	notify_security_alert, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (user_id) DO UPDATE SET timezone = excluded.timezone,
+	locale = excluded.locale, date_format = excluded.date_format, week_start = excluded.week_start, notify_task_assigned = excluded.notify_task_assigned,
+	notify_task_updated = excluded.notify_task_updated, notify_security_alert = excluded.notify_security_alert, updated_at = excluded.updated_at`,
+		userId, preferences.Timezone, preferences.Locale, preferences.DateFormat, preferences.WeekStart, preferences.Notifications.TaskAssigned,
+		preferences.Notifications.TaskUpdated, preferences.Notifications.SecurityAlert, time.Now())
+	if err != nil {
+		return response.UserPreferences{}, err
+	}
+	return preferences, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/jackc/pgx/v5"
+)
+
+// TeammateOfViewer returns sql condition which is true if user of row of users table (aliased as u) shares at least one team with the user given
+// as first argument of the query, only teams of the organization given by organizationParam placeholder are considered.
+func TeammateOfViewer(organizationParam string) string {
+	return `EXISTS (SELECT 1 FROM team_members as tm JOIN team_members as vm ON vm.team_id = tm.team_id JOIN teams as tt ON tt.id = tm.team_id
+	WHERE tm.member_id = u.id AND vm.member_id = $1 AND tt.organization_id = ` + organizationParam + `)`
+}
+
+// MemberOfOrganization returns sql condition which is true if user of row of users table (aliased as u) is member of the organization given by organizationParam placeholder.
+func MemberOfOrganization(organizationParam string) string {
+	return `EXISTS (SELECT 1 FROM organization_members as om WHERE om.member_id = u.id AND om.organization_id = ` + organizationParam + `)`
+}
+
+// DefaultPrivacySettings returns privacy settings of new user, settings which are not given by user are set to everyone and user is discoverable.
+func DefaultPrivacySettings(privacy request.PrivacySettings) request.PrivacySettings {
+	for _, visibility := range []*string{&privacy.EmailVisibility, &privacy.BioVisibility, &privacy.TeamsVisibility, &privacy.AssignableBy} {
+		if *visibility == constant.EMPTY_STRING {
+			*visibility = constant.VISIBILITY_EVERYONE
+		}
+	}
+	if privacy.Discoverable == nil {
+		discoverable := true
+		privacy.Discoverable = &discoverable
+	}
+	return privacy
+}
+
+// IsVisibleTo reports whether something having given visibility can be seen by the viewer, user can always see their own details.
+func IsVisibleTo(visibility string, isSelf bool, isTeammate bool) bool {
+	return isSelf || visibility == constant.VISIBILITY_EVERYONE || (visibility == constant.VISIBILITY_TEAMMATES && isTeammate)
+}
+
+// HideInvisibleFields clears email and bio of the user which are not visible to the viewer.
+func HideInvisibleFields(user *response.User, viewerId int64, isTeammate bool) {
+	isSelf := user.ID == viewerId
+	if !IsVisibleTo(user.Privacy.EmailVisibility, isSelf, isTeammate) {
+		user.Email = constant.EMPTY_STRING
+	}
+	if !IsVisibleTo(user.Privacy.BioVisibility, isSelf, isTeammate) {
+		user.Bio = constant.EMPTY_STRING
+	}
+}
+
+// CheckTaskAssignee returns error if assignee is not member of the organization, if assigner is not allowed to assign task to the assignee
+// as per privacy settings of the assignee, or if assignee has not verified email while verified assignees are required.
+// users can always assign tasks to themselves.
+func CheckTaskAssignee(ctx context.Context, dbConn *pgx.Conn, organizationId int64, assignerId int64, assigneeId int64) error {
+	var assignableBy string
+	var emailVerified, isTeammate bool
+	rows := dbConn.QueryRow(ctx, `SELECT u.assignable_by, u.email_verified, `+TeammateOfViewer("$3")+` FROM users as u WHERE u.id = $2 AND `+MemberOfOrganization("$3"),
+		assignerId, assigneeId, organizationId)
+	err := rows.Scan(&assignableBy, &emailVerified, &isTeammate)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return errorhandling.NotMemberOfOrganization
+		}
+		return err
+	}
+	if !IsVisibleTo(assignableBy, assignerId == assigneeId, isTeammate) {
+		return errorhandling.OnlyPublicUserAssignne
+	}
+	if config.Config.EmailVerification.RequiredForTasks && !emailVerified {
+		return errorhandling.OnlyVerifiedUserAssignee
+	}
+	return nil
+}
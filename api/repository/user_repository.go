@@ -3,45 +3,67 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/chirag1807/task-management-system/api/model/dto"
 	"github.com/chirag1807/task-management-system/api/model/request"
 	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/config"
 	"github.com/chirag1807/task-management-system/constant"
 	errorhandling "github.com/chirag1807/task-management-system/error"
 	"github.com/chirag1807/task-management-system/utils"
+	"github.com/chirag1807/task-management-system/utils/storage"
+	"github.com/go-redis/redis/v8"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 type UserRepository interface {
-	GetAllPublicPrivacyUsers(queryParams request.UserQueryParams) ([]response.User, error)
+	GetAllPublicPrivacyUsers(organizationId int64, userId int64, queryParams request.UserQueryParams) ([]response.User, error)
 	GetMyDetails(userId int64) (response.User, error)
 	UpdateUserProfile(userId int64, userToUpdate request.UpdateUser) error
-	SendOTPToUser(userEmail dto.Email, OTP int, OTPExpireTime time.Time) (int64, error)
-	VerifyOTP(otpFromUser request.OTP) error
-	ResetUserPassword(userPasswordWithOTPId request.UserPasswordWithOTPID) error
+	SendOTPToUser(userEmail string) error
+	VerifyOTP(otpFromUser request.OTP) (string, error)
+	ResetUserPassword(resetPassword request.ResetPassword) error
+	DeleteExpiredOTPs() (int64, error)
+	ConfirmEmailChange(userId int64, otp string) error
+	ResendVerificationEmail(userEmail string) error
+	VerifyEmail(otpFromUser request.OTP) error
 	VerifyUserPassword(userPassword string, userId int64) error
+	EnrollTwoFactor(userId int64) (string, string, error)
+	ConfirmTwoFactor(userId int64, code string) ([]string, error)
+	DisableTwoFactor(userId int64) error
+	UpdateUserAvatar(userId int64, thumbnails map[int][]byte) (response.Avatar, error)
+	ExportUserData(userId int64) (response.UserDataExport, error)
+	ScheduleAccountDeletion(userId int64) error
+	DeleteScheduledAccounts() (int64, error)
+	GetUserPreferences(userId int64) (response.UserPreferences, error)
+	UpdateUserPreferences(userId int64, preferencesToUpdate request.UserPreferences) (response.UserPreferences, error)
 }
 
 type userRepository struct {
 	dbConn       *pgx.Conn
+	redisClient  *redis.Client
 	rabbitmqConn *amqp.Connection
 }
 
-func NewUserRepo(dbConn *pgx.Conn, rabbitmqConn *amqp.Connection) UserRepository {
+func NewUserRepo(dbConn *pgx.Conn, redisClient *redis.Client, rabbitmqConn *amqp.Connection) UserRepository {
 	return userRepository{
 		dbConn:       dbConn,
+		redisClient:  redisClient,
 		rabbitmqConn: rabbitmqConn,
 	}
 }
 
-func (u userRepository) GetAllPublicPrivacyUsers(queryParams request.UserQueryParams) ([]response.User, error) {
-	query := `SELECT * FROM users WHERE privacy = $1`
+// GetAllPublicPrivacyUsers returns members of the organization who are discoverable in search, as seen by given user.
+// email and bio are left out as per privacy settings of each user, and bio is searched only if it is visible to the viewer.
+func (u userRepository) GetAllPublicPrivacyUsers(organizationId int64, userId int64, queryParams request.UserQueryParams) ([]response.User, error) {
+	query := `SELECT u.id, u.first_name, u.last_name, u.bio, u.email, u.email_visibility, u.bio_visibility, u.teams_visibility, u.discoverable, u.assignable_by,
+	u.email_verified, u.avatar_key, ` + TeammateOfViewer("$2") + ` FROM users as u WHERE u.discoverable = true AND ` + MemberOfOrganization("$2")
 	query = CreateQueryForParamsOfGetUser(query, queryParams)
-	publicUsers, err := u.dbConn.Query(context.Background(), query, "PUBLIC")
+	publicUsers, err := u.dbConn.Query(context.Background(), query, userId, organizationId)
 	publicUsersSlice := make([]response.User, 0)
 	if err != nil {
 		return publicUsersSlice, err
@@ -50,18 +72,25 @@ func (u userRepository) GetAllPublicPrivacyUsers(queryParams request.UserQueryPa
 
 	var publicUser response.User
 	for publicUsers.Next() {
-		if err := publicUsers.Scan(&publicUser.ID, &publicUser.FirstName, &publicUser.LastName, &publicUser.Bio, &publicUser.Email, &publicUser.Password, &publicUser.Privacy); err != nil {
+		var avatarKey *string
+		var isTeammate bool
+		if err := publicUsers.Scan(&publicUser.ID, &publicUser.FirstName, &publicUser.LastName, &publicUser.Bio, &publicUser.Email, &publicUser.Privacy.EmailVisibility,
+			&publicUser.Privacy.BioVisibility, &publicUser.Privacy.TeamsVisibility, &publicUser.Privacy.Discoverable, &publicUser.Privacy.AssignableBy,
+			&publicUser.EmailVerified, &avatarKey, &isTeammate); err != nil {
 			return publicUsersSlice, err
 		}
+		publicUser.Avatar = utils.AvatarOf(avatarKey)
+		HideInvisibleFields(&publicUser, userId, isTeammate)
 		publicUsersSlice = append(publicUsersSlice, publicUser)
 	}
 	return publicUsersSlice, nil
 }
 
+// CreateQueryForParamsOfGetUser appends search and pagination to query having viewer as first and organization as second argument.
 func CreateQueryForParamsOfGetUser(query string, queryParams request.UserQueryParams) string {
 	if queryParams.Search != constant.EMPTY_STRING {
-		query += fmt.Sprintf(" AND (first_name ILIKE '%%%s%%' OR last_name ILIKE '%%%s%%' OR bio ILIKE '%%%s%%')",
-			queryParams.Search, queryParams.Search, queryParams.Search)
+		query += fmt.Sprintf(" AND (u.first_name ILIKE '%%%s%%' OR u.last_name ILIKE '%%%s%%' OR (u.bio ILIKE '%%%s%%' AND (u.id = $1 OR u.bio_visibility = 'EVERYONE' OR (u.bio_visibility = 'TEAMMATES' AND %s))))",
+			queryParams.Search, queryParams.Search, queryParams.Search, TeammateOfViewer("$2"))
 	}
 	query += fmt.Sprintf(" LIMIT %d", queryParams.Limit)
 	query += fmt.Sprintf(" OFFSET %d", queryParams.Offset)
@@ -70,8 +99,9 @@ func CreateQueryForParamsOfGetUser(query string, queryParams request.UserQueryPa
 
 func (u userRepository) GetMyDetails(userId int64) (response.User, error) {
 	var userDetails response.User
-	user := u.dbConn.QueryRow(context.Background(), `SELECT * FROM users WHERE id = $1`, userId)
-	err := user.Scan(&userDetails.ID, &userDetails.FirstName, &userDetails.LastName, &userDetails.Bio, &userDetails.Email, &userDetails.Password, &userDetails.Privacy)
+	var avatarKey *string
+	user := u.dbConn.QueryRow(context.Background(), `SELECT id, first_name, last_name, bio, email, COALESCE(password, ''), email_visibility, bio_visibility, teams_visibility, discoverable, assignable_by, email_verified, avatar_key FROM users WHERE id = $1`, userId)
+	err := user.Scan(&userDetails.ID, &userDetails.FirstName, &userDetails.LastName, &userDetails.Bio, &userDetails.Email, &userDetails.Password, &userDetails.Privacy.EmailVisibility, &userDetails.Privacy.BioVisibility, &userDetails.Privacy.TeamsVisibility, &userDetails.Privacy.Discoverable, &userDetails.Privacy.AssignableBy, &userDetails.EmailVerified, &avatarKey)
 
 	if err != nil {
 		if err.Error() == constant.PG_NO_ROWS {
@@ -79,129 +109,420 @@ func (u userRepository) GetMyDetails(userId int64) (response.User, error) {
 		}
 		return userDetails, err
 	}
+	userDetails.Avatar = utils.AvatarOf(avatarKey)
 	return userDetails, nil
 }
 
 func (u userRepository) UpdateUserProfile(userId int64, userToUpdate request.UpdateUser) error {
-	if userToUpdate.Privacy == "PRIVATE" {
-		var userCount int
-		rows := u.dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM team_members where member_id = $1`, userId)
-		err := rows.Scan(&userCount)
+	// email is switched only after new address is verified by ConfirmEmailChange, till then it is kept as pending email.
+	newEmail := userToUpdate.Email
+	userToUpdate.Email = constant.EMPTY_STRING
+	// new password is stored in password column as hash, current password is already verified by controller.
+	newPassword := userToUpdate.NewPassword
+	userToUpdate.Password = constant.EMPTY_STRING
+	userToUpdate.NewPassword = constant.EMPTY_STRING
+	if newPassword != constant.EMPTY_STRING || userToUpdate != (request.UpdateUser{}) {
+		ctx := context.Background()
+		tx, err := u.dbConn.Begin(ctx)
 		if err != nil {
 			return err
 		}
-		if userCount > 0 {
-			return errorhandling.LeftAllTeamsToMakePrivacyPrivate
+		if newPassword != constant.EMPTY_STRING {
+			err = CheckPasswordReuse(ctx, tx, userId, newPassword, "newPassword")
+			if err != nil {
+				tx.Rollback(ctx)
+				return err
+			}
+			userToUpdate.Password, err = utils.HashPassword(newPassword)
+			if err != nil {
+				tx.Rollback(ctx)
+				return err
+			}
+			err = SavePasswordHistory(ctx, tx, userId, userToUpdate.Password)
+			if err != nil {
+				tx.Rollback(ctx)
+				return err
+			}
+		}
+		query, args, err := UpdateQuery("users", userToUpdate, userId, 0)
+		if err != nil {
+			tx.Rollback(ctx)
+			return err
+		}
+		_, err = tx.Exec(ctx, query, args...)
+		if err != nil {
+			tx.Rollback(ctx)
+			return err
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	if newEmail != constant.EMPTY_STRING {
+		return u.requestEmailChange(userId, newEmail)
+	}
+	return nil
+}
+
+// requestEmailChange stores new email of the user as pending email and sends otp to it, email is not changed if it is same as current one.
+func (u userRepository) requestEmailChange(userId int64, newEmail string) error {
+	ctx := context.Background()
+	var currentEmail string
+	var userCount int
+	rows := u.dbConn.QueryRow(ctx, `SELECT email, (SELECT COUNT(*) FROM users WHERE email = $1) FROM users WHERE id = $2`, newEmail, userId)
+	err := rows.Scan(&currentEmail, &userCount)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return errorhandling.NoUserFound
+		}
+		return err
+	}
+	if currentEmail == newEmail {
+		return nil
+	}
+	if userCount > 0 {
+		return errorhandling.DuplicateEmailFound
+	}
+
+	err = u.checkOTPSendLimit(ctx, newEmail)
+	if err != nil {
+		return err
+	}
+	tx, err := u.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `UPDATE users SET pending_email = $1 WHERE id = $2`, newEmail, userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = SendOTPEmail(ctx, tx, u.rabbitmqConn, newEmail, constant.OTP_PURPOSE_EMAIL_CHANGE, "Email Change Verification")
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return nil
+}
+
+// ConfirmEmailChange consumes email change otp sent to pending email of the user and makes it email of the user.
+func (u userRepository) ConfirmEmailChange(userId int64, otp string) error {
+	ctx := context.Background()
+	tx, err := u.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	var pendingEmail *string
+	rows := tx.QueryRow(ctx, `SELECT pending_email FROM users WHERE id = $1 FOR UPDATE`, userId)
+	err = rows.Scan(&pendingEmail)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err.Error() == constant.PG_NO_ROWS {
+			return errorhandling.NoUserFound
 		}
+		return err
 	}
-	query, args, err := UpdateQuery("users", userToUpdate, userId, 0)
+	if pendingEmail == nil {
+		tx.Rollback(ctx)
+		return errorhandling.NoEmailChangePending
+	}
+
+	otpID, err := ConsumeOTP(ctx, tx, *pendingEmail, constant.OTP_PURPOSE_EMAIL_CHANGE, otp)
 	if err != nil {
+		tx.Rollback(ctx)
+		if err == errorhandling.OTPNotMatched {
+			return u.recordWrongOTP(otpID, *pendingEmail)
+		}
 		return err
 	}
-	_, err = u.dbConn.Exec(context.Background(), query, args...)
+	_, err = tx.Exec(ctx, `UPDATE users SET email = pending_email, pending_email = NULL, email_verified = true WHERE id = $1`, userId)
 	if err != nil {
+		tx.Rollback(ctx)
 		pgErr, ok := err.(*pgconn.PgError)
 		if ok && pgErr.Code == constant.PG_Duplicate_Error_Code {
 			return errorhandling.DuplicateEmailFound
 		}
 		return err
 	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
 	return nil
 }
 
-func (u userRepository) SendOTPToUser(userEmail dto.Email, OTP int, OTPExpireTime time.Time) (int64, error) {
+// ResendVerificationEmail sends new email verification otp to given email, it returns nil without sending anything if no unverified user
+// is registered with the email, so that response doesn't tell whether account exists or not.
+func (u userRepository) ResendVerificationEmail(userEmail string) error {
 	ctx := context.Background()
-	var databaseOTPId int64
+	err := u.checkOTPSendLimit(ctx, userEmail)
+	if err != nil {
+		return err
+	}
+
 	var userCount int
-	rows := u.dbConn.QueryRow(ctx, `SELECT COUNT(*) FROM users where email = $1`, userEmail.To)
-	err := rows.Scan(&userCount)
+	rows := u.dbConn.QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE email = $1 AND email_verified = false`, userEmail)
+	err = rows.Scan(&userCount)
 	if err != nil {
-		return 0, err
+		return err
 	}
 	if userCount == 0 {
-		return 0, errorhandling.NoEmailFound
+		return nil
 	}
+	return u.sendOTPEmail(ctx, userEmail, constant.OTP_PURPOSE_EMAIL_VERIFICATION, "Email Verification")
+}
+
+// VerifyEmail consumes email verification otp of the user and marks email of the user as verified.
+func (u userRepository) VerifyEmail(otpFromUser request.OTP) error {
+	ctx := context.Background()
 	tx, err := u.dbConn.Begin(ctx)
 	if err != nil {
-		return 0, err
+		return err
 	}
-	rows = tx.QueryRow(ctx, `INSERT INTO otps (otp, otp_expire_time, email) VALUES ($1, $2, $3) RETURNING id`, OTP, OTPExpireTime, userEmail.To)
-	err = rows.Scan(&databaseOTPId)
+	otpID, err := ConsumeOTP(ctx, tx, otpFromUser.Email, constant.OTP_PURPOSE_EMAIL_VERIFICATION, otpFromUser.OTP)
 	if err != nil {
 		tx.Rollback(ctx)
-		return 0, err
+		if err == errorhandling.OTPNotMatched {
+			return u.recordWrongOTP(otpID, otpFromUser.Email)
+		}
+		return err
 	}
-	err = utils.ProduceEmail(u.rabbitmqConn, userEmail)
+	_, err = tx.Exec(ctx, `UPDATE users SET email_verified = true WHERE email = $1`, otpFromUser.Email)
 	if err != nil {
 		tx.Rollback(ctx)
-		return 0, err
+		return err
 	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		tx.Rollback(ctx)
-		return 0, err
+		return err
 	}
-	return databaseOTPId, nil
+	return nil
 }
 
-func (u userRepository) VerifyOTP(otpFromUser request.OTP) error {
-	var dbOTP response.OTP
-	rows, err := u.dbConn.Query(context.Background(), `SELECT * FROM otps where id = $1`, otpFromUser.ID)
+// SendOTPToUser sends password reset otp to given email, if no user is registered with the email otp is stored same way but never sent,
+// so that neither response nor its timing tells whether account exists or not. rate limits are applied to every email for the same reason.
+func (u userRepository) SendOTPToUser(userEmail string) error {
+	ctx := context.Background()
+	err := u.checkOTPSendLimit(ctx, userEmail)
 	if err != nil {
 		return err
 	}
-	defer rows.Close()
-	if rows.Next() {
-		if err := rows.Scan(&dbOTP.ID, &dbOTP.OTP, &dbOTP.OTPExpiryTime, &dbOTP.Email, &dbOTP.IsVerified); err != nil {
+
+	var userCount int
+	rows := u.dbConn.QueryRow(ctx, `SELECT COUNT(*) FROM users where email = $1`, userEmail)
+	err = rows.Scan(&userCount)
+	if err != nil {
+		return err
+	}
+	if userCount == 0 {
+		return u.storeUnsentOTP(ctx, userEmail, constant.OTP_PURPOSE_PASSWORD_RESET)
+	}
+	return u.sendOTPEmail(ctx, userEmail, constant.OTP_PURPOSE_PASSWORD_RESET, "OTP Verification")
+}
+
+// storeUnsentOTP stores otp of given purpose without sending it, so that request for unregistered email does same database work as for registered one.
+func (u userRepository) storeUnsentOTP(ctx context.Context, email string, purpose string) error {
+	tx, err := u.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = CreateOTP(ctx, tx, email, purpose)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return nil
+}
+
+// checkOTPSendLimit spaces out otps sent to given email and limits them per hour, so that inbox of the user can't be flooded.
+func (u userRepository) checkOTPSendLimit(ctx context.Context, email string) error {
+	sentRecently, err := u.redisClient.SetNX(ctx, "otp_send:"+email, time.Now().Unix(), constant.OTP_SEND_INTERVAL).Result()
+	if err != nil {
+		return err
+	}
+	if !sentRecently {
+		retryAfter, err := u.redisClient.TTL(ctx, "otp_send:"+email).Result()
+		if err != nil {
 			return err
 		}
-		if time.Until(dbOTP.OTPExpiryTime) < 0 {
-			return errorhandling.OTPVerificationTimeExpired
-		} else if dbOTP.OTP != otpFromUser.OTP {
-			return errorhandling.OTPNotMatched
-		} else {
-			rows.Close()
-			_, err := u.dbConn.Exec(context.Background(), "UPDATE otps SET is_verified = $1 WHERE id = $2", true, otpFromUser.ID)
-			if err != nil {
-				return err
-			}
-			return nil
-		}
-	} else {
-		return errorhandling.NoOTPIDFound
+		return errorhandling.CreateRateLimitError(constant.OTP_SEND_TOO_SOON, retryAfter)
+	}
+	allowed, retryAfter, err := utils.AllowRequest(u.redisClient, "otp_send_count:"+email, constant.OTP_SEND_LIMIT, constant.OTP_SEND_LIMIT_WINDOW)
+	if err != nil {
+		return err
 	}
+	if !allowed {
+		return errorhandling.CreateRateLimitError(constant.TOO_MANY_REQUESTS, retryAfter)
+	}
+	return nil
 }
 
-func (u userRepository) ResetUserPassword(userPasswordWithOTPId request.UserPasswordWithOTPID) error {
-	// var userCount int
-	// u.dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM users where email = $1`, userEmailPassword.Email).Scan(&userCount)
-	// if userCount == 0 {
-	// 	return errorhandling.NoEmailFound
-	// }
-	var dbEmail string
-	var isOTPVerified bool
-	rows, err := u.dbConn.Query(context.Background(), `SELECT email, is_verified FROM otps where id = $1`, userPasswordWithOTPId.ID)
+// sendOTPEmail stores otp of given purpose and queues email carrying it in a single transaction.
+func (u userRepository) sendOTPEmail(ctx context.Context, email string, purpose string, subject string) error {
+	tx, err := u.dbConn.Begin(ctx)
 	if err != nil {
 		return err
 	}
-	defer rows.Close()
-	if rows.Next() {
-		if err := rows.Scan(&dbEmail, &isOTPVerified); err != nil {
-			return err
+	err = SendOTPEmail(ctx, tx, u.rabbitmqConn, email, purpose, subject)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return nil
+}
+
+// VerifyOTP consumes password reset otp of the user and returns short lived reset token which is required by ResetUserPassword.
+// OTPNotMatched is returned for expired or used otp and for unregistered email too, so that response doesn't tell whether account exists or not.
+func (u userRepository) VerifyOTP(otpFromUser request.OTP) (string, error) {
+	ctx := context.Background()
+	tx, err := u.dbConn.Begin(ctx)
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	otpID, err := ConsumeOTP(ctx, tx, otpFromUser.Email, constant.OTP_PURPOSE_PASSWORD_RESET, otpFromUser.OTP)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err == errorhandling.OTPNotMatched {
+			return constant.EMPTY_STRING, u.recordWrongOTP(otpID, otpFromUser.Email)
 		}
-		if !isOTPVerified {
-			return errorhandling.OTPVerificationTimeExpired
-		} else {
-			rows.Close()
-			_, err := u.dbConn.Exec(context.Background(), "UPDATE users SET password = $1 WHERE email = $2", userPasswordWithOTPId.Password, dbEmail)
-			if err != nil {
-				return err
-			}
-			return nil
+		if err == errorhandling.OTPVerificationTimeExpired {
+			return constant.EMPTY_STRING, errorhandling.OTPNotMatched
 		}
-	} else {
-		return errorhandling.NoOTPIDFound
+		return constant.EMPTY_STRING, err
+	}
+
+	var userId int64
+	rows := tx.QueryRow(ctx, `SELECT id FROM users WHERE email = $1`, otpFromUser.Email)
+	err = rows.Scan(&userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err.Error() == constant.PG_NO_ROWS {
+			return constant.EMPTY_STRING, errorhandling.OTPNotMatched
+		}
+		return constant.EMPTY_STRING, err
+	}
+
+	resetToken, err := utils.CreatePurposeToken(constant.OTP_PURPOSE_PASSWORD_RESET, userId, constant.PASSWORD_RESET_TOKEN_LIFETIME)
+	if err != nil {
+		tx.Rollback(ctx)
+		return constant.EMPTY_STRING, err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return constant.EMPTY_STRING, err
+	}
+	return resetToken, nil
+}
+
+// recordWrongOTP counts wrong attempts of the otp, after few attempts otp is invalidated so that it can't be brute-forced
+// and user has to request new otp, Retry-After tells when new otp can be requested.
+func (u userRepository) recordWrongOTP(otpID int64, email string) error {
+	ctx := context.Background()
+	allowed, _, err := utils.AllowRequest(u.redisClient, fmt.Sprintf("otp_attempts:%d", otpID), constant.OTP_MAX_VERIFY_ATTEMPTS-1, constant.OTP_LIFETIME)
+	if err != nil {
+		return err
+	}
+	if allowed {
+		return errorhandling.OTPNotMatched
+	}
+
+	_, err = u.dbConn.Exec(ctx, "UPDATE otps SET expires_at = $1 WHERE id = $2", time.Now().UTC(), otpID)
+	if err != nil {
+		return err
+	}
+	retryAfter, err := u.redisClient.TTL(ctx, "otp_send:"+email).Result()
+	if err != nil {
+		return err
+	}
+	return errorhandling.CreateRateLimitError(constant.OTP_ATTEMPTS_EXCEEDED, retryAfter)
+}
+
+// ResetUserPassword updates password of the user of reset token, jti of the token is remembered till its expiry so that token can be used only once.
+// password reset forced by admin is fulfilled by it.
+// new password is checked against password history of the user before the token is used up, so that user can retry with another password.
+// all sessions and access tokens of the user are revoked same as tmsctl reset-password and password reset forced by admin.
+func (u userRepository) ResetUserPassword(resetPassword request.ResetPassword) error {
+	ctx := context.Background()
+	claims, err := utils.VerifyPurposeToken(resetPassword.ResetToken, constant.OTP_PURPOSE_PASSWORD_RESET)
+	if err != nil || claims.TokenID == constant.EMPTY_STRING {
+		return errorhandling.PasswordResetTokenInvalid
+	}
+
+	tx, err := u.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	err = CheckPasswordReuse(ctx, tx, claims.UserID, resetPassword.Password, "password")
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	hashedPassword, err := utils.HashPassword(resetPassword.Password)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	firstUse, err := u.redisClient.SetNX(ctx, "used_reset_token:"+claims.TokenID, claims.UserID, time.Until(claims.ExpiresAt)).Result()
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if !firstUse {
+		tx.Rollback(ctx)
+		return errorhandling.PasswordResetTokenInvalid
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE users SET password = $1, password_reset_required = false WHERE id = $2", hashedPassword, claims.UserID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = SavePasswordHistory(ctx, tx, claims.UserID, hashedPassword)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
 	}
+	// whoever knew the old password may still be logged in, so every session of the user is revoked.
+	err = RevokeAllSessionsOfUser(ctx, tx, claims.UserID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return utils.DenyAllAccessTokensOfUser(u.redisClient, claims.UserID, time.Now())
+}
+
+// DeleteExpiredOTPs deletes expired and used otps and returns count of deleted otps.
+func (u userRepository) DeleteExpiredOTPs() (int64, error) {
+	result, err := u.dbConn.Exec(context.Background(), `DELETE FROM otps WHERE expires_at < $1 OR used_at IS NOT NULL`, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 func (u userRepository) VerifyUserPassword(userPassword string, userId int64) error {
@@ -220,3 +541,359 @@ func (u userRepository) VerifyUserPassword(userPassword string, userId int64) er
 
 	return nil
 }
+
+// EnrollTwoFactor generates new totp secret for the user and returns it along with otpauth uri.
+// two factor authentication remains disabled till user confirms enrollment with the first code.
+func (u userRepository) EnrollTwoFactor(userId int64) (string, string, error) {
+	ctx := context.Background()
+	var email string
+	row := u.dbConn.QueryRow(ctx, `SELECT email FROM users WHERE id = $1`, userId)
+	err := row.Scan(&email)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return constant.EMPTY_STRING, constant.EMPTY_STRING, errorhandling.NoUserFound
+		}
+		return constant.EMPTY_STRING, constant.EMPTY_STRING, err
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return constant.EMPTY_STRING, constant.EMPTY_STRING, err
+	}
+
+	result, err := u.dbConn.Exec(ctx, `INSERT INTO user_two_factor (user_id, secret) VALUES ($1, $2)
+	ON CONFLICT (user_id) DO UPDATE SET secret = excluded.secret, last_used_step = 0, created_at = $3 WHERE user_two_factor.enabled = false`, userId, secret, time.Now())
+	if err != nil {
+		return constant.EMPTY_STRING, constant.EMPTY_STRING, err
+	}
+	if result.RowsAffected() == 0 {
+		return constant.EMPTY_STRING, constant.EMPTY_STRING, errorhandling.TwoFactorAlreadyEnabled
+	}
+
+	return secret, utils.CreateTOTPURI(secret, email), nil
+}
+
+// ConfirmTwoFactor verifies first code of authenticator app against enrolled secret, enables two factor authentication
+// and returns new recovery codes. only hashes of recovery codes are stored, so they are returned only this time.
+func (u userRepository) ConfirmTwoFactor(userId int64, code string) ([]string, error) {
+	ctx := context.Background()
+	var secret string
+	var enabled bool
+
+	tx, err := u.dbConn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRow(ctx, `SELECT secret, enabled FROM user_two_factor WHERE user_id = $1 FOR UPDATE`, userId)
+	err = row.Scan(&secret, &enabled)
+	if err != nil {
+		tx.Rollback(ctx)
+		if err.Error() == constant.PG_NO_ROWS {
+			return nil, errorhandling.TwoFactorNotEnrolled
+		}
+		return nil, err
+	}
+	if enabled {
+		tx.Rollback(ctx)
+		return nil, errorhandling.TwoFactorAlreadyEnabled
+	}
+
+	timeStep, valid := utils.VerifyTOTPCode(secret, code, time.Now(), 0)
+	if !valid {
+		tx.Rollback(ctx)
+		return nil, errorhandling.InvalidTwoFactorCode
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE user_two_factor SET enabled = true, enabled_at = $1, last_used_step = $2 WHERE user_id = $3`, time.Now(), timeStep, userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	recoveryCodes, err := utils.GenerateRecoveryCodes(constant.RECOVERY_CODES_COUNT)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	err = InsertRecoveryCodes(ctx, tx, userId, recoveryCodes)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// DisableTwoFactor removes totp secret and recovery codes of the user, password must be verified before calling it.
+func (u userRepository) DisableTwoFactor(userId int64) error {
+	ctx := context.Background()
+	tx, err := u.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM user_two_factor WHERE user_id = $1 AND enabled = true`, userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		tx.Rollback(ctx)
+		return errorhandling.TwoFactorNotEnabled
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM two_factor_recovery_codes WHERE user_id = $1`, userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return nil
+}
+
+// UpdateUserAvatar stores thumbnails of new avatar of the user under new key and removes files of previous avatar,
+// new key is used for every upload so that thumbnails cached by browsers are never served for changed avatar.
+func (u userRepository) UpdateUserAvatar(userId int64, thumbnails map[int][]byte) (response.Avatar, error) {
+	ctx := context.Background()
+	var previousAvatarKey *string
+	err := u.dbConn.QueryRow(ctx, `SELECT avatar_key FROM users WHERE id = $1`, userId).Scan(&previousAvatarKey)
+	if err != nil {
+		if err.Error() == constant.PG_NO_ROWS {
+			return response.Avatar{}, errorhandling.NoUserFound
+		}
+		return response.Avatar{}, err
+	}
+
+	randomID, err := utils.CreateRandomID()
+	if err != nil {
+		return response.Avatar{}, err
+	}
+	avatarKey := strconv.FormatInt(userId, 10) + "/" + randomID
+	avatarStorage := storage.Default()
+	for size, thumbnail := range thumbnails {
+		err = avatarStorage.Save(utils.AvatarFileKey(avatarKey, size), thumbnail)
+		if err != nil {
+			deleteAvatarFiles(avatarKey)
+			return response.Avatar{}, err
+		}
+	}
+
+	_, err = u.dbConn.Exec(ctx, `UPDATE users SET avatar_key = $1 WHERE id = $2`, avatarKey, userId)
+	if err != nil {
+		deleteAvatarFiles(avatarKey)
+		return response.Avatar{}, err
+	}
+	if previousAvatarKey != nil {
+		deleteAvatarFiles(*previousAvatarKey)
+	}
+	return *utils.AvatarOf(&avatarKey), nil
+}
+
+// deleteAvatarFiles removes thumbnails of the avatar from storage, failure is only logged because stale file doesn't affect the user.
+func deleteAvatarFiles(avatarKey string) {
+	for _, size := range utils.AvatarSizes {
+		if err := storage.Default().Delete(utils.AvatarFileKey(avatarKey, size)); err != nil {
+			config.LoggerInstance.Warning(err.Error())
+		}
+	}
+}
+
+// ExportUserData returns copy of personal data of the user, password hash is never part of it.
+func (u userRepository) ExportUserData(userId int64) (response.UserDataExport, error) {
+	ctx := context.Background()
+	userData := response.UserDataExport{
+		ExportedAt:    time.Now().UTC(),
+		Teams:         make([]response.Team, 0),
+		CreatedTasks:  make([]response.Task, 0),
+		AssignedTasks: make([]response.Task, 0),
+		Sessions:      make([]response.Session, 0),
+		Comments:      make([]response.TaskComment, 0),
+	}
+
+	profile, err := u.GetMyDetails(userId)
+	if err != nil {
+		return userData, err
+	}
+	profile.Password = constant.EMPTY_STRING
+	userData.Profile = profile
+	userData.Preferences, err = u.GetUserPreferences(userId)
+	if err != nil {
+		return userData, err
+	}
+
+	teams, err := u.dbConn.Query(ctx, `SELECT id, name, created_by, created_at, team_privacy, auto_assign_strategy, organization_id FROM teams
+	WHERE created_by = $1 OR id IN (SELECT team_id FROM team_members WHERE member_id = $1) ORDER BY created_at`, userId)
+	if err != nil {
+		return userData, err
+	}
+	defer teams.Close()
+	var team response.Team
+	for teams.Next() {
+		if err := teams.Scan(&team.ID, &team.Name, &team.CreatedBy, &team.CreatedAt, &team.TeamPrivacy, &team.AutoAssignStrategy, &team.OrganizationID); err != nil {
+			return userData, err
+		}
+		userData.Teams = append(userData.Teams, team)
+	}
+
+	userData.CreatedTasks, err = u.getTasks(ctx, `SELECT `+TaskColumns+` FROM tasks WHERE created_by = $1 ORDER BY created_at`, userId)
+	if err != nil {
+		return userData, err
+	}
+	userData.AssignedTasks, err = u.getTasks(ctx, `SELECT `+TaskColumns+` FROM tasks WHERE assignee_individual = $1 OR owner_individual = $1 ORDER BY created_at`, userId)
+	if err != nil {
+		return userData, err
+	}
+
+	sessions, err := u.dbConn.Query(ctx, `SELECT id, user_agent, ip_address, created_at, last_used_at FROM user_sessions WHERE user_id = $1 ORDER BY created_at`, userId)
+	if err != nil {
+		return userData, err
+	}
+	defer sessions.Close()
+	var session response.Session
+	for sessions.Next() {
+		if err := sessions.Scan(&session.ID, &session.UserAgent, &session.IPAddress, &session.CreatedAt, &session.LastUsedAt); err != nil {
+			return userData, err
+		}
+		userData.Sessions = append(userData.Sessions, session)
+	}
+
+	comments, err := u.dbConn.Query(ctx, `SELECT id, task_id, comment, created_by, created_at FROM task_comments WHERE created_by = $1 ORDER BY created_at`, userId)
+	if err != nil {
+		return userData, err
+	}
+	defer comments.Close()
+	var comment response.TaskComment
+	for comments.Next() {
+		if err := comments.Scan(&comment.ID, &comment.TaskID, &comment.Comment, &comment.CreatedBy, &comment.CreatedAt); err != nil {
+			return userData, err
+		}
+		userData.Comments = append(userData.Comments, comment)
+	}
+	return userData, nil
+}
+
+func (u userRepository) getTasks(ctx context.Context, query string, args ...interface{}) ([]response.Task, error) {
+	tasksSlice := make([]response.Task, 0)
+	tasks, err := u.dbConn.Query(ctx, query, args...)
+	if err != nil {
+		return tasksSlice, err
+	}
+	defer tasks.Close()
+
+	var task response.Task
+	for tasks.Next() {
+		if err := ScanTask(tasks, &task); err != nil {
+			return tasksSlice, err
+		}
+		tasksSlice = append(tasksSlice, task)
+	}
+	return tasksSlice, nil
+}
+
+// ScheduleAccountDeletion schedules deletion of the user after grace period and logs the user out of every session,
+// logging in again within grace period cancels the deletion. requesting it again keeps the deletion time scheduled first.
+func (u userRepository) ScheduleAccountDeletion(userId int64) error {
+	ctx := context.Background()
+	graceDays := config.Config.AccountDeletion.GraceDays
+	if graceDays <= 0 {
+		graceDays = constant.ACCOUNT_DELETION_GRACE_DAYS
+	}
+
+	tx, err := u.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := tx.Exec(ctx, `UPDATE users SET deletion_scheduled_at = COALESCE(deletion_scheduled_at, $1) WHERE id = $2 AND deleted_at IS NULL`,
+		time.Now().UTC().AddDate(0, 0, graceDays), userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		tx.Rollback(ctx)
+		return errorhandling.NoUserFound
+	}
+	_, err = tx.Exec(ctx, `UPDATE user_sessions SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`, time.Now(), userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1`, userId)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	return utils.DenyAllAccessTokensOfUser(u.redisClient, userId, time.Now())
+}
+
+// DeleteScheduledAccounts anonymizes users whose grace period of deletion is over and returns count of deleted accounts.
+func (u userRepository) DeleteScheduledAccounts() (int64, error) {
+	ctx := context.Background()
+	rows, err := u.dbConn.Query(ctx, `SELECT id FROM users WHERE deletion_scheduled_at <= $1 AND deleted_at IS NULL`, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	userIDs, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return 0, err
+	}
+
+	var deletedAccounts int64
+	for _, userID := range userIDs {
+		tx, err := u.dbConn.Begin(ctx)
+		if err != nil {
+			return deletedAccounts, err
+		}
+		anonymizedUser, err := AnonymizeUser(ctx, tx, userID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return deletedAccounts, err
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			tx.Rollback(ctx)
+			return deletedAccounts, err
+		}
+		deletedAccounts++
+
+		err = u.clearAnonymizedUserCache(ctx, anonymizedUser)
+		if err != nil {
+			return deletedAccounts, err
+		}
+	}
+	return deletedAccounts, nil
+}
+
+// clearAnonymizedUserCache removes redis sets, unread notification count and avatar of anonymized user and redis sets of its deleted teams and caches updated tasks again.
+func (u userRepository) clearAnonymizedUserCache(ctx context.Context, anonymizedUser dto.AnonymizedUser) error {
+	if anonymizedUser.AvatarKey != nil {
+		deleteAvatarFiles(*anonymizedUser.AvatarKey)
+	}
+	userID := strconv.FormatInt(anonymizedUser.UserID, 10)
+	keys := []string{"user:" + userID + ":teams", "tasks:assigned_to_user:" + userID, "tasks:created_by:" + userID, "notifications:unread:" + userID}
+	for _, teamID := range anonymizedUser.DeletedTeamIDs {
+		keys = append(keys, "tasks:assigned_to_team:"+strconv.FormatInt(teamID, 10))
+	}
+	err := u.redisClient.Del(ctx, keys...).Err()
+	if err != nil {
+		return err
+	}
+	return CacheTasksByID(ctx, u.dbConn, u.redisClient, anonymizedUser.UpdatedTaskIDs)
+}
@@ -1,11 +1,13 @@
 package repository
 
 import (
+	"context"
+	"net/http"
 	"testing"
 	"time"
 
-	"github.com/chirag1807/task-management-system/api/model/dto"
 	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/constant"
 	errorhandling "github.com/chirag1807/task-management-system/error"
 	"github.com/chirag1807/task-management-system/utils"
 	"github.com/stretchr/testify/assert"
@@ -14,12 +16,14 @@ import (
 func TestGetAllPublicPrivacyUsers(t *testing.T) {
 	testCases := []struct {
 		TestCaseName string
+		UserID       int64
 		QueryParams  request.UserQueryParams
 		Expected     interface{}
 		StatusCode   int
 	}{
 		{
 			TestCaseName: "Public Privacy Users Fetched Successfully",
+			UserID:       954488202459119617,
 			QueryParams: request.UserQueryParams{
 				Limit:  1,
 				Offset: 0,
@@ -32,10 +36,21 @@ func TestGetAllPublicPrivacyUsers(t *testing.T) {
 
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
-			_, err := NewUserRepo(dbConn, rabbitmqConn).GetAllPublicPrivacyUsers(v.QueryParams)
+			_, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).GetAllPublicPrivacyUsers(954560000000000001, v.UserID, v.QueryParams)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
+
+	users, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).GetAllPublicPrivacyUsers(954560000000000001, 954488202459119617, request.UserQueryParams{Limit: 50, Search: "Aashutosh"})
+	assert.NoError(t, err)
+	for _, user := range users {
+		assert.NotEqual(t, int64(954497896847212546), user.ID)
+	}
+
+	// users of other organizations are never part of search.
+	users, err = NewUserRepo(dbConn, redisClient, rabbitmqConn).GetAllPublicPrivacyUsers(954560000000000002, 954497896847212548, request.UserQueryParams{Limit: 50, Search: "Ridham"})
+	assert.NoError(t, err)
+	assert.Empty(t, users)
 }
 
 func TestGetMyDetails(t *testing.T) {
@@ -61,7 +76,7 @@ func TestGetMyDetails(t *testing.T) {
 
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
-			_, err := NewUserRepo(dbConn, rabbitmqConn).GetMyDetails(v.UserID)
+			_, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).GetMyDetails(v.UserID)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
@@ -75,7 +90,7 @@ func TestUpdateUserProfile(t *testing.T) {
 		Bio          string
 		Email        string
 		Password     string
-		Privacy      string
+		Privacy      request.PrivacySettings
 		UserID       int64
 		Expected     interface{}
 		StatusCode   int
@@ -89,11 +104,11 @@ func TestUpdateUserProfile(t *testing.T) {
 			StatusCode:   200,
 		},
 		{
-			TestCaseName: "Leave All Teams to Make Privacy Private",
-			Privacy:      "PRIVATE",
+			TestCaseName: "Privacy Settings Updated while Being Member of Teams.",
+			Privacy:      request.PrivacySettings{EmailVisibility: "TEAMMATES", BioVisibility: "NOBODY"},
 			UserID:       954488202459119617,
-			Expected:     errorhandling.LeftAllTeamsToMakePrivacyPrivate,
-			StatusCode:   401,
+			Expected:     nil,
+			StatusCode:   200,
 		},
 		{
 			TestCaseName: "Duplicate Email",
@@ -102,17 +117,26 @@ func TestUpdateUserProfile(t *testing.T) {
 			Expected:     errorhandling.DuplicateEmailFound,
 			StatusCode:   409,
 		},
+		{
+			TestCaseName: "Email Change Requested.",
+			Email:        "dhyey.panchal@gmail.com",
+			UserID:       954488202459119617,
+			Expected:     nil,
+			StatusCode:   200,
+		},
 	}
 
+	redisClient.Del(context.Background(), "otp_send:dhyey.panchal@gmail.com", "otp_send_count:dhyey.panchal@gmail.com")
+
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
 			userToUpdate := request.UpdateUser{
-				FirstName: v.FirstName,
-				LastName:  v.LastName,
-				Email:     v.Email,
-				Privacy:   v.Privacy,
+				FirstName:       v.FirstName,
+				LastName:        v.LastName,
+				Email:           v.Email,
+				PrivacySettings: v.Privacy,
 			}
-			err := NewUserRepo(dbConn, rabbitmqConn).UpdateUserProfile(v.UserID, userToUpdate)
+			err := NewUserRepo(dbConn, redisClient, rabbitmqConn).UpdateUserProfile(v.UserID, userToUpdate)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
@@ -122,101 +146,444 @@ func TestSendOTPToUser(t *testing.T) {
 	testCases := []struct {
 		TestCaseName string
 		Email        string
-		OTP          int
 		Expected     interface{}
 		StatusCode   int
 	}{
 		{
 			TestCaseName: "OTP Sent Successfully.",
-			Email:        "dhyey@gmail.com",
-			OTP:          1099,
+			Email:        "guptaaahutosh354@gmail.com",
 			Expected:     nil,
 			StatusCode:   200,
 		},
 		{
-			TestCaseName: "No Email Found",
+			TestCaseName: "No Email Found, Answered Same Way.",
 			Email:        "dhyey123@gmail.com",
-			OTP:          1099,
-			Expected:     errorhandling.NoEmailFound,
-			StatusCode:   404,
+			Expected:     nil,
+			StatusCode:   200,
 		},
 	}
 
+	redisClient.Del(context.Background(), "otp_send:guptaaahutosh354@gmail.com", "otp_send_count:guptaaahutosh354@gmail.com",
+		"otp_send:dhyey123@gmail.com", "otp_send_count:dhyey123@gmail.com")
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
-			emailBody := utils.PrepareEmailBody(v.OTP)
-			email := dto.Email{
-				To:      v.Email,
-				Subject: "OTP Verification",
-				Body:    emailBody,
-			}
-			_, err := NewUserRepo(dbConn, rabbitmqConn).SendOTPToUser(email, 4099, time.Now().Add(5*time.Minute))
+			err := NewUserRepo(dbConn, redisClient, rabbitmqConn).SendOTPToUser(v.Email)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
+
+	var otpCount int
+	dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM otps WHERE email = $1`, "dhyey123@gmail.com").Scan(&otpCount)
+	assert.Equal(t, 1, otpCount)
+}
+
+func TestSendOTPToUserTooSoon(t *testing.T) {
+	err := NewUserRepo(dbConn, redisClient, rabbitmqConn).SendOTPToUser("guptaaahutosh354@gmail.com")
+	assert.Equal(t, http.StatusTooManyRequests, err.(errorhandling.CustomError).HttpStatusCode)
+	assert.Greater(t, err.(errorhandling.CustomError).RetryAfter, time.Duration(0))
 }
 
 func TestVerifyOTP(t *testing.T) {
 	testCases := []struct {
 		TestCaseName string
-		ID           int64
-		OTP          int
+		Email        string
+		OTP          string
 		Expected     interface{}
 		StatusCode   int
 	}{
 		{
 			TestCaseName: "OTP Verified Successfully.",
-			OTP:          1099,
-			ID:           954537852771565569,
+			Email:        "dhyey@gmail.com",
+			OTP:          "109900",
 			Expected:     nil,
 			StatusCode:   200,
 		},
 		{
-			TestCaseName: "No Email Found",
-			ID:           954537852771565570,
-			OTP:          1099,
-			Expected:     errorhandling.NoOTPIDFound,
-			StatusCode:   404,
+			TestCaseName: "OTP Already Used.",
+			Email:        "dhyey@gmail.com",
+			OTP:          "109900",
+			Expected:     errorhandling.OTPNotMatched,
+			StatusCode:   401,
+		},
+		{
+			TestCaseName: "No User Registered with Email, Answered Same Way.",
+			Email:        "dhyey1234@gmail.com",
+			OTP:          "109900",
+			Expected:     errorhandling.OTPNotMatched,
+			StatusCode:   401,
 		},
 	}
 
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
 			otpFromUser := request.OTP{
-				ID:  v.ID,
-				OTP: v.OTP,
+				Email: v.Email,
+				OTP:   v.OTP,
 			}
-			err := NewUserRepo(dbConn, rabbitmqConn).VerifyOTP(otpFromUser)
+			resetToken, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).VerifyOTP(otpFromUser)
 			assert.Equal(t, v.Expected, err)
+			if v.Expected == nil {
+				claims, err := utils.VerifyPurposeToken(resetToken, constant.OTP_PURPOSE_PASSWORD_RESET)
+				assert.NoError(t, err)
+				assert.Equal(t, int64(954488202459119617), claims.UserID)
+			}
 		})
 	}
 }
 
+func TestVerifyOTPAttemptsExceeded(t *testing.T) {
+	redisClient.Del(context.Background(), "otp_attempts:954537852771565575")
+	wrongOTP := request.OTP{
+		Email: "ridham@gmail.com",
+		OTP:   "100000",
+	}
+
+	for i := 1; i < constant.OTP_MAX_VERIFY_ATTEMPTS; i++ {
+		_, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).VerifyOTP(wrongOTP)
+		assert.Equal(t, errorhandling.OTPNotMatched, err)
+	}
+
+	_, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).VerifyOTP(wrongOTP)
+	assert.Equal(t, http.StatusTooManyRequests, err.(errorhandling.CustomError).HttpStatusCode)
+
+	// otp is invalidated, so even correct otp can't be verified now.
+	_, err = NewUserRepo(dbConn, redisClient, rabbitmqConn).VerifyOTP(request.OTP{Email: "ridham@gmail.com", OTP: "207700"})
+	assert.Equal(t, errorhandling.OTPNotMatched, err)
+}
+
 func TestResetUserPassword(t *testing.T) {
+	resetToken, _ := utils.CreatePurposeToken(constant.OTP_PURPOSE_PASSWORD_RESET, 954488202459119617, constant.PASSWORD_RESET_TOKEN_LIFETIME)
+	accessToken, _ := utils.CreateJWTToken(constant.JWT_TYPE_ACCESS, time.Now().Add(constant.ACCESS_TOKEN_LIFETIME), 954488202459119617, 954537852771565570)
+
 	testCases := []struct {
 		TestCaseName string
-		ID           int64
+		ResetToken   string
 		Password     string
 		Expected     interface{}
 		StatusCode   int
 	}{
 		{
 			TestCaseName: "Password Reset Done Successfully.",
-			ID:           954537852771565569,
-			Password:     "Dhyey123$",
+			ResetToken:   resetToken,
+			Password:     "Dhyey@Secure2024",
 			Expected:     nil,
 			StatusCode:   200,
 		},
+		{
+			TestCaseName: "Reset Token Already Used.",
+			ResetToken:   resetToken,
+			Password:     "Dhyey@Secure2024",
+			Expected:     errorhandling.PasswordResetTokenInvalid,
+			StatusCode:   401,
+		},
+		{
+			TestCaseName: "Access Token Used as Reset Token.",
+			ResetToken:   accessToken,
+			Password:     "Dhyey@Secure2024",
+			Expected:     errorhandling.PasswordResetTokenInvalid,
+			StatusCode:   401,
+		},
 	}
 
 	for _, v := range testCases {
 		t.Run(v.TestCaseName, func(t *testing.T) {
-			userPasswordWithOTPId := request.UserPasswordWithOTPID{
-				ID:       v.ID,
-				Password: v.Password,
+			resetPassword := request.ResetPassword{
+				ResetToken: v.ResetToken,
+				Password:   v.Password,
 			}
-			err := NewUserRepo(dbConn, rabbitmqConn).ResetUserPassword(userPasswordWithOTPId)
+			err := NewUserRepo(dbConn, redisClient, rabbitmqConn).ResetUserPassword(resetPassword)
 			assert.Equal(t, v.Expected, err)
 		})
 	}
 }
+
+func TestDeleteExpiredOTPs(t *testing.T) {
+	_, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).DeleteExpiredOTPs()
+	assert.NoError(t, err)
+
+	var otpCount int
+	dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM otps WHERE used_at IS NOT NULL OR expires_at < $1`, time.Now().UTC()).Scan(&otpCount)
+	assert.Equal(t, 0, otpCount)
+}
+
+func TestEnrollTwoFactor(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Two Factor Enrolled Successfully.",
+			UserID:       954488202459119617,
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "Two Factor Already Enabled.",
+			UserID:       954497896847212546,
+			Expected:     errorhandling.TwoFactorAlreadyEnabled,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			_, _, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).EnrollTwoFactor(v.UserID)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestConfirmTwoFactor(t *testing.T) {
+	var secret string
+	err := dbConn.QueryRow(context.Background(), `SELECT secret FROM user_two_factor WHERE user_id = $1`, 954488202459119617).Scan(&secret)
+	assert.Equal(t, nil, err)
+	code, err := utils.GenerateTOTPCode(secret, utils.TOTPTimeStep(time.Now()))
+	assert.Equal(t, nil, err)
+
+	testCases := []struct {
+		TestCaseName  string
+		UserID        int64
+		Code          string
+		RecoveryCodes int
+		Expected      interface{}
+	}{
+		{
+			TestCaseName: "Two Factor Not Enrolled.",
+			UserID:       954497896847212545,
+			Code:         "123456",
+			Expected:     errorhandling.TwoFactorNotEnrolled,
+		},
+		{
+			TestCaseName: "Invalid Code.",
+			UserID:       954488202459119617,
+			Code:         "000000",
+			Expected:     errorhandling.InvalidTwoFactorCode,
+		},
+		{
+			TestCaseName:  "Two Factor Enabled Successfully.",
+			UserID:        954488202459119617,
+			Code:          code,
+			RecoveryCodes: 10,
+			Expected:      nil,
+		},
+		{
+			TestCaseName: "Two Factor Already Enabled.",
+			UserID:       954488202459119617,
+			Code:         code,
+			Expected:     errorhandling.TwoFactorAlreadyEnabled,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			recoveryCodes, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).ConfirmTwoFactor(v.UserID, v.Code)
+			assert.Equal(t, v.Expected, err)
+			assert.Equal(t, v.RecoveryCodes, len(recoveryCodes))
+		})
+	}
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Two Factor Disabled Successfully.",
+			UserID:       954497896847212546,
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "Two Factor Not Enabled.",
+			UserID:       954497896847212546,
+			Expected:     errorhandling.TwoFactorNotEnabled,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := NewUserRepo(dbConn, redisClient, rabbitmqConn).DisableTwoFactor(v.UserID)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestResendVerificationEmail(t *testing.T) {
+	redisClient.Del(context.Background(), "otp_send:dhyey123@gmail.com", "otp_send_count:dhyey123@gmail.com")
+	err := NewUserRepo(dbConn, redisClient, rabbitmqConn).ResendVerificationEmail("dhyey123@gmail.com")
+	assert.Equal(t, nil, err)
+}
+
+func TestVerifyEmail(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		Email        string
+		OTP          string
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Wrong OTP.",
+			Email:        "guptaaahutosh354@gmail.com",
+			OTP:          "100000",
+			Expected:     errorhandling.OTPNotMatched,
+		},
+		{
+			TestCaseName: "Email Verified Successfully.",
+			Email:        "guptaaahutosh354@gmail.com",
+			OTP:          "354354",
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "OTP Already Used.",
+			Email:        "guptaaahutosh354@gmail.com",
+			OTP:          "354354",
+			Expected:     errorhandling.OTPVerificationTimeExpired,
+		},
+	}
+
+	redisClient.Del(context.Background(), "otp_attempts:954537852771565576")
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := NewUserRepo(dbConn, redisClient, rabbitmqConn).VerifyEmail(request.OTP{Email: v.Email, OTP: v.OTP})
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestConfirmEmailChange(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		OTP          string
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "No Email Change Pending.",
+			UserID:       954497896847212545,
+			OTP:          "577577",
+			Expected:     errorhandling.NoEmailChangePending,
+		},
+		{
+			TestCaseName: "Email Changed Successfully.",
+			UserID:       954497896847212546,
+			OTP:          "577577",
+			Expected:     nil,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := NewUserRepo(dbConn, redisClient, rabbitmqConn).ConfirmEmailChange(v.UserID, v.OTP)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+
+	userDetails, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).GetMyDetails(954497896847212546)
+	assert.NoError(t, err)
+	assert.Equal(t, "aashutosh.gupta@gmail.com", userDetails.Email)
+	assert.True(t, userDetails.EmailVerified)
+}
+
+func TestExportUserData(t *testing.T) {
+	userData, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).ExportUserData(954488202459119617)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(954488202459119617), userData.Profile.ID)
+	assert.Empty(t, userData.Profile.Password)
+	assert.NotEmpty(t, userData.Teams)
+	assert.NotEmpty(t, userData.CreatedTasks)
+
+	_, err = NewUserRepo(dbConn, redisClient, rabbitmqConn).ExportUserData(1)
+	assert.Equal(t, errorhandling.NoUserFound, err)
+}
+
+func TestUpdateUserPreferences(t *testing.T) {
+	preferences, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).GetUserPreferences(954488202459119617)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultUserPreferences(), preferences)
+
+	testCases := []struct {
+		TestCaseName        string
+		UserID              int64
+		PreferencesToUpdate request.UserPreferences
+		Expected            interface{}
+	}{
+		{
+			TestCaseName: "Preferences Updated Successfully.",
+			UserID:       954488202459119617,
+			PreferencesToUpdate: request.UserPreferences{
+				Timezone:      "Asia/Kolkata",
+				DateFormat:    constant.DATE_FORMAT_DAY_FIRST,
+				Notifications: request.NotificationPreferences{TaskAssigned: constant.NOTIFICATION_CHANNEL_EMAIL},
+			},
+			Expected: nil,
+		},
+		{
+			TestCaseName:        "User Not Found.",
+			UserID:              1,
+			PreferencesToUpdate: request.UserPreferences{Timezone: "Asia/Kolkata"},
+			Expected:            errorhandling.NoUserFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			_, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).UpdateUserPreferences(v.UserID, v.PreferencesToUpdate)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+
+	preferences, err = NewUserRepo(dbConn, redisClient, rabbitmqConn).GetUserPreferences(954488202459119617)
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Kolkata", preferences.Timezone)
+	assert.Equal(t, constant.DEFAULT_LOCALE, preferences.Locale)
+	assert.Equal(t, constant.NOTIFICATION_CHANNEL_EMAIL, preferences.Notifications.TaskAssigned)
+	assert.Equal(t, constant.NOTIFICATION_CHANNEL_SOCKET, preferences.Notifications.TaskUpdated)
+}
+
+func TestScheduleAccountDeletion(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		UserID       int64
+		Expected     interface{}
+	}{
+		{
+			TestCaseName: "Account Deletion Scheduled Successfully.",
+			UserID:       954497896847212546,
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "User Not Found.",
+			UserID:       1,
+			Expected:     errorhandling.NoUserFound,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			err := NewUserRepo(dbConn, redisClient, rabbitmqConn).ScheduleAccountDeletion(v.UserID)
+			assert.Equal(t, v.Expected, err)
+		})
+	}
+}
+
+func TestDeleteScheduledAccounts(t *testing.T) {
+	deletedAccounts, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).DeleteScheduledAccounts()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deletedAccounts)
+
+	userDetails, err := NewUserRepo(dbConn, redisClient, rabbitmqConn).GetMyDetails(954497896847212547)
+	assert.NoError(t, err)
+	assert.Equal(t, constant.DELETED_USER_FIRST_NAME, userDetails.FirstName)
+	assert.False(t, userDetails.Privacy.Discoverable)
+	assert.Equal(t, constant.VISIBILITY_NOBODY, userDetails.Privacy.EmailVisibility)
+
+	accountStatus, err := NewAuthRepo(dbConn, redisClient, rabbitmqConn).GetAccountStatus(954497896847212547)
+	assert.NoError(t, err)
+	assert.True(t, accountStatus.Deactivated)
+
+	var teamCount, taskCount int
+	dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM teams WHERE id = 954507580144451587`).Scan(&teamCount)
+	dbConn.QueryRow(context.Background(), `SELECT COUNT(*) FROM tasks WHERE assignee_individual = 954497896847212547`).Scan(&taskCount)
+	assert.Equal(t, 0, teamCount)
+	assert.Equal(t, 0, taskCount)
+}
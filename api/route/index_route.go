@@ -1,11 +1,16 @@
 package route
 
 import (
+	"net/http"
+	"net/url"
+
 	"github.com/chirag1807/task-management-system/api/controller"
 	"github.com/chirag1807/task-management-system/api/middleware"
 	"github.com/chirag1807/task-management-system/api/repository"
 	"github.com/chirag1807/task-management-system/api/service"
+	"github.com/chirag1807/task-management-system/constant"
 	"github.com/chirag1807/task-management-system/utils/socket"
+	"github.com/chirag1807/task-management-system/utils/storage"
 	chi_middleware "github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
@@ -26,60 +31,177 @@ func InitializeRouter(dbConn *pgx.Conn, redisClient *redis.Client, rabbitmqConn
 	router.Use(chi_middleware.Logger)
 	router.Handle("/socket.io/", c.Handler(socketServer))
 
-	authRepository := repository.NewAuthRepo(dbConn)
+	authRepository := repository.NewAuthRepo(dbConn, redisClient, rabbitmqConn)
 	authService := service.NewAuthService(authRepository)
 	authController := controller.NewAuthController(authService)
 
-	taskRepository := repository.NewTaskRepo(dbConn, redisClient, socketServer)
+	taskRepository := repository.NewTaskRepo(dbConn, redisClient, rabbitmqConn, socketServer)
 	taskService := service.NewTaskService(taskRepository)
 	taskController := controller.NewTaskController(taskService)
 
-	teamRepository := repository.NewTeamRepo(dbConn, redisClient)
+	teamRepository := repository.NewTeamRepo(dbConn, redisClient, socketServer)
 	teamService := service.NewTeamService(teamRepository)
 	teamController := controller.NewTeamController(teamService)
 
-	userRepository := repository.NewUserRepo(dbConn, rabbitmqConn)
+	userRepository := repository.NewUserRepo(dbConn, redisClient, rabbitmqConn)
 	userService := service.NewUserService(userRepository)
 	userController := controller.NewUserController(userService)
 
+	adminRepository := repository.NewAdminRepo(dbConn, redisClient, rabbitmqConn, socketServer)
+	adminService := service.NewAdminService(adminRepository)
+	adminController := controller.NewAdminController(adminService)
+
+	organizationRepository := repository.NewOrganizationRepo(dbConn, redisClient, socketServer)
+	organizationService := service.NewOrganizationService(organizationRepository)
+	organizationController := controller.NewOrganizationController(organizationService)
+
+	projectRepository := repository.NewProjectRepo(dbConn, redisClient)
+	projectService := service.NewProjectService(projectRepository)
+	projectController := controller.NewProjectController(projectService)
+
+	sprintRepository := repository.NewSprintRepo(dbConn)
+	sprintService := service.NewSprintService(sprintRepository)
+	sprintController := controller.NewSprintController(sprintService)
+
+	dashboardRepository := repository.NewDashboardRepo(dbConn, redisClient)
+	dashboardService := service.NewDashboardService(dashboardRepository)
+	dashboardController := controller.NewDashboardController(dashboardService)
+
 	router.Route("/api/v1", func(r chi.Router) {
 		r.Route("/auth", func(r chi.Router) {
 			r.Post("/registration", authController.UserRegistration)
-			r.Post("/login", authController.UserLogin)
-			r.With(middleware.VerifyToken(1)).Post("/refresh-token", authController.RefreshToken)
+			r.With(middleware.RateLimit(redisClient, "login", constant.LOGIN_IP_RATE_LIMIT, constant.RATE_LIMIT_WINDOW)).Post("/login", authController.UserLogin)
+			r.With(middleware.RateLimit(redisClient, "login", constant.LOGIN_IP_RATE_LIMIT, constant.RATE_LIMIT_WINDOW)).Post("/login/2fa", authController.VerifyTwoFactorLogin)
+			r.Get("/oidc/login", authController.OIDCLogin)
+			r.Get("/oidc/callback", authController.OIDCCallback)
+			r.With(middleware.VerifyToken(1, authService)).Post("/refresh-token", authController.RefreshToken)
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.VerifyToken(0, authService))
+				r.Use(middleware.RequireSession)
+				r.Post("/logout", authController.Logout)
+				r.Post("/logout-all", authController.LogoutAll)
+				r.Get("/sessions", authController.GetActiveSessions)
+				r.Delete("/sessions/{SessionID}", authController.RevokeSession)
+				r.Post("/tokens", authController.CreatePersonalAccessToken)
+				r.Get("/tokens", authController.GetPersonalAccessTokens)
+				r.Delete("/tokens/{TokenID}", authController.RevokePersonalAccessToken)
+			})
 		})
 
 		r.Route("/tasks", func(r chi.Router) {
-			r.Use(middleware.VerifyToken(0))
-			r.Post("/", taskController.CreateTask)
-			r.Put("/{TaskID}", taskController.UpdateTask)
-			r.Get("/", taskController.GetAllTasks)
-			r.Get("/team/{TeamID}", taskController.GetTasksofTeam)
+			r.Use(middleware.VerifyToken(0, authService))
+			r.Use(middleware.RequireOrganization(organizationService))
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_WRITE)).Post("/", taskController.CreateTask)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_WRITE)).Put("/{TaskID}", taskController.UpdateTask)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_READ)).Get("/", taskController.GetAllTasks)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_READ)).Get("/team/{TeamID}", taskController.GetTasksofTeam)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_WRITE)).Post("/{TaskID}/comments", taskController.AddCommentToTask)
 		})
 
 		r.Route("/teams", func(r chi.Router) {
-			r.Use(middleware.VerifyToken(0))
-			r.Post("/", teamController.CreateTeam)
-			r.Post("/{TeamID}/members", teamController.AddMembersToTeam)
-			r.Delete("/{TeamID}/members", teamController.RemoveMembersFromTeam)
-			r.Get("/", teamController.GetAllTeams)
-			r.Get("/{TeamID}/members", teamController.GetTeamMembers)
-			r.Delete("/leave/{TeamID}", teamController.LeaveTeam)
+			r.Use(middleware.VerifyToken(0, authService))
+			r.Use(middleware.RequireOrganization(organizationService))
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_WRITE)).Post("/", teamController.CreateTeam)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_WRITE)).Post("/{TeamID}/members", teamController.AddMembersToTeam)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_WRITE)).Delete("/{TeamID}/members", teamController.RemoveMembersFromTeam)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_READ)).Get("/", teamController.GetAllTeams)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_READ)).Get("/{TeamID}/members", teamController.GetTeamMembers)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_READ)).Get("/{TeamID}/activity", teamController.GetTeamActivity)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_WRITE)).Put("/{TeamID}/auto-assign", teamController.UpdateTeamAutoAssignStrategy)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_WRITE)).Put("/{TeamID}/availability", teamController.UpdateTeamMemberAvailability)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_READ)).Get("/{TeamID}/analytics", teamController.GetTeamAnalytics)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_WRITE)).Delete("/leave/{TeamID}", teamController.LeaveTeam)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_WRITE)).Post("/{TeamID}/sprints", sprintController.CreateSprint)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_READ)).Get("/{TeamID}/sprints", sprintController.GetSprintsOfTeam)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_READ)).Get("/{TeamID}/sprints/velocity", sprintController.GetSprintVelocity)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_WRITE)).Put("/{TeamID}/sprints/{SprintID}", sprintController.UpdateSprint)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_WRITE)).Post("/{TeamID}/sprints/{SprintID}/tasks", sprintController.AddTasksToSprint)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_WRITE)).Delete("/{TeamID}/sprints/{SprintID}/tasks", sprintController.RemoveTasksFromSprint)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_READ)).Get("/{TeamID}/sprints/{SprintID}/tasks", sprintController.GetTasksOfSprint)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_WRITE)).Post("/{TeamID}/sprints/{SprintID}/complete", sprintController.CompleteSprint)
+			r.With(middleware.RequireScope(constant.SCOPE_TEAMS_READ)).Get("/{TeamID}/sprints/{SprintID}/burndown", sprintController.GetSprintBurndown)
+		})
+
+		r.Route("/projects", func(r chi.Router) {
+			r.Use(middleware.VerifyToken(0, authService))
+			r.Use(middleware.RequireOrganization(organizationService))
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_WRITE)).Post("/", projectController.CreateProject)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_READ)).Get("/", projectController.GetAllProjects)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_READ)).Get("/{ProjectID}", projectController.GetProject)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_WRITE)).Put("/{ProjectID}", projectController.UpdateProject)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_WRITE)).Delete("/{ProjectID}", projectController.DeleteProject)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_READ)).Get("/{ProjectID}/tasks", projectController.GetTasksOfProject)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_WRITE)).Post("/{ProjectID}/milestones", projectController.CreateMilestone)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_READ)).Get("/{ProjectID}/milestones", projectController.GetMilestonesOfProject)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_WRITE)).Put("/{ProjectID}/milestones/{MilestoneID}", projectController.UpdateMilestone)
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_WRITE)).Delete("/{ProjectID}/milestones/{MilestoneID}", projectController.DeleteMilestone)
+		})
+
+		r.Route("/me", func(r chi.Router) {
+			r.Use(middleware.VerifyToken(0, authService))
+			r.With(middleware.RequireScope(constant.SCOPE_TASKS_READ), middleware.RequireOrganization(organizationService)).Get("/dashboard", dashboardController.GetDashboard)
+			r.With(middleware.RequireScope(constant.SCOPE_USERS_WRITE)).Post("/notifications/read", dashboardController.MarkNotificationsRead)
 		})
 
 		r.Route("/users", func(r chi.Router) {
 			r.Group(func(r chi.Router) {
-				r.Use(middleware.VerifyToken(0))
-				r.Get("/public-privacy", userController.GetAllPublicPrivacyUsers)
-				r.Get("/profile", userController.GetMyDetails)
-				r.Put("/profile", userController.UpdateUserProfile)
+				r.Use(middleware.VerifyToken(0, authService))
+				r.With(middleware.RequireScope(constant.SCOPE_USERS_READ), middleware.RequireOrganization(organizationService)).Get("/public-privacy", userController.GetAllPublicPrivacyUsers)
+				r.With(middleware.RequireScope(constant.SCOPE_USERS_READ)).Get("/profile", userController.GetMyDetails)
+				r.With(middleware.RequireScope(constant.SCOPE_USERS_WRITE)).Put("/profile", userController.UpdateUserProfile)
+				r.With(middleware.RequireSession).Post("/profile/email/verify", userController.ConfirmEmailChange)
+				r.With(middleware.RequireScope(constant.SCOPE_USERS_WRITE)).Put("/profile/avatar", userController.UpdateUserAvatar)
+				r.With(middleware.RequireScope(constant.SCOPE_USERS_READ)).Get("/profile/preferences", userController.GetUserPreferences)
+				r.With(middleware.RequireScope(constant.SCOPE_USERS_WRITE)).Put("/profile/preferences", userController.UpdateUserPreferences)
+				r.With(middleware.RequireSession).Post("/profile/2fa", userController.EnrollTwoFactor)
+				r.With(middleware.RequireSession).Post("/profile/2fa/confirm", userController.ConfirmTwoFactor)
+				r.With(middleware.RequireSession).Delete("/profile/2fa", userController.DisableTwoFactor)
+				r.With(middleware.RequireSession).Get("/me/export", userController.ExportUserData)
+				r.With(middleware.RequireSession).Delete("/me", userController.DeleteAccount)
 			})
-			r.Post("/send-otp", userController.SendOTPToUser)
-			r.Post("/verify-otp", userController.VerifyOTP)
+			r.With(middleware.RateLimit(redisClient, "otp", constant.OTP_IP_RATE_LIMIT, constant.RATE_LIMIT_WINDOW)).Post("/send-otp", userController.SendOTPToUser)
+			r.With(middleware.RateLimit(redisClient, "otp", constant.OTP_IP_RATE_LIMIT, constant.RATE_LIMIT_WINDOW)).Post("/verify-otp", userController.VerifyOTP)
 			r.Put("/reset-password", userController.ResetUserPassword)
+			r.With(middleware.RateLimit(redisClient, "otp", constant.OTP_IP_RATE_LIMIT, constant.RATE_LIMIT_WINDOW)).Post("/verify-email", userController.VerifyEmail)
+			r.With(middleware.RateLimit(redisClient, "otp", constant.OTP_IP_RATE_LIMIT, constant.RATE_LIMIT_WINDOW)).Post("/verify-email/resend", userController.ResendVerificationEmail)
+		})
+
+		r.Route("/organizations", func(r chi.Router) {
+			r.Use(middleware.VerifyToken(0, authService))
+			r.With(middleware.RequireScope(constant.SCOPE_USERS_WRITE)).Post("/", organizationController.CreateOrganization)
+			r.With(middleware.RequireScope(constant.SCOPE_USERS_READ)).Get("/", organizationController.GetMyOrganizations)
+			r.Route("/{OrganizationID}", func(r chi.Router) {
+				r.Use(middleware.RequireOrganization(organizationService))
+				r.With(middleware.RequireScope(constant.SCOPE_USERS_READ)).Get("/members", organizationController.GetOrganizationMembers)
+				r.With(middleware.RequireScope(constant.SCOPE_USERS_WRITE)).Post("/members", organizationController.AddMemberToOrganization)
+				r.With(middleware.RequireScope(constant.SCOPE_USERS_WRITE)).Delete("/members/{UserID}", organizationController.RemoveMemberFromOrganization)
+			})
+		})
+
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(middleware.VerifyToken(0, authService))
+			r.Use(middleware.RequireSession)
+			r.Use(middleware.RequireAdmin)
+			r.Get("/users", adminController.GetAllUsers)
+			r.Put("/users/{UserID}/deactivate", adminController.DeactivateUser)
+			r.Put("/users/{UserID}/reactivate", adminController.ReactivateUser)
+			r.Post("/users/{UserID}/password-reset", adminController.ForcePasswordReset)
+			r.Delete("/users/{UserID}/sessions", adminController.RevokeUserSessions)
+			r.Get("/teams/{TeamID}", adminController.GetTeam)
+			r.Put("/teams/{TeamID}/owner", adminController.TransferTeamOwnership)
+			r.Get("/audit-logs", adminController.GetAuditLogs)
 		})
 	})
 
+	router.Get("/.well-known/jwks.json", authController.GetJWKS)
+
+	// avatars kept on local disk are served by the application itself, other storages serve them from their own url.
+	if localStorage, ok := storage.Default().(storage.LocalStorage); ok {
+		if avatarPath, err := url.Parse(localStorage.BaseURL); err == nil {
+			router.Handle(avatarPath.Path+"/*", http.StripPrefix(avatarPath.Path+"/", http.FileServer(localStorage.FileSystem())))
+		}
+	}
+
 	router.Route("/socket_events", func(r chi.Router) {
 		r.Get("/", socket.RenderSocketEventsDoc)
 	})
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/api/repository"
+)
+
+type AdminService interface {
+	GetAllUsers(adminId int64, queryParams request.AdminUserQueryParams) ([]response.AdminUser, error)
+	DeactivateUser(adminId int64, userId int64) error
+	ReactivateUser(adminId int64, userId int64) error
+	ForcePasswordReset(adminId int64, userId int64) error
+	RevokeUserSessions(adminId int64, userId int64) error
+	GetTeam(adminId int64, teamId int64) (response.TeamDetails, error)
+	TransferTeamOwnership(adminId int64, ownershipTransfer request.TeamOwnershipTransfer) error
+	GetAuditLogs(adminId int64, queryParams request.AdminAuditLogQueryParams) ([]response.AdminAuditLog, error)
+}
+
+type adminService struct {
+	adminRepository repository.AdminRepository
+}
+
+func NewAdminService(adminRepository repository.AdminRepository) AdminService {
+	return adminService{
+		adminRepository: adminRepository,
+	}
+}
+
+func (a adminService) GetAllUsers(adminId int64, queryParams request.AdminUserQueryParams) ([]response.AdminUser, error) {
+	return a.adminRepository.GetAllUsers(adminId, queryParams)
+}
+
+func (a adminService) DeactivateUser(adminId int64, userId int64) error {
+	return a.adminRepository.DeactivateUser(adminId, userId)
+}
+
+func (a adminService) ReactivateUser(adminId int64, userId int64) error {
+	return a.adminRepository.ReactivateUser(adminId, userId)
+}
+
+func (a adminService) ForcePasswordReset(adminId int64, userId int64) error {
+	return a.adminRepository.ForcePasswordReset(adminId, userId)
+}
+
+func (a adminService) RevokeUserSessions(adminId int64, userId int64) error {
+	return a.adminRepository.RevokeUserSessions(adminId, userId)
+}
+
+func (a adminService) GetTeam(adminId int64, teamId int64) (response.TeamDetails, error) {
+	return a.adminRepository.GetTeam(adminId, teamId)
+}
+
+func (a adminService) TransferTeamOwnership(adminId int64, ownershipTransfer request.TeamOwnershipTransfer) error {
+	return a.adminRepository.TransferTeamOwnership(adminId, ownershipTransfer)
+}
+
+func (a adminService) GetAuditLogs(adminId int64, queryParams request.AdminAuditLogQueryParams) ([]response.AdminAuditLog, error) {
+	return a.adminRepository.GetAuditLogs(adminId, queryParams)
+}
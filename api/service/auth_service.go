@@ -1,15 +1,31 @@
 package service
 
 import (
+	"github.com/chirag1807/task-management-system/api/model/dto"
 	"github.com/chirag1807/task-management-system/api/model/request"
 	"github.com/chirag1807/task-management-system/api/model/response"
 	"github.com/chirag1807/task-management-system/api/repository"
+	"github.com/chirag1807/task-management-system/utils"
 )
 
 type AuthService interface {
 	UserRegistration(user request.User) (int64, error)
-	UserLogin(user request.UserCredentials) (response.User, string, error)
-	RefreshToken(refreshToken string) (int64, string, error)
+	UserLogin(user request.UserCredentials, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error)
+	VerifyTwoFactorLogin(twoFactorLogin request.TwoFactorLogin, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error)
+	RefreshToken(refreshToken string, clientInfo request.ClientInfo) (dto.SessionToken, error)
+	Logout(userID int64, refreshToken string, accessToken utils.JWTTokenClaims) error
+	LogoutAll(userID int64) error
+	DeleteExpiredRefreshTokens() (int64, error)
+	GetActiveSessions(userID int64, currentSessionID int64) ([]response.Session, error)
+	RevokeSession(userID int64, sessionID int64) error
+	IsAccessTokenDenied(accessToken utils.JWTTokenClaims) (bool, error)
+	GetAccountStatus(userID int64) (dto.AccountStatus, error)
+	CreatePersonalAccessToken(userID int64, personalAccessToken request.PersonalAccessToken) (int64, string, error)
+	GetPersonalAccessTokens(userID int64) ([]response.PersonalAccessToken, error)
+	RevokePersonalAccessToken(userID int64, tokenID int64) error
+	VerifyPersonalAccessToken(token string) (int64, []string, error)
+	StartOIDCLogin() (string, error)
+	CompleteOIDCLogin(oidcCallback request.OIDCCallback, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error)
 }
 
 type authService struct {
@@ -26,10 +42,66 @@ func (a authService) UserRegistration(user request.User) (int64, error) {
 	return a.authRepository.UserRegistration(user)
 }
 
-func (a authService) UserLogin(user request.UserCredentials) (response.User, string, error) {
-	return a.authRepository.UserLogin(user)
+func (a authService) UserLogin(user request.UserCredentials, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error) {
+	return a.authRepository.UserLogin(user, clientInfo)
 }
 
-func (a authService) RefreshToken(refreshToken string) (int64, string, error) {
-	return a.authRepository.RefreshToken(refreshToken)
+func (a authService) VerifyTwoFactorLogin(twoFactorLogin request.TwoFactorLogin, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error) {
+	return a.authRepository.VerifyTwoFactorLogin(twoFactorLogin, clientInfo)
+}
+
+func (a authService) RefreshToken(refreshToken string, clientInfo request.ClientInfo) (dto.SessionToken, error) {
+	return a.authRepository.RefreshToken(refreshToken, clientInfo)
+}
+
+func (a authService) Logout(userID int64, refreshToken string, accessToken utils.JWTTokenClaims) error {
+	return a.authRepository.Logout(userID, refreshToken, accessToken)
+}
+
+func (a authService) LogoutAll(userID int64) error {
+	return a.authRepository.LogoutAll(userID)
+}
+
+func (a authService) DeleteExpiredRefreshTokens() (int64, error) {
+	return a.authRepository.DeleteExpiredRefreshTokens()
+}
+
+func (a authService) GetActiveSessions(userID int64, currentSessionID int64) ([]response.Session, error) {
+	return a.authRepository.GetActiveSessions(userID, currentSessionID)
+}
+
+func (a authService) RevokeSession(userID int64, sessionID int64) error {
+	return a.authRepository.RevokeSession(userID, sessionID)
+}
+
+func (a authService) IsAccessTokenDenied(accessToken utils.JWTTokenClaims) (bool, error) {
+	return a.authRepository.IsAccessTokenDenied(accessToken)
+}
+
+func (a authService) GetAccountStatus(userID int64) (dto.AccountStatus, error) {
+	return a.authRepository.GetAccountStatus(userID)
+}
+
+func (a authService) CreatePersonalAccessToken(userID int64, personalAccessToken request.PersonalAccessToken) (int64, string, error) {
+	return a.authRepository.CreatePersonalAccessToken(userID, personalAccessToken)
+}
+
+func (a authService) GetPersonalAccessTokens(userID int64) ([]response.PersonalAccessToken, error) {
+	return a.authRepository.GetPersonalAccessTokens(userID)
+}
+
+func (a authService) RevokePersonalAccessToken(userID int64, tokenID int64) error {
+	return a.authRepository.RevokePersonalAccessToken(userID, tokenID)
+}
+
+func (a authService) VerifyPersonalAccessToken(token string) (int64, []string, error) {
+	return a.authRepository.VerifyPersonalAccessToken(token)
+}
+
+func (a authService) StartOIDCLogin() (string, error) {
+	return a.authRepository.StartOIDCLogin()
+}
+
+func (a authService) CompleteOIDCLogin(oidcCallback request.OIDCCallback, clientInfo request.ClientInfo) (response.User, dto.SessionToken, error) {
+	return a.authRepository.CompleteOIDCLogin(oidcCallback, clientInfo)
 }
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/api/repository"
+)
+
+type DashboardService interface {
+	GetDashboard(organizationId int64, userId int64) (response.Dashboard, error)
+	MarkNotificationsRead(userId int64) error
+}
+
+type dashboardService struct {
+	dashboardRepository repository.DashboardRepository
+}
+
+func NewDashboardService(dashboardRepository repository.DashboardRepository) DashboardService {
+	return dashboardService{
+		dashboardRepository: dashboardRepository,
+	}
+}
+
+func (d dashboardService) GetDashboard(organizationId int64, userId int64) (response.Dashboard, error) {
+	return d.dashboardRepository.GetDashboard(organizationId, userId)
+}
+
+func (d dashboardService) MarkNotificationsRead(userId int64) error {
+	return d.dashboardRepository.MarkNotificationsRead(userId)
+}
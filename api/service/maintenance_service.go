@@ -0,0 +1,34 @@
+package service
+
+import (
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/repository"
+)
+
+type MaintenanceService interface {
+	CreateAdminUser(user request.User) (int64, error)
+	SetUserPassword(email string, password string) error
+	RebuildTaskCache() (int64, error)
+}
+
+type maintenanceService struct {
+	maintenanceRepository repository.MaintenanceRepository
+}
+
+func NewMaintenanceService(maintenanceRepository repository.MaintenanceRepository) MaintenanceService {
+	return maintenanceService{
+		maintenanceRepository: maintenanceRepository,
+	}
+}
+
+func (m maintenanceService) CreateAdminUser(user request.User) (int64, error) {
+	return m.maintenanceRepository.CreateAdminUser(user)
+}
+
+func (m maintenanceService) SetUserPassword(email string, password string) error {
+	return m.maintenanceRepository.SetUserPassword(email, password)
+}
+
+func (m maintenanceService) RebuildTaskCache() (int64, error) {
+	return m.maintenanceRepository.RebuildTaskCache()
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/api/repository"
+)
+
+type OrganizationService interface {
+	CreateOrganization(organizationToCreate request.Organization) (int64, error)
+	GetMyOrganizations(userId int64) ([]response.Organization, error)
+	GetOrganizationRole(organizationId int64, userId int64) (string, error)
+	GetOnlyOrganization(userId int64) (int64, string, error)
+	GetOrganizationMembers(userId int64, organizationId int64, queryParams request.UserQueryParams) ([]response.OrganizationMember, error)
+	AddMemberToOrganization(addedBy int64, memberToAdd request.OrganizationMember) (int64, error)
+	RemoveMemberFromOrganization(removedBy int64, organizationId int64, memberId int64) error
+}
+
+type organizationService struct {
+	organizationRepository repository.OrganizationRepository
+}
+
+func NewOrganizationService(organizationRepository repository.OrganizationRepository) OrganizationService {
+	return organizationService{
+		organizationRepository: organizationRepository,
+	}
+}
+
+func (o organizationService) CreateOrganization(organizationToCreate request.Organization) (int64, error) {
+	return o.organizationRepository.CreateOrganization(organizationToCreate)
+}
+
+func (o organizationService) GetMyOrganizations(userId int64) ([]response.Organization, error) {
+	return o.organizationRepository.GetMyOrganizations(userId)
+}
+
+func (o organizationService) GetOrganizationRole(organizationId int64, userId int64) (string, error) {
+	return o.organizationRepository.GetOrganizationRole(organizationId, userId)
+}
+
+func (o organizationService) GetOnlyOrganization(userId int64) (int64, string, error) {
+	return o.organizationRepository.GetOnlyOrganization(userId)
+}
+
+func (o organizationService) GetOrganizationMembers(userId int64, organizationId int64, queryParams request.UserQueryParams) ([]response.OrganizationMember, error) {
+	return o.organizationRepository.GetOrganizationMembers(userId, organizationId, queryParams)
+}
+
+func (o organizationService) AddMemberToOrganization(addedBy int64, memberToAdd request.OrganizationMember) (int64, error) {
+	return o.organizationRepository.AddMemberToOrganization(addedBy, memberToAdd)
+}
+
+func (o organizationService) RemoveMemberFromOrganization(removedBy int64, organizationId int64, memberId int64) error {
+	return o.organizationRepository.RemoveMemberFromOrganization(removedBy, organizationId, memberId)
+}
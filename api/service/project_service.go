@@ -0,0 +1,70 @@
+package service
+
+import (
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/api/repository"
+)
+
+type ProjectService interface {
+	CreateProject(projectToCreate request.Project) (int64, error)
+	GetAllProjects(organizationId int64, userId int64, queryParams request.ProjectQueryParams) ([]response.Project, error)
+	GetProject(organizationId int64, userId int64, projectId int64) (response.Project, error)
+	UpdateProject(organizationId int64, userId int64, projectToUpdate request.UpdateProject) error
+	DeleteProject(organizationId int64, userId int64, projectId int64) error
+	GetTasksOfProject(organizationId int64, userId int64, projectId int64, queryParams request.TaskQueryParams) ([]response.Task, error)
+	CreateMilestone(organizationId int64, userId int64, milestoneToCreate request.Milestone) (int64, error)
+	GetMilestonesOfProject(organizationId int64, userId int64, projectId int64) ([]response.Milestone, error)
+	UpdateMilestone(organizationId int64, userId int64, milestoneToUpdate request.UpdateMilestone) error
+	DeleteMilestone(organizationId int64, userId int64, projectId int64, milestoneId int64) error
+}
+
+type projectService struct {
+	projectRepository repository.ProjectRepository
+}
+
+func NewProjectService(projectRepository repository.ProjectRepository) ProjectService {
+	return projectService{
+		projectRepository: projectRepository,
+	}
+}
+
+func (p projectService) CreateProject(projectToCreate request.Project) (int64, error) {
+	return p.projectRepository.CreateProject(projectToCreate)
+}
+
+func (p projectService) GetAllProjects(organizationId int64, userId int64, queryParams request.ProjectQueryParams) ([]response.Project, error) {
+	return p.projectRepository.GetAllProjects(organizationId, userId, queryParams)
+}
+
+func (p projectService) GetProject(organizationId int64, userId int64, projectId int64) (response.Project, error) {
+	return p.projectRepository.GetProject(organizationId, userId, projectId)
+}
+
+func (p projectService) UpdateProject(organizationId int64, userId int64, projectToUpdate request.UpdateProject) error {
+	return p.projectRepository.UpdateProject(organizationId, userId, projectToUpdate)
+}
+
+func (p projectService) DeleteProject(organizationId int64, userId int64, projectId int64) error {
+	return p.projectRepository.DeleteProject(organizationId, userId, projectId)
+}
+
+func (p projectService) GetTasksOfProject(organizationId int64, userId int64, projectId int64, queryParams request.TaskQueryParams) ([]response.Task, error) {
+	return p.projectRepository.GetTasksOfProject(organizationId, userId, projectId, queryParams)
+}
+
+func (p projectService) CreateMilestone(organizationId int64, userId int64, milestoneToCreate request.Milestone) (int64, error) {
+	return p.projectRepository.CreateMilestone(organizationId, userId, milestoneToCreate)
+}
+
+func (p projectService) GetMilestonesOfProject(organizationId int64, userId int64, projectId int64) ([]response.Milestone, error) {
+	return p.projectRepository.GetMilestonesOfProject(organizationId, userId, projectId)
+}
+
+func (p projectService) UpdateMilestone(organizationId int64, userId int64, milestoneToUpdate request.UpdateMilestone) error {
+	return p.projectRepository.UpdateMilestone(organizationId, userId, milestoneToUpdate)
+}
+
+func (p projectService) DeleteMilestone(organizationId int64, userId int64, projectId int64, milestoneId int64) error {
+	return p.projectRepository.DeleteMilestone(organizationId, userId, projectId, milestoneId)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/api/repository"
+)
+
+type SprintService interface {
+	CreateSprint(organizationId int64, sprintToCreate request.Sprint) (int64, error)
+	GetSprintsOfTeam(organizationId int64, userId int64, teamId int64, queryParams request.SprintQueryParams) ([]response.Sprint, error)
+	UpdateSprint(organizationId int64, userId int64, sprintToUpdate request.UpdateSprint) error
+	AddTasksToSprint(organizationId int64, userId int64, sprintTasks request.SprintTasks) error
+	RemoveTasksFromSprint(organizationId int64, userId int64, sprintTasks request.SprintTasks) error
+	GetTasksOfSprint(organizationId int64, userId int64, teamId int64, sprintId int64, queryParams request.TaskQueryParams) ([]response.Task, error)
+	CompleteSprint(organizationId int64, userId int64, sprintToComplete request.CompleteSprint) error
+	GetSprintBurndown(organizationId int64, userId int64, teamId int64, sprintId int64) (response.SprintBurndown, error)
+	GetSprintVelocity(organizationId int64, userId int64, teamId int64, queryParams request.SprintVelocityQueryParams) (response.SprintVelocity, error)
+}
+
+type sprintService struct {
+	sprintRepository repository.SprintRepository
+}
+
+func NewSprintService(sprintRepository repository.SprintRepository) SprintService {
+	return sprintService{
+		sprintRepository: sprintRepository,
+	}
+}
+
+func (s sprintService) CreateSprint(organizationId int64, sprintToCreate request.Sprint) (int64, error) {
+	return s.sprintRepository.CreateSprint(organizationId, sprintToCreate)
+}
+
+func (s sprintService) GetSprintsOfTeam(organizationId int64, userId int64, teamId int64, queryParams request.SprintQueryParams) ([]response.Sprint, error) {
+	return s.sprintRepository.GetSprintsOfTeam(organizationId, userId, teamId, queryParams)
+}
+
+func (s sprintService) UpdateSprint(organizationId int64, userId int64, sprintToUpdate request.UpdateSprint) error {
+	return s.sprintRepository.UpdateSprint(organizationId, userId, sprintToUpdate)
+}
+
+func (s sprintService) AddTasksToSprint(organizationId int64, userId int64, sprintTasks request.SprintTasks) error {
+	return s.sprintRepository.AddTasksToSprint(organizationId, userId, sprintTasks)
+}
+
+func (s sprintService) RemoveTasksFromSprint(organizationId int64, userId int64, sprintTasks request.SprintTasks) error {
+	return s.sprintRepository.RemoveTasksFromSprint(organizationId, userId, sprintTasks)
+}
+
+func (s sprintService) GetTasksOfSprint(organizationId int64, userId int64, teamId int64, sprintId int64, queryParams request.TaskQueryParams) ([]response.Task, error) {
+	return s.sprintRepository.GetTasksOfSprint(organizationId, userId, teamId, sprintId, queryParams)
+}
+
+func (s sprintService) CompleteSprint(organizationId int64, userId int64, sprintToComplete request.CompleteSprint) error {
+	return s.sprintRepository.CompleteSprint(organizationId, userId, sprintToComplete)
+}
+
+func (s sprintService) GetSprintBurndown(organizationId int64, userId int64, teamId int64, sprintId int64) (response.SprintBurndown, error) {
+	return s.sprintRepository.GetSprintBurndown(organizationId, userId, teamId, sprintId)
+}
+
+func (s sprintService) GetSprintVelocity(organizationId int64, userId int64, teamId int64, queryParams request.SprintVelocityQueryParams) (response.SprintVelocity, error) {
+	return s.sprintRepository.GetSprintVelocity(organizationId, userId, teamId, queryParams)
+}
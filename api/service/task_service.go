@@ -8,9 +8,10 @@ import (
 
 type TaskService interface {
 	CreateTask(taskToCreate request.Task) (int64, error)
-	GetAllTasks(userId int64, queryParams request.TaskQueryParams) ([]response.Task, error)
-	GetTasksofTeam(teamId int64, queryParams request.TaskQueryParams) ([]response.Task, error)
-	UpdateTask(taskToUpdate request.UpdateTask) error
+	GetAllTasks(organizationId int64, userId int64, queryParams request.TaskQueryParams) ([]response.Task, error)
+	GetTasksofTeam(organizationId int64, teamId int64, queryParams request.TaskQueryParams) ([]response.Task, error)
+	UpdateTask(organizationId int64, taskToUpdate request.UpdateTask) error
+	AddCommentToTask(organizationId int64, commentToAdd request.TaskComment) (int64, error)
 }
 
 type taskService struct {
@@ -27,14 +28,18 @@ func (t taskService) CreateTask(taskToCreate request.Task) (int64, error) {
 	return t.taskRepository.CreateTask(taskToCreate)
 }
 
-func (t taskService) GetAllTasks(userId int64, queryParams request.TaskQueryParams) ([]response.Task, error) {
-	return t.taskRepository.GetAllTasks(userId, queryParams)
+func (t taskService) GetAllTasks(organizationId int64, userId int64, queryParams request.TaskQueryParams) ([]response.Task, error) {
+	return t.taskRepository.GetAllTasks(organizationId, userId, queryParams)
 }
 
-func (t taskService) GetTasksofTeam(teamId int64, queryParams request.TaskQueryParams) ([]response.Task, error) {
-	return t.taskRepository.GetTasksofTeam(teamId, queryParams)
+func (t taskService) GetTasksofTeam(organizationId int64, teamId int64, queryParams request.TaskQueryParams) ([]response.Task, error) {
+	return t.taskRepository.GetTasksofTeam(organizationId, teamId, queryParams)
 }
 
-func (t taskService) UpdateTask(taskToUpdate request.UpdateTask) error {
-	return t.taskRepository.UpdateTask(taskToUpdate)
+func (t taskService) UpdateTask(organizationId int64, taskToUpdate request.UpdateTask) error {
+	return t.taskRepository.UpdateTask(organizationId, taskToUpdate)
+}
+
+func (t taskService) AddCommentToTask(organizationId int64, commentToAdd request.TaskComment) (int64, error) {
+	return t.taskRepository.AddCommentToTask(organizationId, commentToAdd)
 }
@@ -8,11 +8,15 @@ import (
 
 type TeamService interface {
 	CreateTeam(teamToCreate request.Team, teamMembers []int64) (int64, error)
-	AddMembersToTeam(teamCreatedBy int64, teamMembersToAdd request.TeamMembersWithTeamID) error
-	RemoveMembersFromTeam(teamCreatedBy int64, teamMembersToRemove request.TeamMembersWithTeamID) error
-	GetAllTeams(userID int64, queryParams request.TeamQueryParams) ([]response.Team, error)
-	GetTeamMembers(teamId int64, queryParams request.TeamQueryParams) ([]response.User, error)
-	LeaveTeam(userID int64, teamId int64) (error)
+	AddMembersToTeam(organizationId int64, teamCreatedBy int64, teamMembersToAdd request.TeamMembersWithTeamID) error
+	RemoveMembersFromTeam(organizationId int64, teamCreatedBy int64, teamMembersToRemove request.TeamMembersWithTeamID) error
+	GetAllTeams(organizationId int64, userID int64, queryParams request.TeamQueryParams) ([]response.Team, error)
+	GetTeamMembers(organizationId int64, userId int64, teamId int64, queryParams request.TeamQueryParams) ([]response.User, error)
+	LeaveTeam(organizationId int64, userID int64, teamId int64) (error)
+	GetTeamActivity(organizationId int64, userID int64, teamId int64, queryParams request.TeamActivityQueryParams) ([]response.TeamActivity, error)
+	UpdateTeamAutoAssignStrategy(organizationId int64, teamCreatedBy int64, autoAssignStrategy request.TeamAutoAssignStrategy) error
+	UpdateTeamMemberAvailability(organizationId int64, userID int64, memberAvailability request.TeamMemberAvailability) error
+	GetTeamAnalytics(organizationId int64, userId int64, isAdmin bool, teamId int64, queryParams request.TeamAnalyticsQueryParams) (response.TeamAnalytics, error)
 }
 
 type teamService struct {
@@ -29,22 +33,38 @@ func (t teamService) CreateTeam(teamToCreate request.Team, teamMembers []int64)
 	return t.teamRepository.CreateTeam(teamToCreate, teamMembers)
 }
 
-func (t teamService) AddMembersToTeam(teamCreatedBy int64, teamMembersToAdd request.TeamMembersWithTeamID) error {
-	return t.teamRepository.AddMembersToTeam(teamCreatedBy, teamMembersToAdd)
+func (t teamService) AddMembersToTeam(organizationId int64, teamCreatedBy int64, teamMembersToAdd request.TeamMembersWithTeamID) error {
+	return t.teamRepository.AddMembersToTeam(organizationId, teamCreatedBy, teamMembersToAdd)
 }
 
-func (t teamService) RemoveMembersFromTeam(teamCreatedBy int64, teamMembersToRemove request.TeamMembersWithTeamID) error {
-	return t.teamRepository.RemoveMembersFromTeam(teamCreatedBy, teamMembersToRemove)
+func (t teamService) RemoveMembersFromTeam(organizationId int64, teamCreatedBy int64, teamMembersToRemove request.TeamMembersWithTeamID) error {
+	return t.teamRepository.RemoveMembersFromTeam(organizationId, teamCreatedBy, teamMembersToRemove)
 }
 
-func (t teamService) GetAllTeams(userID int64, queryParams request.TeamQueryParams) ([]response.Team, error) {
-	return t.teamRepository.GetAllTeams(userID, queryParams)
+func (t teamService) GetAllTeams(organizationId int64, userID int64, queryParams request.TeamQueryParams) ([]response.Team, error) {
+	return t.teamRepository.GetAllTeams(organizationId, userID, queryParams)
 }
 
-func (t teamService) GetTeamMembers(teamId int64, queryParams request.TeamQueryParams) ([]response.User, error) {
-	return t.teamRepository.GetTeamMembers(teamId, queryParams)
+func (t teamService) GetTeamMembers(organizationId int64, userId int64, teamId int64, queryParams request.TeamQueryParams) ([]response.User, error) {
+	return t.teamRepository.GetTeamMembers(organizationId, userId, teamId, queryParams)
 }
 
-func (t teamService) LeaveTeam(userID int64, teamId int64) (error) {
-	return t.teamRepository.LeaveTeam(userID, teamId)
+func (t teamService) LeaveTeam(organizationId int64, userID int64, teamId int64) (error) {
+	return t.teamRepository.LeaveTeam(organizationId, userID, teamId)
+}
+
+func (t teamService) GetTeamActivity(organizationId int64, userID int64, teamId int64, queryParams request.TeamActivityQueryParams) ([]response.TeamActivity, error) {
+	return t.teamRepository.GetTeamActivity(organizationId, userID, teamId, queryParams)
+}
+
+func (t teamService) UpdateTeamAutoAssignStrategy(organizationId int64, teamCreatedBy int64, autoAssignStrategy request.TeamAutoAssignStrategy) error {
+	return t.teamRepository.UpdateTeamAutoAssignStrategy(organizationId, teamCreatedBy, autoAssignStrategy)
+}
+
+func (t teamService) UpdateTeamMemberAvailability(organizationId int64, userID int64, memberAvailability request.TeamMemberAvailability) error {
+	return t.teamRepository.UpdateTeamMemberAvailability(organizationId, userID, memberAvailability)
+}
+
+func (t teamService) GetTeamAnalytics(organizationId int64, userId int64, isAdmin bool, teamId int64, queryParams request.TeamAnalyticsQueryParams) (response.TeamAnalytics, error) {
+	return t.teamRepository.GetTeamAnalytics(organizationId, userId, isAdmin, teamId, queryParams)
 }
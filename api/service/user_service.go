@@ -1,22 +1,32 @@
 package service
 
 import (
-	"time"
-
-	"github.com/chirag1807/task-management-system/api/model/dto"
 	"github.com/chirag1807/task-management-system/api/model/request"
 	"github.com/chirag1807/task-management-system/api/model/response"
 	"github.com/chirag1807/task-management-system/api/repository"
 )
 
 type UserService interface {
-	GetAllPublicPrivacyUsers(queryParams request.UserQueryParams) ([]response.User, error)
+	GetAllPublicPrivacyUsers(organizationId int64, userId int64, queryParams request.UserQueryParams) ([]response.User, error)
 	GetMyDetails(userId int64) (response.User, error)
 	UpdateUserProfile(userId int64, userToUpdate request.UpdateUser) error
-	SendOTPToUser(userEmail dto.Email, OTP int, OTPExpireTime time.Time) (int64, error)
-	VerifyOTP(otpFromUser request.OTP) error
-	ResetUserPassword(userPasswordWithOTPId request.UserPasswordWithOTPID) error
+	SendOTPToUser(userEmail string) error
+	VerifyOTP(otpFromUser request.OTP) (string, error)
+	ResetUserPassword(resetPassword request.ResetPassword) error
+	DeleteExpiredOTPs() (int64, error)
+	ConfirmEmailChange(userId int64, otp string) error
+	ResendVerificationEmail(userEmail string) error
+	VerifyEmail(otpFromUser request.OTP) error
 	VerifyUserPassword(userPassword string, userId int64) error
+	EnrollTwoFactor(userId int64) (string, string, error)
+	ConfirmTwoFactor(userId int64, code string) ([]string, error)
+	DisableTwoFactor(userId int64) error
+	UpdateUserAvatar(userId int64, thumbnails map[int][]byte) (response.Avatar, error)
+	ExportUserData(userId int64) (response.UserDataExport, error)
+	ScheduleAccountDeletion(userId int64) error
+	DeleteScheduledAccounts() (int64, error)
+	GetUserPreferences(userId int64) (response.UserPreferences, error)
+	UpdateUserPreferences(userId int64, preferencesToUpdate request.UserPreferences) (response.UserPreferences, error)
 }
 
 type userService struct {
@@ -29,8 +39,8 @@ func NewUserService(userRepository repository.UserRepository) UserService {
 	}
 }
 
-func (u userService) GetAllPublicPrivacyUsers(queryParams request.UserQueryParams) ([]response.User, error) {
-	return u.userRepository.GetAllPublicPrivacyUsers(queryParams)
+func (u userService) GetAllPublicPrivacyUsers(organizationId int64, userId int64, queryParams request.UserQueryParams) ([]response.User, error) {
+	return u.userRepository.GetAllPublicPrivacyUsers(organizationId, userId, queryParams)
 }
 
 func (u userService) GetMyDetails(userId int64) (response.User, error) {
@@ -41,18 +51,70 @@ func (u userService) UpdateUserProfile(userId int64, userToUpdate request.Update
 	return u.userRepository.UpdateUserProfile(userId, userToUpdate)
 }
 
-func (u userService) SendOTPToUser(userEmail dto.Email, OTP int, OTPExpireTime time.Time) (int64, error) {
-	return u.userRepository.SendOTPToUser(userEmail, OTP, OTPExpireTime)
+func (u userService) SendOTPToUser(userEmail string) error {
+	return u.userRepository.SendOTPToUser(userEmail)
 }
 
-func (u userService) VerifyOTP(otpFromUser request.OTP) error {
+func (u userService) VerifyOTP(otpFromUser request.OTP) (string, error) {
 	return u.userRepository.VerifyOTP(otpFromUser)
 }
 
-func (u userService) ResetUserPassword(userPasswordWithOTPId request.UserPasswordWithOTPID) error {
-	return u.userRepository.ResetUserPassword(userPasswordWithOTPId)
+func (u userService) ResetUserPassword(resetPassword request.ResetPassword) error {
+	return u.userRepository.ResetUserPassword(resetPassword)
+}
+
+func (u userService) DeleteExpiredOTPs() (int64, error) {
+	return u.userRepository.DeleteExpiredOTPs()
+}
+
+func (u userService) ConfirmEmailChange(userId int64, otp string) error {
+	return u.userRepository.ConfirmEmailChange(userId, otp)
+}
+
+func (u userService) ResendVerificationEmail(userEmail string) error {
+	return u.userRepository.ResendVerificationEmail(userEmail)
+}
+
+func (u userService) VerifyEmail(otpFromUser request.OTP) error {
+	return u.userRepository.VerifyEmail(otpFromUser)
 }
 
 func (u userService) VerifyUserPassword(userPassword string, userId int64) error {
 	return u.userRepository.VerifyUserPassword(userPassword, userId)
-}
\ No newline at end of file
+}
+
+func (u userService) EnrollTwoFactor(userId int64) (string, string, error) {
+	return u.userRepository.EnrollTwoFactor(userId)
+}
+
+func (u userService) ConfirmTwoFactor(userId int64, code string) ([]string, error) {
+	return u.userRepository.ConfirmTwoFactor(userId, code)
+}
+
+func (u userService) DisableTwoFactor(userId int64) error {
+	return u.userRepository.DisableTwoFactor(userId)
+}
+
+func (u userService) UpdateUserAvatar(userId int64, thumbnails map[int][]byte) (response.Avatar, error) {
+	return u.userRepository.UpdateUserAvatar(userId, thumbnails)
+}
+
+func (u userService) ExportUserData(userId int64) (response.UserDataExport, error) {
+	return u.userRepository.ExportUserData(userId)
+}
+
+func (u userService) ScheduleAccountDeletion(userId int64) error {
+	return u.userRepository.ScheduleAccountDeletion(userId)
+}
+
+func (u userService) DeleteScheduledAccounts() (int64, error) {
+	return u.userRepository.DeleteScheduledAccounts()
+}
+
+func (u userService) GetUserPreferences(userId int64) (response.UserPreferences, error) {
+	return u.userRepository.GetUserPreferences(userId)
+}
+
+func (u userService) UpdateUserPreferences(userId int64, preferencesToUpdate request.UserPreferences) (response.UserPreferences, error) {
+	return u.userRepository.UpdateUserPreferences(userId, preferencesToUpdate)
+}
@@ -6,9 +6,14 @@ import (
 	"log"
 	"net/http"
 	"time"
+	_ "time/tzdata"
 
+	"github.com/chirag1807/task-management-system/api/job"
+	"github.com/chirag1807/task-management-system/api/repository"
 	"github.com/chirag1807/task-management-system/api/route"
+	"github.com/chirag1807/task-management-system/api/service"
 	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
 	"github.com/chirag1807/task-management-system/db"
 	"github.com/chirag1807/task-management-system/docs"
 	"github.com/chirag1807/task-management-system/utils/socket"
@@ -25,6 +30,14 @@ func main() {
 	config.LoadConfig("../.config", "../.config/secret.json")
 	dbConn, redisClient, rabbitmqConn := db.SetDBConection(0)
 	defer dbConn.Close(context.Background())
+
+	if config.Config.Migration.OnStartup {
+		appliedVersions, err := db.MigrateUp(context.Background(), dbConn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("%d Migrations Applied %v.\n", len(appliedVersions), appliedVersions)
+	}
   
 	socketServer := socket.SocketConnection()
 	port := fmt.Sprintf(":%d", config.Config.Port)
@@ -52,6 +65,10 @@ func main() {
 		defer socketServer.Close()
 	}()
 
+	go job.CleanupExpiredRefreshTokens(service.NewAuthService(repository.NewAuthRepo(dbConn, redisClient, rabbitmqConn)), constant.REFRESH_TOKEN_CLEANUP_INTERVAL)
+	go job.CleanupExpiredOTPs(service.NewUserService(repository.NewUserRepo(dbConn, redisClient, rabbitmqConn)), constant.OTP_CLEANUP_INTERVAL)
+	go job.DeleteScheduledAccounts(service.NewUserService(repository.NewUserRepo(dbConn, redisClient, rabbitmqConn)), constant.ACCOUNT_DELETION_INTERVAL)
+
 	log.Println("Server Started on Port " + port)
 	log.Fatal(srv.ListenAndServe())
 }
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/repository"
+	"github.com/chirag1807/task-management-system/api/service"
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/chirag1807/task-management-system/db"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+const usage = `Usage: tmsctl [-config directory] <command> [flags]
+
+Commands:
+  migrate          apply pending migrations, "migrate down" rolls back latest one and "migrate status" lists them
+  create-admin     create user having admin flag, password is read from stdin
+  reset-password   set password of a user and revoke their sessions, password is read from stdin
+  seed             insert demo data (same fixtures as tests) into database
+  purge            delete expired otps and refresh tokens
+  rebuild-cache    rebuild redis task cache from database
+  config           print effective configuration with secrets redacted
+`
+
+// tmsctl runs administrative tasks against the same database, redis and config which the server uses.
+// config directory defaults to ../.config, same as the server, and must contain .env and secret.json.
+func main() {
+	log.SetFlags(0)
+	flags := flag.NewFlagSet("tmsctl", flag.ExitOnError)
+	configDirectory := flags.String("config", "../.config", "directory having .env and secret.json")
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flags.Parse(os.Args[1:])
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	config.LoadConfig(*configDirectory, filepath.Join(*configDirectory, "secret.json"))
+	utils.InitReqDataValidationTranslation()
+
+	command, args := flags.Arg(0), flags.Args()[1:]
+	var err error
+	switch command {
+	case "migrate":
+		err = migrate(args)
+	case "create-admin":
+		err = createAdmin(args)
+	case "reset-password":
+		err = resetPassword(args)
+	case "seed":
+		err = seed()
+	case "purge":
+		err = purge()
+	case "rebuild-cache":
+		err = rebuildCache()
+	case "config":
+		printConfig()
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// migrate applies, rolls back or lists migrations embedded in the binary, it connects only to database so redis and rabbitmq
+// don't need to be running. direction defaults to up, flags may be given before or after it.
+func migrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	testDatabase := flags.Bool("test", false, "migrate test database instead of main database")
+	// flag package stops parsing at first non-flag argument, so direction given first is taken out before parsing flags.
+	direction := constant.EMPTY_STRING
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		direction, args = args[0], args[1:]
+	}
+	flags.Parse(args)
+	if direction == constant.EMPTY_STRING && flags.NArg() > 0 {
+		direction = flags.Arg(0)
+		flags.Parse(flags.Args()[1:])
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %v, usage: tmsctl migrate [up|down|status] [-test]", flags.Args())
+	}
+
+	dbFlag := 0
+	if *testDatabase {
+		dbFlag = 1
+	}
+	ctx := context.Background()
+	dbConn, err := pgx.Connect(ctx, db.ConnString(dbFlag))
+	if err != nil {
+		return err
+	}
+	defer dbConn.Close(ctx)
+
+	switch direction {
+	case "up", constant.EMPTY_STRING:
+		appliedVersions, err := db.MigrateUp(ctx, dbConn)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d Migrations Applied %v.\n", len(appliedVersions), appliedVersions)
+	case "down":
+		rolledBackVersion, err := db.MigrateDown(ctx, dbConn)
+		if err != nil {
+			return err
+		}
+		if rolledBackVersion == constant.EMPTY_STRING {
+			fmt.Println("No Migration is Applied.")
+		} else {
+			fmt.Printf("Migration %s Rolled Back.\n", rolledBackVersion)
+		}
+	case "status":
+		migrationStatus, err := db.GetMigrationStatus(ctx, dbConn)
+		if err != nil {
+			return err
+		}
+		for _, m := range migrationStatus {
+			status := "pending"
+			if m.Applied {
+				status = "applied"
+			}
+			fmt.Printf("%-8s %s_%s\n", status, m.Version, m.Name)
+		}
+	default:
+		return fmt.Errorf("unknown migrate direction %q, it must be up, down or status", direction)
+	}
+	return nil
+}
+
+func createAdmin(args []string) error {
+	flags := flag.NewFlagSet("create-admin", flag.ExitOnError)
+	var user request.User
+	flags.StringVar(&user.Email, "email", constant.EMPTY_STRING, "email of the admin")
+	flags.StringVar(&user.FirstName, "first-name", constant.EMPTY_STRING, "first name of the admin")
+	flags.StringVar(&user.LastName, "last-name", constant.EMPTY_STRING, "last name of the admin")
+	flags.StringVar(&user.Bio, "bio", "System Administrator", "bio of the admin")
+	flags.Parse(args)
+
+	password, err := readPassword()
+	if err != nil {
+		return err
+	}
+	user.Password = password
+	if err := utils.Validate.Struct(user); err != nil {
+		return err
+	}
+	if err := utils.ValidatePasswordPolicy("password", user.Password); err != nil {
+		return err
+	}
+	user.Password, err = utils.HashPassword(user.Password)
+	if err != nil {
+		return err
+	}
+
+	dbConn, redisClient, rabbitmqConn := db.SetDBConection(0)
+	defer dbConn.Close(context.Background())
+	defer rabbitmqConn.Close()
+
+	userId, err := service.NewMaintenanceService(repository.NewMaintenanceRepo(dbConn, redisClient)).CreateAdminUser(user)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Admin %s Created with ID %d.\n", user.Email, userId)
+	return nil
+}
+
+func resetPassword(args []string) error {
+	flags := flag.NewFlagSet("reset-password", flag.ExitOnError)
+	email := flags.String("email", constant.EMPTY_STRING, "email of the user")
+	flags.Parse(args)
+	if *email == constant.EMPTY_STRING {
+		return errors.New("email is required")
+	}
+
+	password, err := readPassword()
+	if err != nil {
+		return err
+	}
+	if err := utils.ValidatePasswordPolicy("password", password); err != nil {
+		return err
+	}
+
+	dbConn, redisClient, rabbitmqConn := db.SetDBConection(0)
+	defer dbConn.Close(context.Background())
+	defer rabbitmqConn.Close()
+
+	err = service.NewMaintenanceService(repository.NewMaintenanceRepo(dbConn, redisClient)).SetUserPassword(*email, password)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Password of %s Reset, All of their Sessions are Revoked.\n", *email)
+	return nil
+}
+
+// seed inserts fixtures of tests, they have fixed ids so seeding database which already has them fails without inserting anything.
+func seed() error {
+	dbConn, _, rabbitmqConn := db.SetDBConection(0)
+	defer dbConn.Close(context.Background())
+	defer rabbitmqConn.Close()
+
+	ctx := context.Background()
+	tx, err := dbConn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	tx, err = utils.InsertMockData(tx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	fmt.Println("Demo Data Inserted, Run rebuild-cache to Load its Tasks into Redis.")
+	return nil
+}
+
+func purge() error {
+	dbConn, redisClient, rabbitmqConn := db.SetDBConection(0)
+	defer dbConn.Close(context.Background())
+	defer rabbitmqConn.Close()
+
+	deletedOTPs, err := service.NewUserService(repository.NewUserRepo(dbConn, redisClient, rabbitmqConn)).DeleteExpiredOTPs()
+	if err != nil {
+		return err
+	}
+	deletedTokens, err := service.NewAuthService(repository.NewAuthRepo(dbConn, redisClient, rabbitmqConn)).DeleteExpiredRefreshTokens()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d Expired or Used OTPs and %d Expired Refresh Tokens Deleted.\n", deletedOTPs, deletedTokens)
+	return nil
+}
+
+func rebuildCache() error {
+	dbConn, redisClient, rabbitmqConn := db.SetDBConection(0)
+	defer dbConn.Close(context.Background())
+	defer rabbitmqConn.Close()
+
+	cachedTasks, err := service.NewMaintenanceService(repository.NewMaintenanceRepo(dbConn, redisClient)).RebuildTaskCache()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d Tasks Cached.\n", cachedTasks)
+	return nil
+}
+
+func printConfig() {
+	effectiveConfig := config.RedactedConfig()
+	keys := make([]string, 0, len(effectiveConfig))
+	for key := range effectiveConfig {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("%s=%s\n", key, effectiveConfig[key])
+	}
+}
+
+// readPassword reads first line of stdin as password, so that it doesn't end up in shell history or process list.
+func readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "Password: ")
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == constant.EMPTY_STRING {
+		return constant.EMPTY_STRING, errors.New("password is required")
+	}
+	return strings.TrimRight(password, "\r\n"), nil
+}
@@ -16,6 +16,8 @@ var LoggerInstance logease.SlogLoggerInstance
 
 // LoadConfig uses viper package to load all env variables into config(package:dto) struct via above declared global Config variable.
 // Moreover it also read secret.json file of .config directory and load content into above declared global JWtSecretKey variable.
+// if JWT_KEY_DIRECTORY is set then asymmetric keys of that directory are loaded into JWTKeys and signing key must be one of them.
+// HS256 secret can be disabled by JWT_DISABLE_LEGACY_HS256 only when key directory is set.
 func LoadConfig(envFilePath string, secretJsonFilePath string) {
 	viper.AutomaticEnv()
 	viper.SetConfigType("env")
@@ -37,6 +39,18 @@ func LoadConfig(envFilePath string, secretJsonFilePath string) {
 		log.Fatal(err)
 	}
 
+	if Config.JWT.KeyDirectory != "" {
+		JWTKeys, err = LoadJWTKeys(Config.JWT.KeyDirectory)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if signingKey, ok := JWTKeys[Config.JWT.SigningKeyID]; !ok || signingKey.PrivateKey == nil {
+			log.Fatalf("private key of signing key %q not found in %s", Config.JWT.SigningKeyID, Config.JWT.KeyDirectory)
+		}
+	} else if Config.JWT.DisableLegacyHS256 {
+		log.Fatal("JWT_DISABLE_LEGACY_HS256 requires JWT_KEY_DIRECTORY, otherwise no token can be signed")
+	}
+
 	loggerInstance, err := logease.InitLogease(true, Config.TeamsWebHookURL, logease.Slog)
 	if err != nil {
 		log.Fatal(err)
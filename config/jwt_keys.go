@@ -0,0 +1,81 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chirag1807/task-management-system/api/model/dto"
+	"github.com/chirag1807/task-management-system/constant"
+)
+
+// JWTKeys holds all keys of key directory by their key id, they all are used for verifying tokens
+// but only key of JWT_SIGNING_KEY_ID is used for signing new tokens.
+var JWTKeys map[string]dto.JWTKey
+
+// LoadJWTKeys reads every .pem file of given directory, name of the file without extension becomes key id (kid).
+// file may contain private key (PKCS#1 or PKCS#8) or only public key (PKIX), public key only files are useful
+// during key rotation when old key must still verify tokens issued by it but must not sign new tokens anymore.
+func LoadJWTKeys(keyDirectory string) (map[string]dto.JWTKey, error) {
+	files, err := filepath.Glob(filepath.Join(keyDirectory, "*"+constant.JWT_KEY_FILE_EXT))
+	if err != nil {
+		return nil, err
+	}
+
+	jwtKeys := make(map[string]dto.JWTKey, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		jwtKey, err := parseJWTKey(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		jwtKey.ID = strings.TrimSuffix(filepath.Base(file), constant.JWT_KEY_FILE_EXT)
+		jwtKeys[jwtKey.ID] = jwtKey
+	}
+	return jwtKeys, nil
+}
+
+// parseJWTKey parses pem encoded rsa or ed25519 key and decides signing algorithm from type of the key.
+func parseJWTKey(content []byte) (dto.JWTKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return dto.JWTKey{}, errors.New(constant.INVALID_JWT_KEY)
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return dto.JWTKey{}, errors.New(constant.INVALID_JWT_KEY)
+	}
+	if err != nil {
+		return dto.JWTKey{}, err
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return dto.JWTKey{Algorithm: constant.JWT_ALGORITHM_RS256, PrivateKey: k, PublicKey: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return dto.JWTKey{Algorithm: constant.JWT_ALGORITHM_RS256, PublicKey: k}, nil
+	case ed25519.PrivateKey:
+		return dto.JWTKey{Algorithm: constant.JWT_ALGORITHM_EDDSA, PrivateKey: k, PublicKey: k.Public()}, nil
+	case ed25519.PublicKey:
+		return dto.JWTKey{Algorithm: constant.JWT_ALGORITHM_EDDSA, PublicKey: k}, nil
+	}
+	return dto.JWTKey{}, errors.New(constant.INVALID_JWT_KEY)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+// writePEMFile writes pem block of given type to file of given name in directory.
+func writePEMFile(t *testing.T, directory string, name string, blockType string, content []byte) {
+	err := os.WriteFile(filepath.Join(directory, name), pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: content}), 0600)
+	assert.NoError(t, err)
+}
+
+func TestLoadJWTKeys(t *testing.T) {
+	directory := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	writePEMFile(t, directory, "rsa-key.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+	pkcs8Key, err := x509.MarshalPKCS8PrivateKey(edKey)
+	assert.NoError(t, err)
+	writePEMFile(t, directory, "ed-key.pem", "PRIVATE KEY", pkcs8Key)
+	publicKey, err := x509.MarshalPKIXPublicKey(edPublicKey)
+	assert.NoError(t, err)
+	writePEMFile(t, directory, "old-key.pem", "PUBLIC KEY", publicKey)
+	writePEMFile(t, directory, "notes.txt", "PUBLIC KEY", publicKey)
+
+	jwtKeys, err := LoadJWTKeys(directory)
+	assert.NoError(t, err)
+	assert.Len(t, jwtKeys, 3)
+
+	assert.Equal(t, "rsa-key", jwtKeys["rsa-key"].ID)
+	assert.Equal(t, constant.JWT_ALGORITHM_RS256, jwtKeys["rsa-key"].Algorithm)
+	assert.Equal(t, rsaKey, jwtKeys["rsa-key"].PrivateKey)
+	assert.Equal(t, &rsaKey.PublicKey, jwtKeys["rsa-key"].PublicKey)
+
+	assert.Equal(t, constant.JWT_ALGORITHM_EDDSA, jwtKeys["ed-key"].Algorithm)
+	assert.Equal(t, edKey, jwtKeys["ed-key"].PrivateKey)
+	assert.Equal(t, edPublicKey, jwtKeys["ed-key"].PublicKey)
+
+	assert.Equal(t, constant.JWT_ALGORITHM_EDDSA, jwtKeys["old-key"].Algorithm)
+	assert.Nil(t, jwtKeys["old-key"].PrivateKey)
+	assert.Equal(t, edPublicKey, jwtKeys["old-key"].PublicKey)
+}
+
+func TestLoadJWTKeysInvalidKey(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		Content      []byte
+	}{
+		{
+			TestCaseName: "File is Not PEM Encoded.",
+			Content:      []byte("not a key"),
+		},
+		{
+			TestCaseName: "PEM Block of Unsupported Type.",
+			Content:      pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("certificate")}),
+		},
+		{
+			TestCaseName: "PEM Block with Invalid Key.",
+			Content:      pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}),
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			directory := t.TempDir()
+			err := os.WriteFile(filepath.Join(directory, "key.pem"), v.Content, 0600)
+			assert.NoError(t, err)
+			_, err = LoadJWTKeys(directory)
+			assert.Error(t, err)
+		})
+	}
+}
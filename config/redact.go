@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/chirag1807/task-management-system/constant"
+)
+
+// secretConfigKeys are env variables whose values must never be printed, teams webhook url is included as it carries its own token.
+var secretConfigKeys = map[string]bool{
+	"DATABASE_PASSWORD":   true,
+	"REDIS_PASSWORD":      true,
+	"RABBITMQ_PASSWORD":   true,
+	"SMTP_EMAIL_PASSWORD": true,
+	"OIDC_CLIENT_SECRET":  true,
+	"TEAMS_WEBHOOK_URL":   true,
+}
+
+// RedactedConfig returns loaded configuration as map of env variable names to their values, so that it can be printed safely.
+// values of secrets (passwords, client secret, webhook url and keys of secret.json) are replaced with constant.REDACTED_VALUE if they are set.
+func RedactedConfig() map[string]string {
+	effectiveConfig := make(map[string]string)
+	flattenConfig(reflect.ValueOf(Config), effectiveConfig)
+
+	effectiveConfig["secret.json/secretkey"] = redact(JWtSecretKey.SecretKey)
+	effectiveConfig["secret.json/otpsecretkey"] = redact(JWtSecretKey.OTPSecretKey)
+	return effectiveConfig
+}
+
+// flattenConfig walks config struct recursively and adds its fields by their mapstructure tag, squashed structs are flattened.
+func flattenConfig(value reflect.Value, effectiveConfig map[string]string) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			flattenConfig(value.Field(i), effectiveConfig)
+			continue
+		}
+
+		key := field.Tag.Get("mapstructure")
+		fieldValue := fmt.Sprint(value.Field(i).Interface())
+		if secretConfigKeys[key] {
+			fieldValue = redact(fieldValue)
+		}
+		effectiveConfig[key] = fieldValue
+	}
+}
+
+// redact hides value which is set, empty value is kept as it is so that missing secrets can still be noticed.
+func redact(value string) string {
+	if value == constant.EMPTY_STRING {
+		return value
+	}
+	return constant.REDACTED_VALUE
+}
@@ -1,5 +1,7 @@
 package constant
 
+import "time"
+
 const (
 	EMPTY_STRING              = ""
 	INVALID_TOKEN             = "This Token is Invalid"
@@ -7,7 +9,7 @@ const (
 	LEAVE_TEAM                = "Team Left Successfully."
 	MEMBERS_ADDED_TO_TEAM     = "Members Added to Team."
 	MEMBERS_REMOVED_FROM_TEAM = "Members Removed from Team."
-	OTP_SENT                  = "OTP Sent to given Email ID Successfully."
+	OTP_SENT                  = "If an Account Exists for given Email ID, an OTP has been Sent to It."
 	TOKEN_RESET_SUCCEED       = "Token Reset Done Successfully."
 	TASK_CREATED              = "Task Created Successfully."
 	TASK_UPDATED              = "Task Updated Successfully."
@@ -17,23 +19,280 @@ const (
 	USER_PROFILE_UPDATED      = "User Profile Updated Successfully."
 	USER_MAIL_QUEUE           = "user-mail-queue"
 	OTP_VERIFICATION_SUCCEED  = "OTP Verification Done Successfully, You can proceed Further."
+	TASK_COMMENT_ADDED        = "Comment Added to Task Successfully."
+	TEAM_AUTO_ASSIGN_UPDATED  = "Team Auto Assign Strategy Updated Successfully."
+	TEAM_AVAILABILITY_UPDATED = "Your Availability in Team Updated Successfully."
+	USER_LOGOUT_SUCCEED       = "User Logout Done Successfully."
+	USER_LOGOUT_ALL_SUCCEED   = "User Logged Out from All Sessions Successfully."
+	SESSION_REVOKED           = "Session Revoked Successfully."
+	TWO_FACTOR_ENROLLED       = "Scan the URI in Your Authenticator App and Confirm It with a Code."
+	TWO_FACTOR_ENABLED        = "Two Factor Authentication Enabled Successfully, Store Recovery Codes Safely."
+	TWO_FACTOR_DISABLED       = "Two Factor Authentication Disabled Successfully."
+	TOO_MANY_REQUESTS         = "Too Many Requests, Please Try Again Later."
+	ACCOUNT_LOCKED            = "Account is Temporarily Locked Because of Too Many Failed Login Attempts, Please Try Again Later."
+	OTP_SEND_TOO_SOON         = "OTP was Sent Recently, Please Wait Before Requesting New OTP."
+	OTP_ATTEMPTS_EXCEEDED     = "Too Many Wrong Attempts, OTP is Invalidated, Please Request New OTP."
+	TWO_FACTOR_REQUIRED       = "Two Factor Authentication is Required, Send Code with Challenge Token to Complete Login."
+	PAT_CREATED               = "Personal Access Token Created Successfully, Copy It Now as It won't be Shown Again."
+	PAT_REVOKED               = "Personal Access Token Revoked Successfully."
+	PASSWORD_RESET_SUCCEED    = "Password Reset Done Successfully."
+	EMAIL_VERIFICATION_SENT   = "If an Unverified Account Exists for given Email ID, a Verification Code has been Sent to It."
+	EMAIL_VERIFIED            = "Email Verified Successfully."
+	EMAIL_CHANGE_REQUESTED    = "User Profile Updated Successfully, Verify New Email with Code Sent to It to Complete Email Change."
+	EMAIL_CHANGED             = "Email Changed Successfully."
+	ACCOUNT_DELETE_SCHEDULED  = "Account Deletion Scheduled Successfully, Login Again within Grace Period to Cancel It."
+	AVATAR_UPDATED            = "Avatar Updated Successfully."
+	PREFERENCES_UPDATED       = "Preferences Updated Successfully."
+	USER_DEACTIVATED          = "User Deactivated Successfully."
+	USER_REACTIVATED          = "User Reactivated Successfully."
+	PASSWORD_RESET_FORCED     = "Password Reset Forced Successfully, User is Logged Out and OTP is Sent to Email."
+	USER_SESSIONS_REVOKED     = "All Sessions of User Revoked Successfully."
+	TEAM_OWNER_TRANSFERRED    = "Team Ownership Transferred Successfully."
+	ORGANIZATION_CREATED      = "Organization Created Successfully."
+	ORGANIZATION_MEMBER_ADDED = "Member Added to Organization."
+	REMOVED_FROM_ORGANIZATION = "Member Removed from Organization."
+	PROJECT_CREATED           = "Project Created Successfully."
+	PROJECT_UPDATED           = "Project Updated Successfully."
+	PROJECT_DELETED           = "Project Deleted Successfully."
+	MILESTONE_CREATED         = "Milestone Created Successfully."
+	MILESTONE_UPDATED         = "Milestone Updated Successfully."
+	MILESTONE_DELETED         = "Milestone Deleted Successfully."
+	SPRINT_CREATED            = "Sprint Created Successfully."
+	SPRINT_UPDATED            = "Sprint Updated Successfully."
+	SPRINT_COMPLETED          = "Sprint Completed Successfully."
+	SPRINT_TASKS_ADDED        = "Tasks Planned into Sprint Successfully."
+	SPRINT_TASKS_REMOVED      = "Tasks Moved to Backlog Successfully."
+	NOTIFICATIONS_READ        = "Notifications Marked as Read Successfully."
+)
+
+const (
+	ACCESS_TOKEN_LIFETIME          = time.Hour * 5
+	REFRESH_TOKEN_LIFETIME         = time.Hour * 24 * 7
+	REFRESH_TOKEN_CLEANUP_INTERVAL = time.Hour
+)
+
+const (
+	TOTP_ISSUER                  = "Task Management System"
+	TOTP_DIGITS                  = 6
+	TOTP_PERIOD                  = 30
+	RECOVERY_CODES_COUNT         = 10
+	LOGIN_CHALLENGE_LIFETIME     = time.Minute * 5
+	LOGIN_CHALLENGE_MAX_ATTEMPTS = 5
+	OIDC_STATE_LIFETIME          = time.Minute * 10
+	RATE_LIMIT_WINDOW            = time.Minute
+	LOGIN_IP_RATE_LIMIT          = 20
+	OTP_IP_RATE_LIMIT            = 10
+	LOGIN_MAX_FAILED_ATTEMPTS    = 5
+	LOGIN_FAILURE_WINDOW         = time.Minute * 15
+	LOGIN_LOCKOUT_DURATION       = time.Minute * 15
+	OTP_LIFETIME                 = time.Minute * 5
+	OTP_MAX_VERIFY_ATTEMPTS      = 5
+	OTP_SEND_INTERVAL            = time.Minute
+	OTP_SEND_LIMIT               = 5
+	OTP_SEND_LIMIT_WINDOW        = time.Hour
+	OIDC_HTTP_TIMEOUT            = time.Second * 10
+)
+
+const (
+	PASSWORD_MIN_LENGTH      = 8
+	PASSWORD_TOO_SHORT       = "%s must be at least %d characters long."
+	PASSWORD_NEEDS_UPPERCASE = "%s must contain at least one uppercase letter."
+	PASSWORD_NEEDS_LOWERCASE = "%s must contain at least one lowercase letter."
+	PASSWORD_NEEDS_DIGIT     = "%s must contain at least one digit."
+	PASSWORD_NEEDS_SPECIAL   = "%s must contain at least one special character."
+	PASSWORD_BREACHED        = "%s is too common or found in a data breach, please choose another one."
+	PASSWORD_REUSED          = "%s must not be same as any of last %d passwords."
+)
+
+const (
+	OTP_PURPOSE_PASSWORD_RESET     = "PASSWORD-RESET"
+	OTP_PURPOSE_EMAIL_VERIFICATION = "EMAIL-VERIFICATION"
+	OTP_PURPOSE_EMAIL_CHANGE       = "EMAIL-CHANGE"
+	OTP_DEFAULT_LENGTH             = 6
+	OTP_MIN_LENGTH                 = 6
+	OTP_MAX_LENGTH                 = 10
+	OTP_CLEANUP_INTERVAL           = time.Hour
+	PASSWORD_RESET_TOKEN_LIFETIME  = time.Minute * 10
+)
+
+const (
+	ACCOUNT_DELETION_GRACE_DAYS = 30
+	ACCOUNT_DELETION_INTERVAL   = time.Hour
+	DELETED_USER_FIRST_NAME     = "Deleted"
+	DELETED_USER_LAST_NAME      = "User"
+	DELETED_USER_EMAIL_DOMAIN   = "@deleted.invalid"
+	USER_DATA_EXPORT_JSON       = "json"
+	USER_DATA_EXPORT_ZIP        = "zip"
+	USER_DATA_EXPORT_FILE_NAME  = "user-data"
+)
+
+const (
+	AVATAR_STORAGE_DIRECTORY = "uploads/avatars"
+	AVATAR_BASE_URL          = "/avatars"
+	AVATAR_FORM_FIELD        = "avatar"
+	AVATAR_MAX_UPLOAD_SIZE   = 5 << 20
+	AVATAR_MAX_DIMENSION     = 6000
+	AVATAR_SIZE_SMALL        = 64
+	AVATAR_SIZE_MEDIUM       = 128
+	AVATAR_SIZE_LARGE        = 256
+)
+
+const (
+	DEFAULT_TIMEZONE            = "UTC"
+	DEFAULT_LOCALE              = "en-US"
+	DATE_FORMAT_ISO             = "YYYY-MM-DD"
+	DATE_FORMAT_DAY_FIRST       = "DD/MM/YYYY"
+	DATE_FORMAT_MONTH_FIRST     = "MM/DD/YYYY"
+	WEEK_START_MONDAY           = "MONDAY"
+	WEEK_START_SUNDAY           = "SUNDAY"
+	WEEK_START_SATURDAY         = "SATURDAY"
+	NOTIFICATION_CHANNEL_EMAIL  = "EMAIL"
+	NOTIFICATION_CHANNEL_SOCKET = "SOCKET"
+	NOTIFICATION_CHANNEL_NONE   = "NONE"
+	NOTIFY_TASK_ASSIGNED        = "TASK-ASSIGNED"
+	NOTIFY_TASK_UPDATED         = "TASK-UPDATED"
+	NOTIFY_SECURITY_ALERT       = "SECURITY-ALERT"
+)
+
+const (
+	VISIBILITY_EVERYONE  = "EVERYONE"
+	VISIBILITY_TEAMMATES = "TEAMMATES"
+	VISIBILITY_NOBODY    = "NOBODY"
+)
+
+const (
+	ADMIN_USERS_LISTED               = "USERS-LISTED"
+	ADMIN_USER_DEACTIVATED           = "USER-DEACTIVATED"
+	ADMIN_USER_REACTIVATED           = "USER-REACTIVATED"
+	ADMIN_PASSWORD_RESET_FORCED      = "PASSWORD-RESET-FORCED"
+	ADMIN_SESSIONS_REVOKED           = "SESSIONS-REVOKED"
+	ADMIN_TEAM_VIEWED                = "TEAM-VIEWED"
+	ADMIN_TEAM_OWNERSHIP_TRANSFERRED = "TEAM-OWNERSHIP-TRANSFERRED"
+	ADMIN_AUDIT_LOGS_VIEWED          = "AUDIT-LOGS-VIEWED"
+)
+
+const (
+	ORGANIZATION_ROLE_OWNER  = "OWNER"
+	ORGANIZATION_ROLE_ADMIN  = "ADMIN"
+	ORGANIZATION_ROLE_MEMBER = "MEMBER"
+	ORGANIZATION_HEADER      = "X-Organization-ID"
+)
+
+const (
+	PROJECT_STATUS_ACTIVE = "ACTIVE"
+)
+
+const (
+	SPRINT_STATUS_ACTIVE    = "ACTIVE"
+	SPRINT_STATUS_COMPLETED = "COMPLETED"
+	// number of last completed sprints on which velocity is calculated if it's not asked.
+	SPRINT_VELOCITY_DEFAULT_LIMIT = 5
+)
+
+const (
+	// dates of team analytics range are sent in this format, range covers last weeks up to today if it's not sent.
+	ANALYTICS_DATE_FORMAT        = "2006-01-02"
+	ANALYTICS_DEFAULT_RANGE_DAYS = 84
+	ANALYTICS_MAX_RANGE_DAYS     = 366
+	TEAM_ANALYTICS_CACHE_TTL     = time.Minute * 5
+)
+
+const (
+	// number of tasks in each list of dashboard and number of teams in it.
+	DASHBOARD_TASK_LIMIT = 10
+	DASHBOARD_TEAM_LIMIT = 5
+)
+
+const (
+	AUTO_ASSIGN_NONE         = "NONE"
+	AUTO_ASSIGN_ROUND_ROBIN  = "ROUND-ROBIN"
+	AUTO_ASSIGN_LEAST_LOADED = "LEAST-LOADED"
+)
+
+const (
+	JWT_ALGORITHM_RS256 = "RS256"
+	JWT_ALGORITHM_EDDSA = "EdDSA"
+	JWT_ALGORITHM_HS256 = "HS256"
+	JWT_TYPE_ACCESS     = "access"
+	JWT_TYPE_REFRESH    = "refresh"
+	JWT_KEY_FILE_EXT    = ".pem"
+	UNKNOWN_JWT_KEY     = "Token is Signed by Unknown Key"
+	INVALID_JWT_KEY     = "JWT Key File Contains Unsupported Key"
+)
+
+const (
+	OIDC_SCOPES                 = "openid email profile"
+	OIDC_DISCOVERY_PATH         = "/.well-known/openid-configuration"
+	OIDC_CODE_CHALLENGE_METHOD  = "S256"
+	OIDC_INVALID_ID_TOKEN       = "ID Token of Identity Provider is Invalid"
+	OIDC_TOKEN_EXCHANGE_FAILURE = "Identity Provider Rejected Authorization Code"
+)
+
+const (
+	PERSONAL_ACCESS_TOKEN_PREFIX = "tms_pat_"
+	SCOPE_TASKS_READ             = "tasks:read"
+	SCOPE_TASKS_WRITE            = "tasks:write"
+	SCOPE_TEAMS_READ             = "teams:read"
+	SCOPE_TEAMS_WRITE            = "teams:write"
+	SCOPE_USERS_READ             = "users:read"
+	SCOPE_USERS_WRITE            = "users:write"
+)
+
+const (
+	TEAM_ACTIVITY_EVENT               = "team-activity"
+	TEAM_ACTIVITY_MEMBER_ADDED        = "MEMBER-ADDED"
+	TEAM_ACTIVITY_MEMBER_REMOVED      = "MEMBER-REMOVED"
+	TEAM_ACTIVITY_MEMBER_LEFT         = "MEMBER-LEFT"
+	TEAM_ACTIVITY_TASK_CREATED        = "TASK-CREATED"
+	TEAM_ACTIVITY_TASK_REASSIGNED     = "TASK-REASSIGNED"
+	TEAM_ACTIVITY_TASK_STATUS_CHANGED = "TASK-STATUS-CHANGED"
+	TEAM_ACTIVITY_COMMENT_ADDED       = "COMMENT-ADDED"
+)
+
+const (
+	REDACTED_VALUE         = "********"
+	TASK_CACHE_KEY_PATTERN = "tasks:*"
+	USER_TEAMS_KEY_PATTERN = "user:*:teams"
+)
+
+const (
+	MIGRATION_UP_MARKER               = "-- migrate:up"
+	MIGRATION_DOWN_MARKER             = "-- migrate:down"
+	MIGRATION_NO_TRANSACTION          = "transaction:false"
+	MIGRATION_ADVISORY_LOCK_ID        = 7254031127
+	MIGRATION_LOCK_POLL_INTERVAL      = time.Second
+	MIGRATION_LOCK_STALE_AFTER        = time.Minute * 10
+	MIGRATION_LOCK_HEARTBEAT_INTERVAL = time.Minute
+	COCKROACHDB_VERSION_PREFIX        = "CockroachDB"
 )
 
 const (
 	PG_Duplicate_Error_Code = "23505"
-	PG_NO_ROWS = "no rows in result set"
+	PG_NO_ROWS              = "no rows in result set"
 )
 
 type contextKey string
 
 var (
-	TokenKey        = contextKey("token")
-	UserIdKey       = contextKey("userId")
-	SocketServerKey = contextKey("socketServer")
+	TokenKey            = contextKey("token")
+	TokenClaimsKey      = contextKey("tokenClaims")
+	ScopesKey           = contextKey("scopes")
+	UserIdKey           = contextKey("userId")
+	IsAdminKey          = contextKey("isAdmin")
+	SocketServerKey     = contextKey("socketServer")
+	OrganizationIdKey   = contextKey("organizationId")
+	OrganizationRoleKey = contextKey("organizationRole")
 )
 
 var (
 	TEAM_ID                 = "TeamID"
 	TASK_ID                 = "TaskID"
+	SESSION_ID              = "SessionID"
+	TOKEN_ID                = "TokenID"
+	USER_ID                 = "UserID"
+	ORGANIZATION_ID         = "OrganizationID"
+	PROJECT_ID              = "ProjectID"
+	MILESTONE_ID            = "MilestoneID"
+	SPRINT_ID               = "SprintID"
 	URL_PARAM_CONVERT_ERROR = "strconv.Atoi: parsing"
 )
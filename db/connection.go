@@ -12,13 +12,7 @@ import (
 
 func SetDBConection(flag int) (*pgx.Conn, *redis.Client, *amqp.Connection) {
 	//flag = 0 => main database connection, flag = 1 => test database connection
-	var connConfig *pgx.ConnConfig
-	var err error
-	if flag == 0 {
-		connConfig, err = pgx.ParseConfig(dbConnString())
-	} else {
-		connConfig, err = pgx.ParseConfig(testDbConnString())
-	}
+	connConfig, err := pgx.ParseConfig(ConnString(flag))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -46,6 +40,15 @@ func SetDBConection(flag int) (*pgx.Conn, *redis.Client, *amqp.Connection) {
 	return dbConn, redisClient, rabbitmqConn
 }
 
+// ConnString returns connection url of main database if flag is 0 and of test database otherwise,
+// it is also given to tools like dbmate which connect to database on their own.
+func ConnString(flag int) string {
+	if flag == 0 {
+		return dbConnString()
+	}
+	return testDbConnString()
+}
+
 func dbConnString() string {
 	return "postgresql://" + config.Config.Database.Username + ":" + config.Config.Database.Password + "@" + config.Config.Database.Host + ":" + config.Config.Database.Port + "/" + config.Config.Database.Name + "?sslmode=" + config.Config.Database.SSLMode
 }
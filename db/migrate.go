@@ -0,0 +1,342 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/dto"
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/jackc/pgx/v5"
+)
+
+// migrationFiles are sql files of migrations directory, they are written in dbmate format so that they can still be applied
+// with dbmate. applied versions are kept in schema_migrations table of dbmate, so database migrated by dbmate is picked up as it is.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+type migration struct {
+	Version  string
+	Name     string
+	Up       string
+	Down     string
+	UpInTx   bool
+	DownInTx bool
+}
+
+// MigrateUp applies all embedded migrations which are not applied yet in order of their versions and returns applied versions.
+// each migration is applied in its own transaction along with its version, unless its section is marked with transaction:false.
+func MigrateUp(ctx context.Context, dbConn *pgx.Conn) ([]string, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	appliedVersions := make([]string, 0)
+	err = withMigrationLock(ctx, dbConn, func() error {
+		_, err := dbConn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version VARCHAR(255) PRIMARY KEY)`)
+		if err != nil {
+			return err
+		}
+		err = createCockroachDBTypeAliases(ctx, dbConn)
+		if err != nil {
+			return err
+		}
+		applied, err := getAppliedVersions(ctx, dbConn)
+		if err != nil {
+			return err
+		}
+		for _, m := range migrations {
+			if applied[m.Version] {
+				continue
+			}
+			err = runMigration(ctx, dbConn, m.Up, m.UpInTx, `INSERT INTO schema_migrations (version) VALUES ($1)`, m.Version)
+			if err != nil {
+				return fmt.Errorf("migration %s_%s: %w", m.Version, m.Name, err)
+			}
+			appliedVersions = append(appliedVersions, m.Version)
+		}
+		return nil
+	})
+	return appliedVersions, err
+}
+
+// MigrateDown rolls back latest applied migration and returns its version, version is empty if no migration is applied.
+func MigrateDown(ctx context.Context, dbConn *pgx.Conn) (string, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+
+	var rolledBackVersion string
+	err = withMigrationLock(ctx, dbConn, func() error {
+		_, err := dbConn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version VARCHAR(255) PRIMARY KEY)`)
+		if err != nil {
+			return err
+		}
+		applied, err := getAppliedVersions(ctx, dbConn)
+		if err != nil {
+			return err
+		}
+		for i := len(migrations) - 1; i >= 0; i-- {
+			m := migrations[i]
+			if !applied[m.Version] {
+				continue
+			}
+			err = runMigration(ctx, dbConn, m.Down, m.DownInTx, `DELETE FROM schema_migrations WHERE version = $1`, m.Version)
+			if err != nil {
+				return fmt.Errorf("migration %s_%s: %w", m.Version, m.Name, err)
+			}
+			rolledBackVersion = m.Version
+			return nil
+		}
+		return nil
+	})
+	return rolledBackVersion, err
+}
+
+// GetMigrationStatus returns every embedded migration along with whether it is applied, versions which are applied
+// but not embedded (like migrations of newer binary) are returned at their place without name. it only reads, so it doesn't take lock
+// and every migration is pending if schema_migrations table doesn't exist yet.
+func GetMigrationStatus(ctx context.Context, dbConn *pgx.Conn) ([]dto.MigrationStatus, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := getAppliedVersions(ctx, dbConn)
+	if err != nil {
+		return nil, err
+	}
+	migrationStatus := make([]dto.MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		migrationStatus = append(migrationStatus, dto.MigrationStatus{Version: m.Version, Name: m.Name, Applied: applied[m.Version]})
+		delete(applied, m.Version)
+	}
+	for version := range applied {
+		migrationStatus = append(migrationStatus, dto.MigrationStatus{Version: version, Applied: true})
+	}
+	sort.Slice(migrationStatus, func(i, j int) bool {
+		return migrationStatus[i].Version < migrationStatus[j].Version
+	})
+	return migrationStatus, nil
+}
+
+// loadMigrations parses embedded migration files, version is part of file name before first underscore.
+func loadMigrations() ([]migration, error) {
+	files, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]migration, 0, len(files))
+	for _, file := range files {
+		content, err := migrationFiles.ReadFile(path.Join("migrations", file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, err := parseMigration(file.Name(), string(content))
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// parseMigration splits dbmate migration file into its up and down sections and reads transaction option of each of them.
+func parseMigration(fileName string, content string) (migration, error) {
+	version, name, found := strings.Cut(strings.TrimSuffix(fileName, path.Ext(fileName)), "_")
+	if !found {
+		return migration{}, fmt.Errorf("%s: migration file name must be <version>_<name>.sql", fileName)
+	}
+	if !strings.Contains(content, constant.MIGRATION_UP_MARKER) {
+		return migration{}, fmt.Errorf("%s: %s section not found", fileName, constant.MIGRATION_UP_MARKER)
+	}
+	m := migration{Version: version, Name: name, UpInTx: true, DownInTx: true}
+
+	var section *string
+	var sectionInTx *bool
+	for _, line := range strings.SplitAfter(content, "\n") {
+		trimmedLine := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmedLine, constant.MIGRATION_UP_MARKER):
+			section, sectionInTx = &m.Up, &m.UpInTx
+		case strings.HasPrefix(trimmedLine, constant.MIGRATION_DOWN_MARKER):
+			section, sectionInTx = &m.Down, &m.DownInTx
+		default:
+			if section != nil {
+				*section += line
+			}
+			continue
+		}
+		*sectionInTx = !strings.Contains(trimmedLine, constant.MIGRATION_NO_TRANSACTION)
+	}
+	return m, nil
+}
+
+// runMigration executes section of migration and records its version with given query, both in same transaction if inTx is true.
+// migration sections have many statements, so they are executed without arguments which makes pgx use simple protocol.
+func runMigration(ctx context.Context, dbConn *pgx.Conn, section string, inTx bool, versionQuery string, version string) error {
+	if !inTx {
+		if _, err := dbConn.Exec(ctx, section); err != nil {
+			return err
+		}
+		_, err := dbConn.Exec(ctx, versionQuery, version)
+		return err
+	}
+
+	tx, err := dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, section); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if _, err = tx.Exec(ctx, versionQuery, version); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// getAppliedVersions returns versions recorded in schema_migrations table, table is not created here so that status can be read without writing.
+func getAppliedVersions(ctx context.Context, dbConn *pgx.Conn) (map[string]bool, error) {
+	applied := make(map[string]bool)
+	var tableExists bool
+	err := dbConn.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = 'schema_migrations')`).
+		Scan(&tableExists)
+	if err != nil || !tableExists {
+		return applied, err
+	}
+
+	rows, err := dbConn.Query(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// createCockroachDBTypeAliases creates INT64 and STRING types of cockroachdb as domains on postgres, first migrations were written for
+// cockroachdb only and use them, so they are created here instead of changing already applied migrations. it does nothing on cockroachdb.
+func createCockroachDBTypeAliases(ctx context.Context, dbConn *pgx.Conn) error {
+	cockroachDB, err := isCockroachDB(ctx, dbConn)
+	if err != nil || cockroachDB {
+		return err
+	}
+	_, err = dbConn.Exec(ctx, `DO $$ BEGIN
+		CREATE DOMAIN int64 AS BIGINT;
+	EXCEPTION WHEN duplicate_object THEN NULL;
+	END $$;
+	DO $$ BEGIN
+		CREATE DOMAIN string AS TEXT;
+	EXCEPTION WHEN duplicate_object THEN NULL;
+	END $$;`)
+	return err
+}
+
+// isCockroachDB reports whether connected database is cockroachdb rather than postgres.
+func isCockroachDB(ctx context.Context, dbConn *pgx.Conn) (bool, error) {
+	var version string
+	if err := dbConn.QueryRow(ctx, `SELECT version()`).Scan(&version); err != nil {
+		return false, err
+	}
+	return strings.HasPrefix(version, constant.COCKROACHDB_VERSION_PREFIX), nil
+}
+
+// migrationLockHeartbeatInterval is how often holder of the lock row refreshes it, it is variable so that tests don't have to wait for minutes.
+var migrationLockHeartbeatInterval = constant.MIGRATION_LOCK_HEARTBEAT_INTERVAL
+
+// withMigrationLock runs fn while holding lock which makes concurrent instances wait for each other instead of applying same migrations.
+// postgres gives session level advisory lock, but advisory lock functions of cockroachdb are no-op, so there lock is a row of
+// schema_migrations_lock table which is inserted by the holder and taken over if it is not refreshed within MIGRATION_LOCK_STALE_AFTER.
+// holder refreshes the row from its own connection while fn runs, so that long migration isn't taken over but crashed holder is.
+// database itself is created on cockroachdb if it doesn't exist, like dbmate does, since cockroachdb accepts connection to missing database.
+func withMigrationLock(ctx context.Context, dbConn *pgx.Conn, fn func() error) error {
+	cockroachDB, err := isCockroachDB(ctx, dbConn)
+	if err != nil {
+		return err
+	}
+
+	if !cockroachDB {
+		if _, err := dbConn.Exec(ctx, `SELECT pg_advisory_lock($1)`, constant.MIGRATION_ADVISORY_LOCK_ID); err != nil {
+			return err
+		}
+		defer dbConn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, constant.MIGRATION_ADVISORY_LOCK_ID)
+		return fn()
+	}
+
+	_, err = dbConn.Exec(ctx, `CREATE DATABASE IF NOT EXISTS `+pgx.Identifier{dbConn.Config().Database}.Sanitize())
+	if err != nil {
+		return err
+	}
+	// connection can't be used while fn is using it, so lock is taken and refreshed by separate connection.
+	lockConn, err := pgx.ConnectConfig(ctx, dbConn.Config())
+	if err != nil {
+		return err
+	}
+	defer lockConn.Close(context.Background())
+
+	_, err = lockConn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations_lock (id INT PRIMARY KEY, locked_at TIMESTAMP NOT NULL)`)
+	if err != nil {
+		return err
+	}
+	for {
+		result, err := lockConn.Exec(ctx, `INSERT INTO schema_migrations_lock (id, locked_at) VALUES (1, $1) ON CONFLICT (id) DO UPDATE SET locked_at = excluded.locked_at
+		WHERE schema_migrations_lock.locked_at < $2`, time.Now().UTC(), time.Now().UTC().Add(-constant.MIGRATION_LOCK_STALE_AFTER))
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 1 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(constant.MIGRATION_LOCK_POLL_INTERVAL):
+		}
+	}
+	defer lockConn.Exec(context.Background(), `DELETE FROM schema_migrations_lock WHERE id = 1`)
+
+	stopHeartbeat := make(chan struct{})
+	heartbeatStopped := make(chan struct{})
+	go refreshMigrationLock(lockConn, stopHeartbeat, heartbeatStopped)
+	defer func() {
+		close(stopHeartbeat)
+		<-heartbeatStopped
+	}()
+	return fn()
+}
+
+// refreshMigrationLock updates locked_at of the lock row every migrationLockHeartbeatInterval till stop is closed, it closes stopped once it returns.
+func refreshMigrationLock(lockConn *pgx.Conn, stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+	ticker := time.NewTicker(migrationLockHeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			lockConn.Exec(context.Background(), `UPDATE schema_migrations_lock SET locked_at = $1 WHERE id = 1`, time.Now().UTC())
+		}
+	}
+}
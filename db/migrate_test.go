@@ -0,0 +1,274 @@
+package db
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/chirag1807/task-management-system/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMigration(t *testing.T) {
+	testCases := []struct {
+		TestCaseName string
+		FileName     string
+		Content      string
+		Expected     migration
+		ExpectError  bool
+	}{
+		{
+			TestCaseName: "Migration with Transaction.",
+			FileName:     "20241019210000_add_user_avatar.sql",
+			Content:      "-- migrate:up\nALTER TABLE users ADD COLUMN avatar_key VARCHAR;\n\n-- migrate:down\nALTER TABLE users DROP COLUMN avatar_key;\n",
+			Expected: migration{Version: "20241019210000", Name: "add_user_avatar", Up: "ALTER TABLE users ADD COLUMN avatar_key VARCHAR;\n\n",
+				Down: "ALTER TABLE users DROP COLUMN avatar_key;\n", UpInTx: true, DownInTx: true},
+		},
+		{
+			TestCaseName: "Migration without Transaction.",
+			FileName:     "20241019180000_add_email_verification.sql",
+			Content:      "-- migrate:up transaction:false\nALTER TYPE otppurpose ADD VALUE 'EMAIL-VERIFICATION';\n-- migrate:down\n",
+			Expected: migration{Version: "20241019180000", Name: "add_email_verification", Up: "ALTER TYPE otppurpose ADD VALUE 'EMAIL-VERIFICATION';\n",
+				UpInTx: false, DownInTx: true},
+		},
+		{
+			TestCaseName: "Up Section Not Found.",
+			FileName:     "20241019180000_empty.sql",
+			Content:      "-- migrate:down\n",
+			ExpectError:  true,
+		},
+		{
+			TestCaseName: "Version Not Found.",
+			FileName:     "empty.sql",
+			Content:      "-- migrate:up\n",
+			ExpectError:  true,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			m, err := parseMigration(v.FileName, v.Content)
+			if v.ExpectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, v.Expected, m)
+		})
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := loadMigrations()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+	for i := 1; i < len(migrations); i++ {
+		assert.Less(t, migrations[i-1].Version, migrations[i].Version)
+	}
+}
+
+func TestMigrationsUsePortableTypes(t *testing.T) {
+	cockroachDBOnlyType := regexp.MustCompile(`(?i)\b(INT64|STRING)\b`)
+	migrations, err := loadMigrations()
+	assert.NoError(t, err)
+	for _, m := range migrations {
+		// first migration was written for CockroachDB only, its aliases are created as domains on PostgreSQL by createCockroachDBTypeAliases.
+		if m.Version == "20240313052506" {
+			continue
+		}
+		assert.Empty(t, cockroachDBOnlyType.FindAllString(m.Up+m.Down, -1), "migration %s_%s uses type which only CockroachDB understands", m.Version, m.Name)
+	}
+}
+
+// connectToScratchDatabase creates empty database next to database of given connection string and returns config of connection to it,
+// database is dropped once test finishes. test database is used if connection string is empty.
+func connectToScratchDatabase(t *testing.T, connString string) *pgx.ConnConfig {
+	config.LoadConfig("../.config/", "../.config/secret.json")
+	if connString == constant.EMPTY_STRING {
+		connString = ConnString(1)
+	}
+	ctx := context.Background()
+	testDbConn, err := pgx.Connect(ctx, connString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	connConfig := testDbConn.Config()
+	connConfig.Database = connConfig.Database + "_migrations"
+	databaseName := pgx.Identifier{connConfig.Database}.Sanitize()
+	if _, err := testDbConn.Exec(ctx, `DROP DATABASE IF EXISTS `+databaseName); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := testDbConn.Exec(ctx, `CREATE DATABASE `+databaseName); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		testDbConn.Exec(ctx, `DROP DATABASE IF EXISTS `+databaseName)
+		testDbConn.Close(ctx)
+	})
+	return connConfig
+}
+
+func connect(t *testing.T, connConfig *pgx.ConnConfig) *pgx.Conn {
+	dbConn, err := pgx.ConnectConfig(context.Background(), connConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dbConn
+}
+
+func TestMigrateUpDownAndStatus(t *testing.T) {
+	ctx := context.Background()
+	dbConn := connect(t, connectToScratchDatabase(t, constant.EMPTY_STRING))
+	defer dbConn.Close(ctx)
+	migrations, err := loadMigrations()
+	assert.NoError(t, err)
+	latestVersion := migrations[len(migrations)-1].Version
+
+	// status only reads, so it must not create schema_migrations table.
+	migrationStatus, err := GetMigrationStatus(ctx, dbConn)
+	assert.NoError(t, err)
+	assert.Len(t, migrationStatus, len(migrations))
+	for _, m := range migrationStatus {
+		assert.False(t, m.Applied)
+	}
+	var tableExists bool
+	err = dbConn.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = 'schema_migrations')`).
+		Scan(&tableExists)
+	assert.NoError(t, err)
+	assert.False(t, tableExists)
+
+	appliedVersions, err := MigrateUp(ctx, dbConn)
+	assert.NoError(t, err)
+	assert.Len(t, appliedVersions, len(migrations))
+
+	appliedVersions, err = MigrateUp(ctx, dbConn)
+	assert.NoError(t, err)
+	assert.Empty(t, appliedVersions)
+
+	rolledBackVersion, err := MigrateDown(ctx, dbConn)
+	assert.NoError(t, err)
+	assert.Equal(t, latestVersion, rolledBackVersion)
+
+	migrationStatus, err = GetMigrationStatus(ctx, dbConn)
+	assert.NoError(t, err)
+	for _, m := range migrationStatus {
+		assert.Equal(t, m.Version != latestVersion, m.Applied, m.Version)
+	}
+
+	appliedVersions, err = MigrateUp(ctx, dbConn)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{latestVersion}, appliedVersions)
+}
+
+func TestWithMigrationLock(t *testing.T) {
+	connConfig := connectToScratchDatabase(t, constant.EMPTY_STRING)
+	var holders, maxHolders int32
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		dbConn := connect(t, connConfig)
+		defer dbConn.Close(context.Background())
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := withMigrationLock(context.Background(), dbConn, func() error {
+				currentHolders := atomic.AddInt32(&holders, 1)
+				defer atomic.AddInt32(&holders, -1)
+				for {
+					previousMaxHolders := atomic.LoadInt32(&maxHolders)
+					if currentHolders <= previousMaxHolders || atomic.CompareAndSwapInt32(&maxHolders, previousMaxHolders, currentHolders) {
+						break
+					}
+				}
+				time.Sleep(200 * time.Millisecond)
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), maxHolders)
+}
+
+func TestMigrationLockHeartbeat(t *testing.T) {
+	ctx := context.Background()
+	dbConn := connect(t, connectToScratchDatabase(t, constant.EMPTY_STRING))
+	defer dbConn.Close(ctx)
+	var version string
+	assert.NoError(t, dbConn.QueryRow(ctx, `SELECT version()`).Scan(&version))
+	if !strings.HasPrefix(version, constant.COCKROACHDB_VERSION_PREFIX) {
+		t.Skip("lock row is used only on CockroachDB, PostgreSQL holds advisory lock")
+	}
+
+	migrationLockHeartbeatInterval = 100 * time.Millisecond
+	defer func() { migrationLockHeartbeatInterval = constant.MIGRATION_LOCK_HEARTBEAT_INTERVAL }()
+
+	err := withMigrationLock(ctx, dbConn, func() error {
+		var lockedAt, refreshedAt time.Time
+		if err := dbConn.QueryRow(ctx, `SELECT locked_at FROM schema_migrations_lock WHERE id = 1`).Scan(&lockedAt); err != nil {
+			return err
+		}
+		time.Sleep(500 * time.Millisecond)
+		if err := dbConn.QueryRow(ctx, `SELECT locked_at FROM schema_migrations_lock WHERE id = 1`).Scan(&refreshedAt); err != nil {
+			return err
+		}
+		assert.True(t, refreshedAt.After(lockedAt))
+		return nil
+	})
+	assert.NoError(t, err)
+
+	var lockRows int
+	assert.NoError(t, dbConn.QueryRow(ctx, `SELECT COUNT(*) FROM schema_migrations_lock`).Scan(&lockRows))
+	assert.Equal(t, 0, lockRows)
+}
+
+// TestMockDataOnMigratedDatabase inserts mock data into freshly migrated test database and also into PostgreSQL given by TEST_POSTGRES_URL,
+// so that schema is checked against ids of mock data on both CockroachDB and PostgreSQL.
+func TestMockDataOnMigratedDatabase(t *testing.T) {
+	config.LoadConfig("../.config/", "../.config/secret.json")
+	testCases := []struct {
+		TestCaseName string
+		ConnString   string
+		Skip         bool
+	}{
+		{
+			TestCaseName: "Test Database.",
+		},
+		{
+			TestCaseName: "PostgreSQL.",
+			ConnString:   config.Config.Database.TestPostgresURL,
+			Skip:         config.Config.Database.TestPostgresURL == constant.EMPTY_STRING,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			if v.Skip {
+				t.Skip("TEST_POSTGRES_URL is not set")
+			}
+			ctx := context.Background()
+			dbConn := connect(t, connectToScratchDatabase(t, v.ConnString))
+			defer dbConn.Close(ctx)
+
+			_, err := MigrateUp(ctx, dbConn)
+			assert.NoError(t, err)
+
+			tx, err := dbConn.Begin(ctx)
+			assert.NoError(t, err)
+			tx, err = utils.InsertMockData(tx)
+			assert.NoError(t, err)
+			assert.NoError(t, tx.Commit(ctx))
+
+			var email string
+			assert.NoError(t, dbConn.QueryRow(ctx, `SELECT email FROM users WHERE id = $1`, int64(954488202459119617)).Scan(&email))
+			assert.Equal(t, "dhyey@gmail.com", email)
+			assert.NoError(t, utils.ClearMockData(dbConn))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS task_comments (id SERIAL PRIMARY KEY, task_id BIGINT NOT NULL REFERENCES tasks (id), comment VARCHAR(1000) NOT NULL,
+created_by BIGINT NOT NULL REFERENCES users (id), created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP);
+
+CREATE TYPE teamactivitytype AS ENUM ('MEMBER-ADDED', 'MEMBER-REMOVED', 'MEMBER-LEFT', 'TASK-CREATED', 'TASK-REASSIGNED', 'TASK-STATUS-CHANGED', 'COMMENT-ADDED');
+
+CREATE TABLE IF NOT EXISTS team_activities (
+    id SERIAL PRIMARY KEY,
+    team_id BIGINT NOT NULL REFERENCES teams (id),
+    actor_id BIGINT NOT NULL REFERENCES users (id),
+    activity_type teamactivitytype NOT NULL,
+    task_id BIGINT REFERENCES tasks (id),
+    member_id BIGINT REFERENCES users (id),
+    details JSONB,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS index_fetch_team_activities ON team_activities (team_id, created_at DESC);
+
+-- migrate:down
+DROP INDEX IF EXISTS index_fetch_team_activities;
+DROP TABLE IF EXISTS team_activities;
+DROP TYPE IF EXISTS teamactivitytype;
+DROP TABLE IF EXISTS task_comments;
@@ -0,0 +1,16 @@
+-- migrate:up
+CREATE TYPE autoassignstrategy AS ENUM ('NONE', 'ROUND-ROBIN', 'LEAST-LOADED');
+
+ALTER TABLE teams ADD COLUMN auto_assign_strategy autoassignstrategy NOT NULL DEFAULT 'NONE';
+ALTER TABLE teams ADD COLUMN last_auto_assigned_member BIGINT REFERENCES users (id);
+
+ALTER TABLE team_members ADD COLUMN unavailable_until TIMESTAMP WITHOUT TIME ZONE;
+
+ALTER TABLE tasks ADD COLUMN owner_individual BIGINT REFERENCES users (id);
+
+-- migrate:down
+ALTER TABLE tasks DROP COLUMN owner_individual;
+ALTER TABLE team_members DROP COLUMN unavailable_until;
+ALTER TABLE teams DROP COLUMN last_auto_assigned_member;
+ALTER TABLE teams DROP COLUMN auto_assign_strategy;
+DROP TYPE IF EXISTS autoassignstrategy;
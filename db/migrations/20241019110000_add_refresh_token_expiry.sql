@@ -0,0 +1,12 @@
+-- migrate:up
+ALTER TABLE refresh_tokens ADD COLUMN created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE refresh_tokens ADD COLUMN expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP + INTERVAL '7 days';
+
+CREATE INDEX IF NOT EXISTS index_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX IF NOT EXISTS index_refresh_tokens_expires_at ON refresh_tokens (expires_at);
+
+-- migrate:down
+DROP INDEX IF EXISTS index_refresh_tokens_expires_at;
+DROP INDEX IF EXISTS index_refresh_tokens_user_id;
+ALTER TABLE refresh_tokens DROP COLUMN expires_at;
+ALTER TABLE refresh_tokens DROP COLUMN created_at;
@@ -0,0 +1,31 @@
+-- migrate:up
+-- existing refresh tokens don't belong to any family, so they are dropped and users have to login again.
+DROP TABLE IF EXISTS refresh_tokens;
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    family VARCHAR(64) NOT NULL,
+    refresh_token TEXT NOT NULL,
+    issued_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP + INTERVAL '7 days',
+    used_at TIMESTAMP WITHOUT TIME ZONE,
+    revoked_at TIMESTAMP WITHOUT TIME ZONE,
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    UNIQUE (refresh_token)
+);
+
+CREATE INDEX IF NOT EXISTS index_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX IF NOT EXISTS index_refresh_tokens_family ON refresh_tokens (family);
+CREATE INDEX IF NOT EXISTS index_refresh_tokens_expires_at ON refresh_tokens (expires_at);
+
+-- migrate:down
+DROP TABLE IF EXISTS refresh_tokens;
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (user_id BIGINT NOT NULL REFERENCES users (id), refresh_token TEXT NOT NULL,
+created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP + INTERVAL '7 days');
+
+CREATE INDEX IF NOT EXISTS index_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX IF NOT EXISTS index_refresh_tokens_expires_at ON refresh_tokens (expires_at);
@@ -0,0 +1,21 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    family VARCHAR(64) NOT NULL,
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITHOUT TIME ZONE,
+    UNIQUE (family)
+);
+
+CREATE INDEX IF NOT EXISTS index_user_sessions_user_id ON user_sessions (user_id);
+
+INSERT INTO user_sessions (user_id, family, user_agent, ip_address, created_at, last_used_at)
+SELECT user_id, family, max(user_agent), max(ip_address), min(issued_at), max(issued_at) FROM refresh_tokens GROUP BY user_id, family;
+
+-- migrate:down
+DROP INDEX IF EXISTS index_user_sessions_user_id;
+DROP TABLE IF EXISTS user_sessions;
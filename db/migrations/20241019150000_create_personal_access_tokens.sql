@@ -0,0 +1,19 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    name VARCHAR(100) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    scopes TEXT[] NOT NULL,
+    expires_at TIMESTAMP WITHOUT TIME ZONE,
+    last_used_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITHOUT TIME ZONE,
+    UNIQUE (token_hash)
+);
+
+CREATE INDEX IF NOT EXISTS index_personal_access_tokens_user_id ON personal_access_tokens (user_id);
+
+-- migrate:down
+DROP INDEX IF EXISTS index_personal_access_tokens_user_id;
+DROP TABLE IF EXISTS personal_access_tokens;
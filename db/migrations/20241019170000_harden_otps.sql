@@ -0,0 +1,23 @@
+-- migrate:up
+DROP TABLE IF EXISTS otps;
+
+CREATE TABLE IF NOT EXISTS otps (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    purpose VARCHAR(32) NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS index_otps_email_purpose ON otps (email, purpose);
+CREATE INDEX IF NOT EXISTS index_otps_expires_at ON otps (expires_at);
+
+-- migrate:down
+DROP INDEX IF EXISTS index_otps_expires_at;
+DROP INDEX IF EXISTS index_otps_email_purpose;
+DROP TABLE IF EXISTS otps;
+
+CREATE TABLE IF NOT EXISTS otps (id SERIAL PRIMARY KEY, otp INT8 NOT NULL, otp_expire_time TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+email VARCHAR(255) NOT NULL, is_verified BOOLEAN DEFAULT false);
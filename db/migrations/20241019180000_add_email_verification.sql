@@ -0,0 +1,9 @@
+-- migrate:up transaction:false
+-- accounts created before email verification was introduced are treated as verified, only new accounts start unverified.
+ALTER TABLE users ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE users ALTER COLUMN email_verified SET DEFAULT false;
+ALTER TABLE users ADD COLUMN pending_email VARCHAR(255);
+
+-- migrate:down
+ALTER TABLE users DROP COLUMN pending_email;
+ALTER TABLE users DROP COLUMN email_verified;
@@ -0,0 +1,15 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS password_history (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS index_password_history_user_id ON password_history (user_id, created_at);
+
+INSERT INTO password_history (user_id, password_hash) SELECT id, password FROM users WHERE password IS NOT NULL;
+
+-- migrate:down
+DROP INDEX IF EXISTS index_password_history_user_id;
+DROP TABLE IF EXISTS password_history;
@@ -0,0 +1,10 @@
+-- migrate:up
+ALTER TABLE users ADD COLUMN deletion_scheduled_at TIMESTAMP WITHOUT TIME ZONE;
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP WITHOUT TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS index_users_deletion_scheduled_at ON users (deletion_scheduled_at);
+
+-- migrate:down
+DROP INDEX IF EXISTS index_users_deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN deleted_at;
+ALTER TABLE users DROP COLUMN deletion_scheduled_at;
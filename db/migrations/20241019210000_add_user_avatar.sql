@@ -0,0 +1,5 @@
+-- migrate:up
+ALTER TABLE users ADD COLUMN avatar_key VARCHAR(255);
+
+-- migrate:down
+ALTER TABLE users DROP COLUMN avatar_key;
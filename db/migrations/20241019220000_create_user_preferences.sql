@@ -0,0 +1,18 @@
+-- migrate:up
+CREATE TYPE notificationchannel AS ENUM ('EMAIL', 'SOCKET', 'NONE');
+
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id BIGINT PRIMARY KEY REFERENCES users (id),
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    locale VARCHAR(35) NOT NULL DEFAULT 'en-US',
+    date_format VARCHAR(10) NOT NULL DEFAULT 'YYYY-MM-DD',
+    week_start VARCHAR(9) NOT NULL DEFAULT 'MONDAY',
+    notify_task_assigned notificationchannel NOT NULL DEFAULT 'SOCKET',
+    notify_task_updated notificationchannel NOT NULL DEFAULT 'SOCKET',
+    notify_security_alert notificationchannel NOT NULL DEFAULT 'EMAIL',
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS user_preferences;
+DROP TYPE IF EXISTS notificationchannel;
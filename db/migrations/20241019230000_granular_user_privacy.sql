@@ -0,0 +1,26 @@
+-- migrate:up transaction:false
+-- public users stay visible to everyone, private users were hidden from everyone so all of their settings become nobody.
+CREATE TYPE visibility AS ENUM ('EVERYONE', 'TEAMMATES', 'NOBODY');
+
+ALTER TABLE users ADD COLUMN email_visibility visibility NOT NULL DEFAULT 'EVERYONE';
+ALTER TABLE users ADD COLUMN bio_visibility visibility NOT NULL DEFAULT 'EVERYONE';
+ALTER TABLE users ADD COLUMN teams_visibility visibility NOT NULL DEFAULT 'EVERYONE';
+ALTER TABLE users ADD COLUMN discoverable BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE users ADD COLUMN assignable_by visibility NOT NULL DEFAULT 'EVERYONE';
+
+UPDATE users SET email_visibility = 'NOBODY', bio_visibility = 'NOBODY', teams_visibility = 'NOBODY', discoverable = false, assignable_by = 'NOBODY'
+WHERE privacy = 'PRIVATE';
+
+ALTER TABLE users DROP COLUMN privacy;
+
+-- migrate:down transaction:false
+ALTER TABLE users ADD COLUMN privacy privacy NOT NULL DEFAULT 'PUBLIC';
+
+UPDATE users SET privacy = 'PRIVATE' WHERE discoverable = false;
+
+ALTER TABLE users DROP COLUMN assignable_by;
+ALTER TABLE users DROP COLUMN discoverable;
+ALTER TABLE users DROP COLUMN teams_visibility;
+ALTER TABLE users DROP COLUMN bio_visibility;
+ALTER TABLE users DROP COLUMN email_visibility;
+DROP TYPE IF EXISTS visibility;
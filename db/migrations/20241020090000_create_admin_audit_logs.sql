@@ -0,0 +1,28 @@
+-- migrate:up
+ALTER TABLE users ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN deactivated_at TIMESTAMP WITHOUT TIME ZONE;
+ALTER TABLE users ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TYPE adminauditaction AS ENUM ('USERS-LISTED', 'USER-DEACTIVATED', 'USER-REACTIVATED', 'PASSWORD-RESET-FORCED', 'SESSIONS-REVOKED',
+'TEAM-VIEWED', 'TEAM-OWNERSHIP-TRANSFERRED', 'AUDIT-LOGS-VIEWED');
+
+-- audit logs are append-only, they have no foreign keys so that they outlive users and teams they refer to.
+CREATE TABLE IF NOT EXISTS admin_audit_logs (
+    id SERIAL PRIMARY KEY,
+    admin_id BIGINT NOT NULL,
+    action adminauditaction NOT NULL,
+    target_user_id BIGINT,
+    target_team_id BIGINT,
+    details JSONB,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS index_fetch_admin_audit_logs ON admin_audit_logs (created_at DESC);
+
+-- migrate:down
+DROP INDEX IF EXISTS index_fetch_admin_audit_logs;
+DROP TABLE IF EXISTS admin_audit_logs;
+DROP TYPE IF EXISTS adminauditaction;
+ALTER TABLE users DROP COLUMN password_reset_required;
+ALTER TABLE users DROP COLUMN deactivated_at;
+ALTER TABLE users DROP COLUMN is_admin;
@@ -0,0 +1,48 @@
+-- migrate:up transaction:false
+CREATE TYPE organizationrole AS ENUM ('OWNER', 'ADMIN', 'MEMBER');
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_by BIGINT REFERENCES users (id),
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id BIGINT NOT NULL REFERENCES organizations (id),
+    member_id BIGINT NOT NULL REFERENCES users (id),
+    role organizationrole NOT NULL DEFAULT 'MEMBER',
+    joined_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organization_id, member_id)
+);
+
+CREATE INDEX IF NOT EXISTS index_fetch_organizations_of_member ON organization_members (member_id);
+
+-- existing users, teams and tasks are moved to default organization so that instance keeps working as before,
+-- system administrators become owners of it. its id is generated (not given) so that sequence of postgres stays in step,
+-- and as it is the only organization at this point it is read back by selecting from organizations.
+INSERT INTO organizations (name) VALUES ('Default');
+INSERT INTO organization_members (organization_id, member_id, role)
+SELECT o.id, u.id, CASE WHEN u.is_admin THEN 'OWNER' ELSE 'MEMBER' END::organizationrole FROM organizations AS o, users AS u;
+
+ALTER TABLE teams ADD COLUMN organization_id BIGINT REFERENCES organizations (id);
+ALTER TABLE tasks ADD COLUMN organization_id BIGINT REFERENCES organizations (id);
+
+UPDATE teams SET organization_id = (SELECT id FROM organizations);
+UPDATE tasks SET organization_id = (SELECT id FROM organizations);
+
+ALTER TABLE teams ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE tasks ALTER COLUMN organization_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS index_fetch_teams_of_organization ON teams (organization_id);
+CREATE INDEX IF NOT EXISTS index_fetch_tasks_of_organization ON tasks (organization_id);
+
+-- migrate:down transaction:false
+DROP INDEX IF EXISTS index_fetch_tasks_of_organization;
+DROP INDEX IF EXISTS index_fetch_teams_of_organization;
+ALTER TABLE tasks DROP COLUMN organization_id;
+ALTER TABLE teams DROP COLUMN organization_id;
+DROP INDEX IF EXISTS index_fetch_organizations_of_member;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
+DROP TYPE IF EXISTS organizationrole;
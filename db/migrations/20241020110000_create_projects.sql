@@ -0,0 +1,63 @@
+-- migrate:up
+CREATE TYPE projectstatus AS ENUM ('PLANNED', 'ACTIVE', 'ON-HOLD', 'COMPLETED', 'ARCHIVED');
+
+CREATE TABLE IF NOT EXISTS projects (
+    id SERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations (id),
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(1000) NOT NULL DEFAULT '',
+    owner_id BIGINT NOT NULL REFERENCES users (id),
+    status projectstatus NOT NULL DEFAULT 'ACTIVE',
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS index_fetch_projects_of_organization ON projects (organization_id);
+
+-- users and teams other than owner who can see the project and add tasks to it.
+CREATE TABLE IF NOT EXISTS project_members (
+    project_id BIGINT NOT NULL REFERENCES projects (id),
+    member_id BIGINT NOT NULL REFERENCES users (id),
+    PRIMARY KEY (project_id, member_id)
+);
+
+CREATE TABLE IF NOT EXISTS project_teams (
+    project_id BIGINT NOT NULL REFERENCES projects (id),
+    team_id BIGINT NOT NULL REFERENCES teams (id),
+    PRIMARY KEY (project_id, team_id)
+);
+
+CREATE INDEX IF NOT EXISTS index_fetch_projects_of_member ON project_members (member_id);
+CREATE INDEX IF NOT EXISTS index_fetch_projects_of_team ON project_teams (team_id);
+
+CREATE TABLE IF NOT EXISTS milestones (
+    id SERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL REFERENCES projects (id),
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(1000) NOT NULL DEFAULT '',
+    target_date TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS index_fetch_milestones_of_project ON milestones (project_id, target_date);
+
+ALTER TABLE tasks ADD COLUMN project_id BIGINT REFERENCES projects (id);
+ALTER TABLE tasks ADD COLUMN milestone_id BIGINT REFERENCES milestones (id);
+
+CREATE INDEX IF NOT EXISTS index_fetch_tasks_of_project ON tasks (project_id);
+CREATE INDEX IF NOT EXISTS index_fetch_tasks_of_milestone ON tasks (milestone_id);
+
+-- migrate:down
+DROP INDEX IF EXISTS index_fetch_tasks_of_milestone;
+DROP INDEX IF EXISTS index_fetch_tasks_of_project;
+ALTER TABLE tasks DROP COLUMN milestone_id;
+ALTER TABLE tasks DROP COLUMN project_id;
+DROP INDEX IF EXISTS index_fetch_milestones_of_project;
+DROP TABLE IF EXISTS milestones;
+DROP INDEX IF EXISTS index_fetch_projects_of_team;
+DROP INDEX IF EXISTS index_fetch_projects_of_member;
+DROP TABLE IF EXISTS project_teams;
+DROP TABLE IF EXISTS project_members;
+DROP INDEX IF EXISTS index_fetch_projects_of_organization;
+DROP TABLE IF EXISTS projects;
+DROP TYPE IF EXISTS projectstatus;
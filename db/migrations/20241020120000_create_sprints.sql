@@ -0,0 +1,43 @@
+-- migrate:up
+CREATE TYPE sprintstatus AS ENUM ('PLANNED', 'ACTIVE', 'COMPLETED');
+
+CREATE TABLE IF NOT EXISTS sprints (
+    id SERIAL PRIMARY KEY,
+    team_id BIGINT NOT NULL REFERENCES teams (id),
+    name VARCHAR(255) NOT NULL,
+    goal VARCHAR(1000) NOT NULL DEFAULT '',
+    start_date TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    end_date TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    status sprintstatus NOT NULL DEFAULT 'PLANNED',
+    created_by BIGINT NOT NULL REFERENCES users (id),
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITHOUT TIME ZONE,
+    -- number of tasks in sprint and number of them completed, saved when sprint is completed.
+    planned_tasks INT,
+    completed_tasks INT
+);
+
+CREATE INDEX IF NOT EXISTS index_fetch_sprints_of_team ON sprints (team_id, start_date DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS index_one_active_sprint_of_team ON sprints (team_id) WHERE status = 'ACTIVE';
+
+-- history of planning tasks into sprints, task is in the sprint while removed_at is null. unfinished tasks are removed
+-- when sprint is completed whereas finished ones stay in it.
+CREATE TABLE IF NOT EXISTS sprint_tasks (
+    sprint_id BIGINT NOT NULL REFERENCES sprints (id),
+    task_id BIGINT NOT NULL REFERENCES tasks (id),
+    added_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    removed_at TIMESTAMP WITHOUT TIME ZONE,
+    PRIMARY KEY (sprint_id, task_id)
+);
+
+CREATE INDEX IF NOT EXISTS index_fetch_sprints_of_task ON sprint_tasks (task_id);
+CREATE INDEX IF NOT EXISTS index_fetch_status_history_of_task ON team_activities (task_id, created_at) WHERE activity_type = 'TASK-STATUS-CHANGED';
+
+-- migrate:down
+DROP INDEX IF EXISTS index_fetch_status_history_of_task;
+DROP INDEX IF EXISTS index_fetch_sprints_of_task;
+DROP TABLE IF EXISTS sprint_tasks;
+DROP INDEX IF EXISTS index_one_active_sprint_of_team;
+DROP INDEX IF EXISTS index_fetch_sprints_of_team;
+DROP TABLE IF EXISTS sprints;
+DROP TYPE IF EXISTS sprintstatus;
@@ -0,0 +1,13 @@
+-- migrate:up
+-- audit logs can't be changed or removed even by the application, rows are rejected one by one as cockroachdb has only row level triggers (v24.3+).
+CREATE OR REPLACE FUNCTION reject_admin_audit_log_change() RETURNS TRIGGER LANGUAGE PLpgSQL AS $$
+BEGIN
+    RAISE EXCEPTION 'admin_audit_logs is append-only, % is not allowed', TG_OP;
+END;
+$$;
+
+CREATE TRIGGER admin_audit_logs_append_only BEFORE UPDATE OR DELETE ON admin_audit_logs FOR EACH ROW EXECUTE FUNCTION reject_admin_audit_log_change();
+
+-- migrate:down
+DROP TRIGGER IF EXISTS admin_audit_logs_append_only ON admin_audit_logs;
+DROP FUNCTION IF EXISTS reject_admin_audit_log_change();
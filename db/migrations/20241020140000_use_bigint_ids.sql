@@ -0,0 +1,40 @@
+-- migrate:up
+-- SERIAL is INT8 on CockroachDB but INT4 on PostgreSQL where ids like 954488202459119617 don't fit, so id columns and their sequences
+-- are made BIGINT. it changes nothing on CockroachDB where ids are INT8 already and no sequence is created for SERIAL.
+ALTER TABLE users ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS users_id_seq AS BIGINT;
+ALTER TABLE otps ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS otps_id_seq AS BIGINT;
+ALTER TABLE teams ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS teams_id_seq AS BIGINT;
+ALTER TABLE tasks ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS tasks_id_seq AS BIGINT;
+ALTER TABLE task_comments ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS task_comments_id_seq AS BIGINT;
+ALTER TABLE team_activities ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS team_activities_id_seq AS BIGINT;
+ALTER TABLE refresh_tokens ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS refresh_tokens_id_seq AS BIGINT;
+ALTER TABLE user_sessions ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS user_sessions_id_seq AS BIGINT;
+ALTER TABLE two_factor_recovery_codes ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS two_factor_recovery_codes_id_seq AS BIGINT;
+ALTER TABLE personal_access_tokens ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS personal_access_tokens_id_seq AS BIGINT;
+ALTER TABLE user_identities ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS user_identities_id_seq AS BIGINT;
+ALTER TABLE password_history ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS password_history_id_seq AS BIGINT;
+ALTER TABLE admin_audit_logs ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS admin_audit_logs_id_seq AS BIGINT;
+ALTER TABLE organizations ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS organizations_id_seq AS BIGINT;
+ALTER TABLE projects ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS projects_id_seq AS BIGINT;
+ALTER TABLE milestones ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS milestones_id_seq AS BIGINT;
+ALTER TABLE sprints ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE IF EXISTS sprints_id_seq AS BIGINT;
+
+-- migrate:down
+-- ids may not fit in INT4 anymore, so they are left BIGINT.
@@ -4,15 +4,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/chirag1807/task-management-system/constant"
 	"github.com/gorilla/schema"
 )
 
 type CustomError struct {
-	ErrorCode      string `json:"code" example:"Bad request"`
-	HttpStatusCode int    `json:"-" example:"400"` // json:"-" refers field won't include in response.
-	ErrorMessage   string `json:"error" example:"Corresponding Error Message will Show Here"`
+	ErrorCode      string        `json:"code" example:"Bad request"`
+	HttpStatusCode int           `json:"-" example:"400"` // json:"-" refers field won't include in response.
+	ErrorMessage   string        `json:"error" example:"Corresponding Error Message will Show Here"`
+	RetryAfter     time.Duration `json:"-"` // sent in Retry-After header of 429 response.
 }
 
 // here I implemented error interface's Error() method. so that we can customize error for our project.
@@ -20,6 +22,16 @@ func (c CustomError) Error() string {
 	return c.ErrorMessage
 }
 
+// CreateRateLimitError takes error message and duration after which client can try again and return 429 error in CustomError format.
+func CreateRateLimitError(errorMessage string, retryAfter time.Duration) error {
+	return CustomError{
+		ErrorCode:      http.StatusText(http.StatusTooManyRequests),
+		HttpStatusCode: http.StatusTooManyRequests,
+		ErrorMessage:   errorMessage,
+		RetryAfter:     retryAfter,
+	}
+}
+
 // CreateCustomError takes error message and http status code as parameters and return error in CustomError format.
 func CreateCustomError(errorMessage string, errorCode string, httpStatusCode int) error {
 	return CustomError{
@@ -33,29 +45,77 @@ var (
 	AccessTokenExpired                = CreateCustomError("Access Token is Expired, Please Regenrate It.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 	DuplicateEmailFound               = CreateCustomError("Duplicate Email Found.", http.StatusText(http.StatusConflict), http.StatusConflict)
 	FirstVerifyOTP                    = CreateCustomError("First Verify OTP with Our System", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
-	LeftAllTeamsToMakePrivacyPrivate  = CreateCustomError("You must Left All Teams that You are Part of to Make Your Privacy Private.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 	MemberExist                       = CreateCustomError("Member Already Added in Team.", http.StatusText(http.StatusConflict), http.StatusConflict)
 	NoUserFound                       = CreateCustomError("No User Found for This Request.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
 	NoEmailFound                      = CreateCustomError("No User Registered with This Email ID.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
-	NoOTPIDFound                      = CreateCustomError("No OTP ID Found.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	NoPersonalAccessTokenFound        = CreateCustomError("No Active Personal Access Token Found For This Request.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	NoSessionFound                    = CreateCustomError("No Active Session Found For This Request.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
 	NoTaskFound                       = CreateCustomError("No Task Found For This Request.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	NoTeamFound                       = CreateCustomError("No Team Found For This Request.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
 	NotAllowed                        = CreateCustomError("You are not Allowed to Perform this Task.", http.StatusText(http.StatusForbidden), http.StatusForbidden)
 	NotAMember                        = CreateCustomError("You can not Left the Meeting Because You are Not a Member of This Team.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	NotMemberOfTeam                   = CreateCustomError("You are Not a Member of This Team.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 	OTPVerificationTimeExpired        = CreateCustomError("Sorry, Time for OTP Verification has expired.", http.StatusText(http.StatusGone), http.StatusGone)
 	OTPNotMatched                     = CreateCustomError("You have Entered Wrong OTP, Try Again with Correct OTP.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 	OnlyOneAssignee                   = CreateCustomError("Either Assignee Team or Assignee Individual should be Present", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
-	OnlyPublicMemberAllowed           = CreateCustomError("Only Public Profile Users can be Added in Team.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
-	OnlyPublicUserAssignne            = CreateCustomError("Tasks can be Assgined to Only Public Profile Users.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	OnlyPublicMemberAllowed           = CreateCustomError("Only Users who are Discoverable or Your Teammates can be Added in Team.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	OnlyPublicUserAssignne            = CreateCustomError("This User does not Allow You to Assign Tasks to Them.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	OnlyVerifiedMemberAllowed         = CreateCustomError("Only Users with Verified Email can be Added in Team.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	OnlyVerifiedUserAssignee          = CreateCustomError("Tasks can be Assigned to Only Users with Verified Email.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	NoEmailChangePending              = CreateCustomError("No Email Change is Pending, Update Email in Profile First.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 	OnlyPublicTeamAssignne            = CreateCustomError("Tasks can be Assgined to Only Public Profile Teams.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 	PasswordNotMatched                = CreateCustomError("Password is Incorrect.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	PasswordResetTokenInvalid         = CreateCustomError("Password Reset Token is Invalid, Expired or Already Used, Please Verify OTP Again.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 	PasswordConfirmPasswordNotMatched = CreateCustomError("Password and Confirm Password Not Matched.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 	ProvideValidParams                = CreateCustomError("Please Provide Valid URL Parameter to Proceed Further.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 	ReadBodyError                     = CreateCustomError("Could not Read Request Body, Please Provide Valid Body.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 	RefreshTokenExpired               = CreateCustomError("Access Token is Expired, Please Do Login Again.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 	RefreshTokenError                 = CreateCustomError("Access Token Can't be Regenerated, Please Do Login Again.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 	RefreshTokenNotFound              = CreateCustomError("Refresh Token Not Found.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	RefreshTokenReused                = CreateCustomError("Refresh Token is Already Used, All Sessions of This Login are Revoked. Please Do Login Again.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	RefreshTokenRevoked               = CreateCustomError("Refresh Token is Revoked, Please Do Login Again.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	InsufficientScope                 = CreateCustomError("Personal Access Token doesn't have Scope Required for This Request.", http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	PersonalAccessTokenInvalid        = CreateCustomError("Personal Access Token is Invalid, Expired or Revoked.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	PersonalAccessTokenNotAllowed     = CreateCustomError("Personal Access Token can't be Used for This Request, Please Use Access Token of Login.", http.StatusText(http.StatusForbidden), http.StatusForbidden)
 	TokenNotFound                     = CreateCustomError("Authorization Token Not Found.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	TokenRevoked                      = CreateCustomError("Access Token is Revoked, Please Do Login Again.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	TwoFactorAlreadyEnabled           = CreateCustomError("Two Factor Authentication is Already Enabled.", http.StatusText(http.StatusConflict), http.StatusConflict)
+	TwoFactorNotEnabled               = CreateCustomError("Two Factor Authentication is Not Enabled.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	TwoFactorNotEnrolled              = CreateCustomError("First Enroll for Two Factor Authentication.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	InvalidTwoFactorCode              = CreateCustomError("Two Factor Authentication Code is Invalid.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	LoginChallengeExpired             = CreateCustomError("Login Challenge is Expired or Invalid, Please Do Login Again.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	OIDCNotConfigured                 = CreateCustomError("Single Sign-On Login is Not Configured.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	OIDCStateExpired                  = CreateCustomError("Single Sign-On Login is Expired or Invalid, Please Start Login Again.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	OIDCLoginFailed                   = CreateCustomError("Identity Provider could not Verify Your Login, Please Start Login Again.", http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	OIDCEmailNotVerified              = CreateCustomError("Email is Not Verified by Identity Provider.", http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	InvalidAvatarImage                = CreateCustomError("Avatar Must be a Valid JPEG, PNG or GIF Image of at Most 6000x6000 Pixels.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	AvatarTooLarge                    = CreateCustomError("Avatar Must be Smaller than 5 MB.", http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
 	TaskClosed                        = CreateCustomError("Task Can't be Updated because It is Closed.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	AccountDeactivated                = CreateCustomError("Your Account is Deactivated, Please Contact Administrator.", http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	PasswordResetRequired             = CreateCustomError("Password Reset is Required for Your Account, Please Reset Password with OTP Sent to Your Email.", http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	AdminAccessRequired               = CreateCustomError("Only System Administrators are Allowed to Perform this Task.", http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	AdminCannotDeactivateSelf         = CreateCustomError("You can't Deactivate Your Own Account.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	UserAlreadyDeactivated            = CreateCustomError("User is Already Deactivated.", http.StatusText(http.StatusConflict), http.StatusConflict)
+	UserNotDeactivated                = CreateCustomError("User is Not Deactivated.", http.StatusText(http.StatusConflict), http.StatusConflict)
+	NewOwnerDeactivated               = CreateCustomError("Ownership can't be Transferred to Deactivated User.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	OrganizationRequired              = CreateCustomError("Organization is Required, Send Its ID in X-Organization-ID Header.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	NoOrganizationFound               = CreateCustomError("No Organization Found For This Request.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	OrganizationAdminRequired         = CreateCustomError("Only Owners and Admins of Organization are Allowed to Perform this Task.", http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	OrganizationMemberExist           = CreateCustomError("Member Already Added in Organization.", http.StatusText(http.StatusConflict), http.StatusConflict)
+	NotMemberOfOrganization           = CreateCustomError("User is Not a Member of This Organization.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	LastOrganizationOwner             = CreateCustomError("Organization must have at least One Owner.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	OrganizationMemberOwnsTeams       = CreateCustomError("Member Owns Teams in This Organization, Transfer Their Ownership First.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	OrganizationMemberOwnsProjects    = CreateCustomError("Member Owns Projects in This Organization, Delete Them First.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	NoProjectFound                    = CreateCustomError("No Project Found For This Request.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	NoMilestoneFound                  = CreateCustomError("No Milestone Found For This Request.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	MilestoneNotInProject             = CreateCustomError("Milestone doesn't Belong to Given Project.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	NoSprintFound                     = CreateCustomError("No Sprint Found For This Request.", http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	SprintCompleted                   = CreateCustomError("Sprint is Already Completed.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	SprintAlreadyActive               = CreateCustomError("Team Already has an Active Sprint, Complete It First.", http.StatusText(http.StatusConflict), http.StatusConflict)
+	InvalidSprintDates                = CreateCustomError("End Date of Sprint must be After It's Start Date.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	OnlyOpenTeamTasksInSprint         = CreateCustomError("Only Open Tasks Assigned to The Team can be Planned into It's Sprint.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	TeamAnalyticsNotAllowed           = CreateCustomError("Only Owner of Team and Admins of Organization are Allowed to See Team Analytics.", http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	InvalidAnalyticsRange             = CreateCustomError("From Date must not be After To Date and Range must be at most 366 Days.", http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 )
 
 // HandleJSONUnmarshalError function handles JSON unmarshalling errors, constructs custom error messages,
@@ -3,7 +3,9 @@ package errorhandling
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/chirag1807/task-management-system/config"
 )
@@ -13,6 +15,9 @@ import (
 // it will simply send 'Internal Server Error' as error message and 500 as status code.
 func SendErrorResponse(r *http.Request, w http.ResponseWriter, err error, message string, params ...interface{}) {
 	if error, ok := err.(CustomError); ok {
+		if error.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(error.RetryAfter.Seconds()))))
+		}
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(error.HttpStatusCode)
 		config.LoggerInstance.Warning(err.Error())
@@ -25,9 +30,9 @@ func SendErrorResponse(r *http.Request, w http.ResponseWriter, err error, messag
 		fmt.Println(err)
 		// config.LoggerInstance.Error(r, err, message, params...)
 		err = CustomError{
-			ErrorCode:   http.StatusText(http.StatusInternalServerError),
+			ErrorCode:      http.StatusText(http.StatusInternalServerError),
 			HttpStatusCode: http.StatusInternalServerError,
-			ErrorMessage: "Internal Server Error",
+			ErrorMessage:   "Internal Server Error",
 		}
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusInternalServerError)
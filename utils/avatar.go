@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"net/http"
+
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+	"github.com/chirag1807/task-management-system/utils/storage"
+)
+
+// AvatarSizes are sizes in pixels of square thumbnails which are created for every avatar.
+var AvatarSizes = []int{constant.AVATAR_SIZE_SMALL, constant.AVATAR_SIZE_MEDIUM, constant.AVATAR_SIZE_LARGE}
+
+var avatarContentTypes = map[string]bool{"image/jpeg": true, "image/png": true, "image/gif": true}
+
+// CreateAvatarThumbnails decodes uploaded image and returns png encoded square thumbnails of it by their size,
+// image is cropped from center to make it square. thumbnails are encoded from decoded pixels only, so exif and other metadata of upload is dropped.
+func CreateAvatarThumbnails(data []byte) (map[int][]byte, error) {
+	if !avatarContentTypes[http.DetectContentType(data)] {
+		return nil, errorhandling.InvalidAvatarImage
+	}
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || imageConfig.Width == 0 || imageConfig.Height == 0 ||
+		imageConfig.Width > constant.AVATAR_MAX_DIMENSION || imageConfig.Height > constant.AVATAR_MAX_DIMENSION {
+		return nil, errorhandling.InvalidAvatarImage
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errorhandling.InvalidAvatarImage
+	}
+
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	cropStart := image.Pt(bounds.Min.X+(bounds.Dx()-side)/2, bounds.Min.Y+(bounds.Dy()-side)/2)
+	draw.Draw(square, square.Bounds(), img, cropStart, draw.Src)
+
+	thumbnails := make(map[int][]byte, len(AvatarSizes))
+	for _, size := range AvatarSizes {
+		var thumbnail bytes.Buffer
+		if err := png.Encode(&thumbnail, resizeSquare(square, size)); err != nil {
+			return nil, err
+		}
+		thumbnails[size] = thumbnail.Bytes()
+	}
+	return thumbnails, nil
+}
+
+// resizeSquare scales square image to given size, every pixel of thumbnail is average of source pixels it covers.
+// pixels are premultiplied by alpha in image.RGBA, so averaging them doesn't darken transparent edges.
+func resizeSquare(src *image.RGBA, size int) *image.RGBA {
+	side := src.Bounds().Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0, y1 := y*side/size, max((y+1)*side/size, y*side/size+1)
+		for x := 0; x < size; x++ {
+			x0, x1 := x*side/size, max((x+1)*side/size, x*side/size+1)
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				offset := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(src.Pix[offset])
+					g += uint64(src.Pix[offset+1])
+					b += uint64(src.Pix[offset+2])
+					a += uint64(src.Pix[offset+3])
+					offset += 4
+					count++
+				}
+			}
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / count)
+			dst.Pix[offset+1] = uint8(g / count)
+			dst.Pix[offset+2] = uint8(b / count)
+			dst.Pix[offset+3] = uint8(a / count)
+		}
+	}
+	return dst
+}
+
+// AvatarFileKey returns storage key of thumbnail of given size of the avatar.
+func AvatarFileKey(avatarKey string, size int) string {
+	return fmt.Sprintf("%s-%d.png", avatarKey, size)
+}
+
+// AvatarOf returns urls of thumbnails of the avatar, nil is returned if user has not uploaded any avatar.
+func AvatarOf(avatarKey *string) *response.Avatar {
+	if avatarKey == nil || *avatarKey == constant.EMPTY_STRING {
+		return nil
+	}
+	avatarStorage := storage.Default()
+	return &response.Avatar{
+		Small:  avatarStorage.URL(AvatarFileKey(*avatarKey, constant.AVATAR_SIZE_SMALL)),
+		Medium: avatarStorage.URL(AvatarFileKey(*avatarKey, constant.AVATAR_SIZE_MEDIUM)),
+		Large:  avatarStorage.URL(AvatarFileKey(*avatarKey, constant.AVATAR_SIZE_LARGE)),
+	}
+}
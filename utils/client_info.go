@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/chirag1807/task-management-system/api/model/request"
+)
+
+// GetClientInfo retrieves user agent and ip address of the client from request.
+func GetClientInfo(r *http.Request) request.ClientInfo {
+	ipAddress, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ipAddress = r.RemoteAddr
+	}
+	return request.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IPAddress: ipAddress,
+	}
+}
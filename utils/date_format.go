@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/constant"
+)
+
+var dateLayouts = map[string]string{
+	constant.DATE_FORMAT_ISO:         "2006-01-02",
+	constant.DATE_FORMAT_DAY_FIRST:   "02/01/2006",
+	constant.DATE_FORMAT_MONTH_FIRST: "01/02/2006",
+}
+
+// FormatDateTime renders given time in timezone and date format of the user, times are stored in UTC
+// so timestamps without time zone read from database are rendered correctly too. UTC and iso date format are used if preferences are not valid.
+func FormatDateTime(t time.Time, preferences response.UserPreferences) string {
+	location, err := time.LoadLocation(preferences.Timezone)
+	if err != nil || preferences.Timezone == constant.EMPTY_STRING {
+		location = time.UTC
+	}
+	layout, ok := dateLayouts[preferences.DateFormat]
+	if !ok {
+		layout = dateLayouts[constant.DATE_FORMAT_ISO]
+	}
+	return t.In(location).Format(layout + " 15:04 MST")
+}
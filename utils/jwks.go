@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"sort"
+
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/config"
+)
+
+// CreateJWKS converts public keys of all loaded jwt keys into json web key set, keys are sorted by kid so that response is stable.
+func CreateJWKS() response.JWKS {
+	jwks := response.JWKS{Keys: make([]response.JWK, 0, len(config.JWTKeys))}
+	for _, jwtKey := range config.JWTKeys {
+		jwk := response.JWK{
+			KeyID:     jwtKey.ID,
+			Use:       "sig",
+			Algorithm: jwtKey.Algorithm,
+		}
+		switch publicKey := jwtKey.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		default:
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID
+	})
+	return jwks
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"strconv"
 	"time"
@@ -10,37 +12,191 @@ import (
 	"github.com/golang-jwt/jwt"
 )
 
+// JWTTokenClaims holds claims of verified jwt token which are needed after verification, like for revoking the token.
+type JWTTokenClaims struct {
+	UserID    int64
+	SessionID int64
+	TokenID   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
 // CreateJWTToken uses golang-jwt package to generate jwt token and return that token as string.
-// Here it takes token expire time and user id and add them to token as claims.
-func CreateJWTToken(tokenExpiryTime time.Time, userId int64) (string, error) {
-	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"userId": strconv.FormatInt(userId, 10),
-		"exp":    tokenExpiryTime.Unix(),
-	})
-
-	token, err := jwtToken.SignedString([]byte(config.JWtSecretKey.SecretKey))
+// Here it takes token type (access or refresh), token expire time, user id and session id and add them to token as claims along with unique token id (jti), issued time, issuer and audience.
+// type is added as typ claim so that refresh token can't be used as access token and vice versa.
+// token is signed by configured signing key (RS256 or EdDSA) and its id is added to kid header, so that verifier can pick right key during key rotation.
+// if no key directory is configured then token is signed by HS256 secret of secret.json as before.
+func CreateJWTToken(tokenType string, tokenExpiryTime time.Time, userId int64, sessionId int64) (string, error) {
+	tokenId, err := CreateRandomID()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+
+	claims := jwt.MapClaims{
+		"sub": strconv.FormatInt(userId, 10),
+		"sid": strconv.FormatInt(sessionId, 10),
+		"jti": tokenId,
+		"typ": tokenType,
+		"iat": time.Now().Unix(),
+		"exp": tokenExpiryTime.Unix(),
+	}
+	return signJWTClaims(claims)
+}
+
+// CreatePurposeToken creates short lived jwt token which can be used only for given purpose like password reset, it carries purpose claim
+// so VerifyJWTToken rejects it and it can't be used as access token, and access token can't be used in place of it.
+func CreatePurposeToken(purpose string, userId int64, lifetime time.Duration) (string, error) {
+	tokenId, err := CreateRandomID()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+
+	claims := jwt.MapClaims{
+		"sub":     strconv.FormatInt(userId, 10),
+		"jti":     tokenId,
+		"purpose": purpose,
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(lifetime).Unix(),
+	}
+	return signJWTClaims(claims)
+}
+
+// signJWTClaims adds issuer and audience to claims and signs them by configured signing key, or by HS256 secret if no key directory is configured.
+func signJWTClaims(claims jwt.MapClaims) (string, error) {
+	if config.Config.JWT.Issuer != constant.EMPTY_STRING {
+		claims["iss"] = config.Config.JWT.Issuer
+	}
+	if config.Config.JWT.Audience != constant.EMPTY_STRING {
+		claims["aud"] = config.Config.JWT.Audience
+	}
+
+	signingKey, ok := config.JWTKeys[config.Config.JWT.SigningKeyID]
+	if !ok {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(config.JWtSecretKey.SecretKey))
+	}
+
+	jwtToken := jwt.NewWithClaims(jwt.GetSigningMethod(signingKey.Algorithm), claims)
+	jwtToken.Header["kid"] = signingKey.ID
+	token, err := jwtToken.SignedString(signingKey.PrivateKey)
 	if err != nil {
 		return constant.EMPTY_STRING, err
 	}
 	return token, nil
 }
 
-// VerifyJWTToken takes token as parameter, verifies it and return userId and nil in case of token verified successfully and 0 and error if token verification failed.
-func VerifyJWTToken(token string) (int64, error) {
-	jwtToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		return []byte(config.JWtSecretKey.SecretKey), nil
-	})
+// VerifyJWTToken takes token as parameter, verifies it and return its claims and nil in case of token verified successfully and empty claims and error if token verification failed.
+// key is picked by kid header of the token and algorithm of the token must be algorithm of that key, token without kid is accepted only if it is signed by HS256 secret
+// which is kept for tokens issued before key directory was configured, set JWT_DISABLE_LEGACY_HS256 once they are expired.
+// token carrying purpose claim is rejected as it is issued by CreatePurposeToken for single purpose only, and token of other type than given type is rejected.
+func VerifyJWTToken(token string, tokenType string) (JWTTokenClaims, error) {
+	claims, err := parseJWTToken(token)
 	if err != nil {
-		return 0, err
+		return JWTTokenClaims{}, err
+	}
+	if _, ok := claims["purpose"]; ok {
+		return JWTTokenClaims{}, errors.New(constant.INVALID_CLAIMS)
+	}
+	tokenClaims := getJWTTokenClaims(claims)
+	if getJWTTokenType(claims, tokenClaims) != tokenType {
+		return JWTTokenClaims{}, errors.New(constant.INVALID_CLAIMS)
+	}
+	return tokenClaims, nil
+}
+
+// getJWTTokenType returns typ claim of the token, tokens issued before typ claim was added are told apart by their lifetime
+// as only refresh token lives longer than access token.
+func getJWTTokenType(claims jwt.MapClaims, tokenClaims JWTTokenClaims) string {
+	if tokenType, ok := claims["typ"].(string); ok {
+		return tokenType
+	}
+	if tokenClaims.ExpiresAt.Sub(tokenClaims.IssuedAt) > constant.ACCESS_TOKEN_LIFETIME {
+		return constant.JWT_TYPE_REFRESH
+	}
+	return constant.JWT_TYPE_ACCESS
+}
+
+// VerifyPurposeToken verifies token created by CreatePurposeToken and returns its claims only if token is issued for given purpose.
+func VerifyPurposeToken(token string, purpose string) (JWTTokenClaims, error) {
+	claims, err := parseJWTToken(token)
+	if err != nil {
+		return JWTTokenClaims{}, err
+	}
+	if tokenPurpose, _ := claims["purpose"].(string); tokenPurpose != purpose {
+		return JWTTokenClaims{}, errors.New(constant.INVALID_CLAIMS)
+	}
+	return getJWTTokenClaims(claims), nil
+}
+
+// parseJWTToken verifies signature, expiry, issuer and audience of the token and returns its claims.
+func parseJWTToken(token string) (jwt.MapClaims, error) {
+	jwtToken, err := jwt.Parse(token, getJWTVerificationKey)
+	if err != nil {
+		return nil, err
 	}
 	if !jwtToken.Valid {
-		return 0, errors.New(constant.INVALID_TOKEN)
+		return nil, errors.New(constant.INVALID_TOKEN)
 	}
 	claims, ok := jwtToken.Claims.(jwt.MapClaims)
 	if !ok {
-		return 0, errors.New(constant.INVALID_CLAIMS)
+		return nil, errors.New(constant.INVALID_CLAIMS)
+	}
+	if config.Config.JWT.Issuer != constant.EMPTY_STRING && !claims.VerifyIssuer(config.Config.JWT.Issuer, true) {
+		return nil, errors.New(constant.INVALID_CLAIMS)
+	}
+	if config.Config.JWT.Audience != constant.EMPTY_STRING && !claims.VerifyAudience(config.Config.JWT.Audience, true) {
+		return nil, errors.New(constant.INVALID_CLAIMS)
+	}
+	return claims, nil
+}
+
+// getJWTTokenClaims converts map claims of verified token into JWTTokenClaims.
+func getJWTTokenClaims(claims jwt.MapClaims) JWTTokenClaims {
+	userIdFromClaims, ok := claims["sub"].(string)
+	if !ok {
+		// tokens issued before standard claims were added carry user id in userId claim.
+		userIdFromClaims, _ = claims["userId"].(string)
 	}
-	userIdFromClaims, _ := claims["userId"].(string)
 	userId, _ := strconv.ParseInt(userIdFromClaims, 10, 64)
-	return userId, nil
+	sessionIdFromClaims, _ := claims["sid"].(string)
+	sessionId, _ := strconv.ParseInt(sessionIdFromClaims, 10, 64)
+	tokenId, _ := claims["jti"].(string)
+	issuedAt, _ := claims["iat"].(float64)
+	expiresAt, _ := claims["exp"].(float64)
+	return JWTTokenClaims{
+		UserID:    userId,
+		SessionID: sessionId,
+		TokenID:   tokenId,
+		IssuedAt:  time.Unix(int64(issuedAt), 0),
+		ExpiresAt: time.Unix(int64(expiresAt), 0),
+	}
+}
+
+// getJWTVerificationKey returns public key of the token's kid, it rejects token whose algorithm doesn't match with algorithm of the key
+// so that token can't be forged by signing it with public key as HMAC secret. token without kid is verified by HS256 secret unless it is disabled by JWT_DISABLE_LEGACY_HS256.
+func getJWTVerificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == constant.EMPTY_STRING {
+		if config.Config.JWT.DisableLegacyHS256 || token.Method.Alg() != constant.JWT_ALGORITHM_HS256 || config.JWtSecretKey.SecretKey == constant.EMPTY_STRING {
+			return nil, errors.New(constant.INVALID_TOKEN)
+		}
+		return []byte(config.JWtSecretKey.SecretKey), nil
+	}
+
+	jwtKey, ok := config.JWTKeys[kid]
+	if !ok {
+		return nil, errors.New(constant.UNKNOWN_JWT_KEY)
+	}
+	if token.Method.Alg() != jwtKey.Algorithm {
+		return nil, errors.New(constant.INVALID_TOKEN)
+	}
+	return jwtKey.PublicKey, nil
+}
+
+// CreateRandomID generates random hex string which is used as jti claim of the token and as refresh token family.
+func CreateRandomID() (string, error) {
+	tokenId := make([]byte, 16)
+	if _, err := rand.Read(tokenId); err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	return hex.EncodeToString(tokenId), nil
 }
@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/chirag1807/task-management-system/api/model/dto"
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+// setJWTTestKeys configures rsa key "rsa-key" and ed25519 key "ed-key" of which ed-key signs new tokens, and
+// retired key "old-key" of which only public key is kept. it returns private keys of all of them.
+func setJWTTestKeys(t *testing.T) (*rsa.PrivateKey, ed25519.PrivateKey, ed25519.PrivateKey) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	config.JWTKeys = map[string]dto.JWTKey{
+		"rsa-key": {ID: "rsa-key", Algorithm: constant.JWT_ALGORITHM_RS256, PrivateKey: rsaKey, PublicKey: &rsaKey.PublicKey},
+		"ed-key":  {ID: "ed-key", Algorithm: constant.JWT_ALGORITHM_EDDSA, PrivateKey: edKey, PublicKey: edKey.Public()},
+		"old-key": {ID: "old-key", Algorithm: constant.JWT_ALGORITHM_EDDSA, PublicKey: oldKey.Public()},
+	}
+	config.Config.JWT = dto.JWT{SigningKeyID: "ed-key", Issuer: "task-management-system", Audience: "task-management-system"}
+	config.JWtSecretKey = dto.JWTSecret{SecretKey: "legacy-secret"}
+	t.Cleanup(func() {
+		config.JWTKeys = nil
+		config.Config.JWT = dto.JWT{}
+		config.JWtSecretKey = dto.JWTSecret{}
+	})
+	return rsaKey, edKey, oldKey
+}
+
+// signTestToken signs access token claims by given method and key, kid header is added if it is not empty.
+func signTestToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, issuer string, audience string) string {
+	claims := jwt.MapClaims{
+		"sub": "954488202459119617",
+		"sid": "954537852771565573",
+		"jti": "test-token",
+		"typ": constant.JWT_TYPE_ACCESS,
+		"iss": issuer,
+		"aud": audience,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	jwtToken := jwt.NewWithClaims(method, claims)
+	if kid != constant.EMPTY_STRING {
+		jwtToken.Header["kid"] = kid
+	}
+	token, err := jwtToken.SignedString(key)
+	assert.NoError(t, err)
+	return token
+}
+
+func TestCreateAndVerifyJWTToken(t *testing.T) {
+	setJWTTestKeys(t)
+
+	accessToken, err := CreateJWTToken(constant.JWT_TYPE_ACCESS, time.Now().Add(constant.ACCESS_TOKEN_LIFETIME), 954488202459119617, 954537852771565573)
+	assert.NoError(t, err)
+	jwtToken, _, err := new(jwt.Parser).ParseUnverified(accessToken, jwt.MapClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "ed-key", jwtToken.Header["kid"])
+
+	claims, err := VerifyJWTToken(accessToken, constant.JWT_TYPE_ACCESS)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(954488202459119617), claims.UserID)
+	assert.Equal(t, int64(954537852771565573), claims.SessionID)
+
+	_, err = VerifyJWTToken(accessToken, constant.JWT_TYPE_REFRESH)
+	assert.EqualError(t, err, constant.INVALID_CLAIMS)
+
+	refreshToken, err := CreateJWTToken(constant.JWT_TYPE_REFRESH, time.Now().Add(constant.REFRESH_TOKEN_LIFETIME), 954488202459119617, 954537852771565573)
+	assert.NoError(t, err)
+	_, err = VerifyJWTToken(refreshToken, constant.JWT_TYPE_ACCESS)
+	assert.EqualError(t, err, constant.INVALID_CLAIMS)
+	_, err = VerifyJWTToken(refreshToken, constant.JWT_TYPE_REFRESH)
+	assert.NoError(t, err)
+
+	purposeToken, err := CreatePurposeToken(constant.OTP_PURPOSE_PASSWORD_RESET, 954488202459119617, constant.PASSWORD_RESET_TOKEN_LIFETIME)
+	assert.NoError(t, err)
+	_, err = VerifyJWTToken(purposeToken, constant.JWT_TYPE_ACCESS)
+	assert.EqualError(t, err, constant.INVALID_CLAIMS)
+}
+
+func TestGetJWTVerificationKey(t *testing.T) {
+	rsaKey, edKey, oldKey := setJWTTestKeys(t)
+	rsaPublicKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: func() []byte {
+		publicKey, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+		assert.NoError(t, err)
+		return publicKey
+	}()})
+
+	testCases := []struct {
+		TestCaseName       string
+		Token              string
+		DisableLegacyHS256 bool
+		Expected           interface{}
+	}{
+		{
+			TestCaseName: "Token Verified by RSA Key of Its Kid.",
+			Token:        signTestToken(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, "task-management-system", "task-management-system"),
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "Token Verified by EdDSA Key of Its Kid.",
+			Token:        signTestToken(t, jwt.SigningMethodEdDSA, "ed-key", edKey, "task-management-system", "task-management-system"),
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "Token Signed by Other Key than Its Kid.",
+			Token:        signTestToken(t, jwt.SigningMethodEdDSA, "ed-key", oldKey, "task-management-system", "task-management-system"),
+			Expected:     "ed25519: verification error",
+		},
+		{
+			TestCaseName: "Token Verified by Public Key of Retired Key.",
+			Token:        signTestToken(t, jwt.SigningMethodEdDSA, "old-key", oldKey, "task-management-system", "task-management-system"),
+			Expected:     nil,
+		},
+		{
+			TestCaseName: "HS256 Token Signed by RSA Public Key.",
+			Token:        signTestToken(t, jwt.SigningMethodHS256, "rsa-key", rsaPublicKeyPEM, "task-management-system", "task-management-system"),
+			Expected:     constant.INVALID_TOKEN,
+		},
+		{
+			TestCaseName: "HS256 Token without Kid Signed by RSA Public Key.",
+			Token:        signTestToken(t, jwt.SigningMethodHS256, constant.EMPTY_STRING, rsaPublicKeyPEM, "task-management-system", "task-management-system"),
+			Expected:     "signature is invalid",
+		},
+		{
+			TestCaseName: "Token Algorithm Doesn't Match with Key.",
+			Token:        signTestToken(t, jwt.SigningMethodEdDSA, "rsa-key", edKey, "task-management-system", "task-management-system"),
+			Expected:     constant.INVALID_TOKEN,
+		},
+		{
+			TestCaseName: "Token Signed by Unknown Key.",
+			Token:        signTestToken(t, jwt.SigningMethodEdDSA, "unknown-key", edKey, "task-management-system", "task-management-system"),
+			Expected:     constant.UNKNOWN_JWT_KEY,
+		},
+		{
+			TestCaseName: "Token Issued by Other Issuer.",
+			Token:        signTestToken(t, jwt.SigningMethodEdDSA, "ed-key", edKey, "other-issuer", "task-management-system"),
+			Expected:     constant.INVALID_CLAIMS,
+		},
+		{
+			TestCaseName: "Token Issued for Other Audience.",
+			Token:        signTestToken(t, jwt.SigningMethodEdDSA, "ed-key", edKey, "task-management-system", "other-audience"),
+			Expected:     constant.INVALID_CLAIMS,
+		},
+		{
+			TestCaseName: "Legacy HS256 Token without Kid.",
+			Token:        signTestToken(t, jwt.SigningMethodHS256, constant.EMPTY_STRING, []byte("legacy-secret"), "task-management-system", "task-management-system"),
+			Expected:     nil,
+		},
+		{
+			TestCaseName:       "Legacy HS256 Token without Kid When It is Disabled.",
+			Token:              signTestToken(t, jwt.SigningMethodHS256, constant.EMPTY_STRING, []byte("legacy-secret"), "task-management-system", "task-management-system"),
+			DisableLegacyHS256: true,
+			Expected:           constant.INVALID_TOKEN,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			config.Config.JWT.DisableLegacyHS256 = v.DisableLegacyHS256
+			_, err := VerifyJWTToken(v.Token, constant.JWT_TYPE_ACCESS)
+			if v.Expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, v.Expected.(string))
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/chirag1807/task-management-system/api/model/dto"
+	"github.com/chirag1807/task-management-system/api/model/response"
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/golang-jwt/jwt"
+)
+
+var oidcHTTPClient = &http.Client{Timeout: constant.OIDC_HTTP_TIMEOUT}
+
+// GetOIDCDiscoveryDocument fetches discovery document of configured identity provider,
+// issuer of the document must be same as configured issuer otherwise its endpoints can't be trusted.
+func GetOIDCDiscoveryDocument() (dto.OIDCDiscoveryDocument, error) {
+	var discoveryDocument dto.OIDCDiscoveryDocument
+	issuerURL := strings.TrimSuffix(config.Config.OIDC.IssuerURL, "/")
+	err := getOIDCJSON(issuerURL+constant.OIDC_DISCOVERY_PATH, &discoveryDocument)
+	if err != nil {
+		return dto.OIDCDiscoveryDocument{}, err
+	}
+	if strings.TrimSuffix(discoveryDocument.Issuer, "/") != issuerURL {
+		return dto.OIDCDiscoveryDocument{}, fmt.Errorf("discovery document issuer %q doesn't match %q", discoveryDocument.Issuer, issuerURL)
+	}
+	return discoveryDocument, nil
+}
+
+// CreatePKCECodeChallenge returns S256 code challenge of given code verifier as described in RFC 7636.
+func CreatePKCECodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// CreateOIDCAuthorizationURL returns url of identity provider where user is redirected for login,
+// state, nonce and code challenge are added so that callback can be bound to this login.
+func CreateOIDCAuthorizationURL(discoveryDocument dto.OIDCDiscoveryDocument, state string, nonce string, codeVerifier string) (string, error) {
+	authorizationURL, err := url.Parse(discoveryDocument.AuthorizationEndpoint)
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	query := authorizationURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", config.Config.OIDC.ClientID)
+	query.Set("redirect_uri", config.Config.OIDC.RedirectURL)
+	query.Set("scope", constant.OIDC_SCOPES)
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CreatePKCECodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", constant.OIDC_CODE_CHALLENGE_METHOD)
+	authorizationURL.RawQuery = query.Encode()
+	return authorizationURL.String(), nil
+}
+
+// ExchangeOIDCAuthorizationCode sends authorization code along with code verifier to token endpoint of identity provider and returns id token.
+func ExchangeOIDCAuthorizationCode(discoveryDocument dto.OIDCDiscoveryDocument, code string, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", config.Config.OIDC.RedirectURL)
+	form.Set("client_id", config.Config.OIDC.ClientID)
+	form.Set("client_secret", config.Config.OIDC.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	res, err := oidcHTTPClient.PostForm(discoveryDocument.TokenEndpoint, form)
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return constant.EMPTY_STRING, fmt.Errorf("%s: %s", constant.OIDC_TOKEN_EXCHANGE_FAILURE, res.Status)
+	}
+
+	var tokenResponse struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&tokenResponse); err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	if tokenResponse.IDToken == constant.EMPTY_STRING {
+		return constant.EMPTY_STRING, errors.New(constant.OIDC_INVALID_ID_TOKEN)
+	}
+	return tokenResponse.IDToken, nil
+}
+
+// VerifyOIDCIDToken verifies signature of id token with keys of identity provider's jwks, then it checks issuer, audience and nonce
+// so that token issued for other client or for other login can't be used here.
+func VerifyOIDCIDToken(discoveryDocument dto.OIDCDiscoveryDocument, idToken string, nonce string) (dto.OIDCClaims, error) {
+	var jwks response.JWKS
+	err := getOIDCJSON(discoveryDocument.JWKSURI, &jwks)
+	if err != nil {
+		return dto.OIDCClaims{}, err
+	}
+
+	jwtToken, err := jwt.Parse(idToken, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		for _, jwk := range jwks.Keys {
+			if kid != constant.EMPTY_STRING && jwk.KeyID != kid {
+				continue
+			}
+			if jwk.Algorithm != constant.EMPTY_STRING && jwk.Algorithm != token.Method.Alg() {
+				continue
+			}
+			return parseJWK(jwk, token.Method.Alg())
+		}
+		return nil, errors.New(constant.UNKNOWN_JWT_KEY)
+	})
+	if err != nil {
+		return dto.OIDCClaims{}, err
+	}
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !ok || !jwtToken.Valid {
+		return dto.OIDCClaims{}, errors.New(constant.OIDC_INVALID_ID_TOKEN)
+	}
+	if !claims.VerifyIssuer(discoveryDocument.Issuer, true) || !claims.VerifyAudience(config.Config.OIDC.ClientID, true) {
+		return dto.OIDCClaims{}, errors.New(constant.OIDC_INVALID_ID_TOKEN)
+	}
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return dto.OIDCClaims{}, errors.New(constant.OIDC_INVALID_ID_TOKEN)
+	}
+
+	oidcClaims := dto.OIDCClaims{Issuer: discoveryDocument.Issuer}
+	oidcClaims.Subject, _ = claims["sub"].(string)
+	oidcClaims.Email, _ = claims["email"].(string)
+	oidcClaims.GivenName, _ = claims["given_name"].(string)
+	oidcClaims.FamilyName, _ = claims["family_name"].(string)
+	// some identity providers send email_verified as string.
+	switch emailVerified := claims["email_verified"].(type) {
+	case bool:
+		oidcClaims.EmailVerified = emailVerified
+	case string:
+		oidcClaims.EmailVerified = emailVerified == "true"
+	}
+	if oidcClaims.Subject == constant.EMPTY_STRING {
+		return dto.OIDCClaims{}, errors.New(constant.OIDC_INVALID_ID_TOKEN)
+	}
+	return oidcClaims, nil
+}
+
+// parseJWK converts json web key into public key, key type must match with algorithm of the token.
+func parseJWK(jwk response.JWK, algorithm string) (interface{}, error) {
+	switch {
+	case jwk.KeyType == "RSA" && strings.HasPrefix(algorithm, "RS"):
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case jwk.KeyType == "OKP" && jwk.Curve == "Ed25519" && algorithm == constant.JWT_ALGORITHM_EDDSA:
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, errors.New(constant.UNKNOWN_JWT_KEY)
+}
+
+func getOIDCJSON(url string, v interface{}) error {
+	res, err := oidcHTTPClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, res.Status)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// CreatePKCECodeVerifier generates high entropy code verifier of 43 characters as required by RFC 7636.
+func CreatePKCECodeVerifier() (string, error) {
+	codeVerifier := make([]byte, 32)
+	if _, err := rand.Read(codeVerifier); err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	return base64.RawURLEncoding.EncodeToString(codeVerifier), nil
+}
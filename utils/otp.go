@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+)
+
+// OTPLength returns configured length of otp, it falls back to default length if configured length is out of allowed range.
+func OTPLength() int {
+	length := config.Config.OTP.Length
+	if length < constant.OTP_MIN_LENGTH || length > constant.OTP_MAX_LENGTH {
+		return constant.OTP_DEFAULT_LENGTH
+	}
+	return length
+}
+
+// GenerateOTP generates numeric otp of given length by crypto/rand, so that it can't be predicted like math/rand.
+func GenerateOTP(length int) (string, error) {
+	otp := make([]byte, length)
+	for i := range otp {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return constant.EMPTY_STRING, err
+		}
+		otp[i] = byte('0' + digit.Int64())
+	}
+	return string(otp), nil
+}
+
+// HashOTP returns hmac-sha256 of otp along with email and purpose, only this hash is stored in database.
+// binding email and purpose to the hash means that otp issued for one email or purpose can't be used for another.
+func HashOTP(otp string, email string, purpose string) string {
+	secretKey := config.JWtSecretKey.OTPSecretKey
+	if secretKey == constant.EMPTY_STRING {
+		secretKey = config.JWtSecretKey.SecretKey
+	}
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(purpose + ":" + email + ":" + otp))
+	return hex.EncodeToString(mac.Sum(nil))
+}
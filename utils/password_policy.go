@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+	errorhandling "github.com/chirag1807/task-management-system/error"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var breachedPasswords map[string]struct{}
+var loadBreachedPasswordsOnce sync.Once
+
+// ValidatePasswordPolicy checks password against configured password policy and returns RequestDataValidationError
+// having one error per violated rule for given parameter name, nil is returned if password follows every rule.
+func ValidatePasswordPolicy(parameterName string, password string) error {
+	policy := config.Config.PasswordPolicy
+	minLength := policy.MinLength
+	if minLength < constant.PASSWORD_MIN_LENGTH {
+		minLength = constant.PASSWORD_MIN_LENGTH
+	}
+
+	var hasUppercase, hasLowercase, hasDigit, hasSpecial bool
+	for _, c := range password {
+		switch {
+		case unicode.IsUpper(c):
+			hasUppercase = true
+		case unicode.IsLower(c):
+			hasLowercase = true
+		case unicode.IsDigit(c):
+			hasDigit = true
+		case unicode.IsPunct(c) || unicode.IsSymbol(c) || unicode.IsSpace(c):
+			hasSpecial = true
+		}
+	}
+
+	var errors []errorhandling.InvalidRequestData
+	addError := func(message string) {
+		errors = append(errors, errorhandling.InvalidRequestData{ParameterName: parameterName, ErrorMessage: message})
+	}
+	if utf8.RuneCountInString(password) < minLength {
+		addError(fmt.Sprintf(constant.PASSWORD_TOO_SHORT, parameterName, minLength))
+	}
+	if policy.RequireUppercase && !hasUppercase {
+		addError(fmt.Sprintf(constant.PASSWORD_NEEDS_UPPERCASE, parameterName))
+	}
+	if policy.RequireLowercase && !hasLowercase {
+		addError(fmt.Sprintf(constant.PASSWORD_NEEDS_LOWERCASE, parameterName))
+	}
+	if policy.RequireDigit && !hasDigit {
+		addError(fmt.Sprintf(constant.PASSWORD_NEEDS_DIGIT, parameterName))
+	}
+	if policy.RequireSpecial && !hasSpecial {
+		addError(fmt.Sprintf(constant.PASSWORD_NEEDS_SPECIAL, parameterName))
+	}
+	if IsBreachedPassword(password) {
+		addError(fmt.Sprintf(constant.PASSWORD_BREACHED, parameterName))
+	}
+
+	if len(errors) > 0 {
+		return errorhandling.CreateRequestDataValidationError(errors, http.StatusBadRequest)
+	}
+	return nil
+}
+
+// IsBreachedPassword tells whether password is in bundled list of common passwords or in configured breached list file, comparison is case insensitive.
+func IsBreachedPassword(password string) bool {
+	loadBreachedPasswordsOnce.Do(loadBreachedPasswords)
+	_, ok := breachedPasswords[strings.ToLower(password)]
+	return ok
+}
+
+// loadBreachedPasswords loads bundled list and configured breached list file into breachedPasswords, file which can't be read is logged and skipped
+// so that server keeps working with bundled list.
+func loadBreachedPasswords() {
+	breachedPasswords = make(map[string]struct{})
+	addPasswords(bufio.NewScanner(strings.NewReader(commonPasswordsFile)))
+
+	breachedListFile := config.Config.PasswordPolicy.BreachedListFile
+	if breachedListFile == constant.EMPTY_STRING {
+		return
+	}
+	file, err := os.Open(breachedListFile)
+	if err != nil {
+		config.LoggerInstance.Warning(err.Error())
+		return
+	}
+	defer file.Close()
+	addPasswords(bufio.NewScanner(file))
+}
+
+func addPasswords(scanner *bufio.Scanner) {
+	for scanner.Scan() {
+		password := strings.TrimSpace(scanner.Text())
+		if password != constant.EMPTY_STRING {
+			breachedPasswords[strings.ToLower(password)] = struct{}{}
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/chirag1807/task-management-system/constant"
+)
+
+// CreatePersonalAccessToken generates new personal access token, prefix makes it easy to distinguish it from jwt token.
+func CreatePersonalAccessToken() (string, error) {
+	randomID, err := CreateRandomID()
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	return constant.PERSONAL_ACCESS_TOKEN_PREFIX + randomID, nil
+}
+
+// HashPersonalAccessToken returns sha256 hash of personal access token, only this hash is stored in database.
+func HashPersonalAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -37,7 +37,6 @@ func ConsumeUserMail(rabbitmqConn *amqp.Connection) {
 		for m := range msgs {
 			var email dto.Email
 			_ = json.Unmarshal(m.Body, &email)
-			fmt.Println(email.To, email.Subject, email.Body)
 			SendEmail(email)
 		}
 	}()
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// AllowRequest counts request of given key in fixed window and reports whether it is within limit,
+// if it is not then it also returns time after which window resets so that client can be told when to retry.
+func AllowRequest(redisClient *redis.Client, key string, limit int64, window time.Duration) (bool, time.Duration, error) {
+	ctx := context.Background()
+	count, err := redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return false, 0, err
+	}
+	if count == 1 {
+		if err := redisClient.Expire(ctx, key, window).Err(); err != nil {
+			return false, 0, err
+		}
+	}
+	if count <= limit {
+		return true, 0, nil
+	}
+
+	retryAfter, err := redisClient.TTL(ctx, key).Result()
+	if err != nil {
+		return false, 0, err
+	}
+	// key without expiry would block forever, so it is fixed here.
+	if retryAfter < 0 {
+		retryAfter = window
+		if err := redisClient.Expire(ctx, key, window).Err(); err != nil {
+			return false, 0, err
+		}
+	}
+	return false, retryAfter, nil
+}
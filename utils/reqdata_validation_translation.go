@@ -82,6 +82,13 @@ func InitReqDataValidationTranslation() {
 		return t
 	})
 
+	Validate.RegisterTranslation("datetime", Translator, func(ut ut.Translator) error {
+		return ut.Add("datetime", "{0} field must be a date in {1} format.", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("datetime", fe.Field(), fe.Param())
+		return t
+	})
+
 	Validate.RegisterTranslation("min", Translator, func(ut ut.Translator) error {
 		return ut.Add("min", "{0} field violates minimum length/value constraint. length/value must be at least {1} long.", true)
 	}, func(ut ut.Translator, fe validator.FieldError) string {
@@ -110,6 +117,20 @@ func InitReqDataValidationTranslation() {
 		return t
 	})
 
+	Validate.RegisterTranslation("timezone", Translator, func(ut ut.Translator) error {
+		return ut.Add("timezone", "{0} must be a valid IANA time zone like Asia/Kolkata.", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("timezone", fe.Field(), fe.Param())
+		return t
+	})
+
+	Validate.RegisterTranslation("bcp47_language_tag", Translator, func(ut ut.Translator) error {
+		return ut.Add("bcp47_language_tag", "{0} must be a valid locale like en-US.", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("bcp47_language_tag", fe.Field(), fe.Param())
+		return t
+	})
+
 	Validate.RegisterTranslation("oneof", Translator, func(ut ut.Translator) error {
 		return ut.Add("oneof", "{0} must be one of {1}", true)
 	}, func(ut ut.Translator, fe validator.FieldError) string {
@@ -1,11 +1,15 @@
 package utils
 
 import (
+	"html"
 	"log"
 	"net/smtp"
 	"strconv"
+	"time"
 
 	"github.com/chirag1807/task-management-system/api/model/dto"
+	"github.com/chirag1807/task-management-system/api/model/request"
+	"github.com/chirag1807/task-management-system/api/model/response"
 	"github.com/chirag1807/task-management-system/config"
 	"github.com/chirag1807/task-management-system/constant"
 )
@@ -41,7 +45,8 @@ func SendEmail(email dto.Email) error {
 	return nil
 }
 
-func PrepareEmailBody(OTP int) string {
+// PrepareEmailBody prepares body of the email which carries otp, otp is valid for given lifetime only.
+func PrepareEmailBody(OTP string, lifetime time.Duration) string {
 	body := `
     <!DOCTYPE html>
     <html lang="en">
@@ -60,7 +65,8 @@ func PrepareEmailBody(OTP int) string {
         <div style="padding: 20px;">
             <p>Hello User,</p>
             <p>Please verify your email address by entering below code.</p>
-            <p>Your verification code is: <strong>` + strconv.Itoa(OTP) + `</strong></p>
+            <p>Your verification code is: <strong>` + OTP + `</strong></p>
+            <p>This code is valid for ` + strconv.Itoa(int(lifetime.Minutes())) + ` minutes and can be used only once.</p>
             <p>If you did not initiate this change, please contact our support team immediately.</p>
             <p>Best regards,<br>ZURU TECH</p>
         </div>
@@ -71,3 +77,106 @@ func PrepareEmailBody(OTP int) string {
 
 	return body
 }
+
+// PrepareRefreshTokenReuseEmailBody prepares body of the security alert email which is sent when already used refresh token is presented again,
+// detection time is shown in timezone of the user.
+func PrepareRefreshTokenReuseEmailBody(clientInfo request.ClientInfo, detectedAt time.Time, preferences response.UserPreferences) string {
+	body := `
+    <!DOCTYPE html>
+    <html lang="` + html.EscapeString(preferences.Locale) + `">
+
+    <head>
+        <meta charset="UTF-8">
+        <meta name="viewport" content="width=device-width, initial-scale=1.0">
+        <title>Security Alert</title>
+    </head>
+
+    <body style="font-family: Arial, sans-serif; margin: 0; padding: 0; background-color: #f4f4f4;">
+        <div style="background-color: #2196F3; color: white; text-align: center; padding: 20px;">
+            <h2>ZURU TECH</h2>
+        </div>
+
+        <div style="padding: 20px;">
+            <p>Hello User,</p>
+            <p>We noticed that an old session token of your account was used again, which can mean that it was stolen.</p>
+            <p>As a precaution we have signed out that session, so you will have to login again on that device.</p>
+            <p>Time: <strong>` + FormatDateTime(detectedAt, preferences) + `</strong></p>
+            <p>IP Address: <strong>` + html.EscapeString(clientInfo.IPAddress) + `</strong></p>
+            <p>Device: <strong>` + html.EscapeString(clientInfo.UserAgent) + `</strong></p>
+            <p>If this was not you, please change your password and contact our support team immediately.</p>
+            <p>Best regards,<br>ZURU TECH</p>
+        </div>
+    </body>
+
+    </html>
+`
+
+	return body
+}
+
+// PrepareAccountLockoutEmailBody prepares email body for notifying the user that their account is locked because of too many failed login attempts,
+// time till which account is locked is shown in timezone of the user.
+func PrepareAccountLockoutEmailBody(clientInfo request.ClientInfo, lockedUntil time.Time, preferences response.UserPreferences) string {
+	body := `
+    <!DOCTYPE html>
+    <html lang="` + html.EscapeString(preferences.Locale) + `">
+
+    <head>
+        <meta charset="UTF-8">
+        <meta name="viewport" content="width=device-width, initial-scale=1.0">
+        <title>Security Alert</title>
+    </head>
+
+    <body style="font-family: Arial, sans-serif; margin: 0; padding: 0; background-color: #f4f4f4;">
+        <div style="background-color: #2196F3; color: white; text-align: center; padding: 20px;">
+            <h2>ZURU TECH</h2>
+        </div>
+
+        <div style="padding: 20px;">
+            <p>Hello User,</p>
+            <p>We noticed too many failed login attempts on your account, so we have temporarily locked it to keep it safe.</p>
+            <p>You can login again after <strong>` + FormatDateTime(lockedUntil, preferences) + `</strong>.</p>
+            <p>Last attempt was made from IP Address <strong>` + html.EscapeString(clientInfo.IPAddress) + `</strong> and Device <strong>` + html.EscapeString(clientInfo.UserAgent) + `</strong>.</p>
+            <p>If this was not you, please reset your password once the lock is over.</p>
+            <p>Best regards,<br>ZURU TECH</p>
+        </div>
+    </body>
+
+    </html>
+`
+
+	return body
+}
+
+// PrepareTaskEmailBody prepares body of the email which notifies the user about task assigned to them or updated, deadline is shown in timezone and date format of the user.
+func PrepareTaskEmailBody(message string, task dto.TaskNotification, preferences response.UserPreferences) string {
+	body := `
+    <!DOCTYPE html>
+    <html lang="` + html.EscapeString(preferences.Locale) + `">
+
+    <head>
+        <meta charset="UTF-8">
+        <meta name="viewport" content="width=device-width, initial-scale=1.0">
+        <title>Task Notification</title>
+    </head>
+
+    <body style="font-family: Arial, sans-serif; margin: 0; padding: 0; background-color: #f4f4f4;">
+        <div style="background-color: #2196F3; color: white; text-align: center; padding: 20px;">
+            <h2>ZURU TECH</h2>
+        </div>
+
+        <div style="padding: 20px;">
+            <p>Hello User,</p>
+            <p>` + html.EscapeString(message) + `</p>
+            <p>Task: <strong>` + html.EscapeString(task.Title) + `</strong></p>
+            <p>Deadline: <strong>` + FormatDateTime(task.Deadline, preferences) + `</strong></p>
+            <p>You can change which notifications you receive by email from your preferences.</p>
+            <p>Best regards,<br>ZURU TECH</p>
+        </div>
+    </body>
+
+    </html>
+`
+
+	return body
+}
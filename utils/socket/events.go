@@ -41,3 +41,8 @@ func EmitCreateAndUpdateTaskEvents(server *socketio.Server, event string, room s
 		server.BroadcastToRoom("/", room, event, msg)
 	}
 }
+
+// EmitTeamActivityEvent emits team-activity event to the room of the team, so that members can update their activity feed.
+func EmitTeamActivityEvent(server *socketio.Server, event string, room string, msg interface{}) {
+	server.BroadcastToRoom("/", room, event, msg)
+}
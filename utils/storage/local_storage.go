@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage keeps files in directory of local disk, files are served by the application itself under path of base url.
+type LocalStorage struct {
+	Directory string
+	BaseURL   string
+}
+
+func NewLocalStorage(directory string, baseURL string) LocalStorage {
+	return LocalStorage{
+		Directory: directory,
+		BaseURL:   strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Save writes file to temporary path first and then renames it, so that partially written file is never served.
+func (l LocalStorage) Save(key string, data []byte) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// Delete removes file of given key, it is not an error if file doesn't exist.
+func (l LocalStorage) Delete(key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l LocalStorage) URL(key string) string {
+	return l.BaseURL + "/" + key
+}
+
+// FileSystem returns directory of the storage for serving its files by http.FileServer, directories are not served so that files can't be listed.
+func (l LocalStorage) FileSystem() http.FileSystem {
+	return filesOnlyFileSystem{fileSystem: http.Dir(l.Directory)}
+}
+
+// filesOnlyFileSystem opens only regular files of underlying file system, directory is reported as not existing.
+type filesOnlyFileSystem struct {
+	fileSystem http.FileSystem
+}
+
+func (f filesOnlyFileSystem) Open(name string) (http.File, error) {
+	file, err := f.fileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	fileInfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if fileInfo.IsDir() {
+		file.Close()
+		return nil, os.ErrNotExist
+	}
+	return file, nil
+}
+
+// path returns path of file of given key, key which points outside of the directory is rejected.
+func (l LocalStorage) path(key string) (string, error) {
+	path := filepath.Join(l.Directory, filepath.FromSlash(key))
+	relativePath, err := filepath.Rel(l.Directory, path)
+	if err != nil || relativePath == "." || strings.HasPrefix(relativePath, "..") {
+		return "", errors.New("invalid storage key " + key)
+	}
+	return path, nil
+}
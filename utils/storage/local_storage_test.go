@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorageFileSystem(t *testing.T) {
+	localStorage := NewLocalStorage(t.TempDir(), "http://localhost:9090/uploads")
+	assert.NoError(t, localStorage.Save("954488202459119617/avatar-128.png", []byte("avatar")))
+
+	testCases := []struct {
+		TestCaseName string
+		Path         string
+		StatusCode   int
+	}{
+		{
+			TestCaseName: "File Served.",
+			Path:         "/954488202459119617/avatar-128.png",
+			StatusCode:   200,
+		},
+		{
+			TestCaseName: "Root Directory Not Listed.",
+			Path:         "/",
+			StatusCode:   404,
+		},
+		{
+			TestCaseName: "Directory of User Not Listed.",
+			Path:         "/954488202459119617/",
+			StatusCode:   404,
+		},
+		{
+			TestCaseName: "File Not Found.",
+			Path:         "/954488202459119617/avatar-256.png",
+			StatusCode:   404,
+		},
+	}
+
+	for _, v := range testCases {
+		t.Run(v.TestCaseName, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", v.Path, http.NoBody)
+			w := httptest.NewRecorder()
+			http.FileServer(localStorage.FileSystem()).ServeHTTP(w, req)
+			assert.Equal(t, v.StatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"sync"
+
+	"github.com/chirag1807/task-management-system/config"
+	"github.com/chirag1807/task-management-system/constant"
+)
+
+// Storage stores files which are uploaded by users, like avatars, under given key and gives url from which they can be downloaded.
+type Storage interface {
+	Save(key string, data []byte) error
+	Delete(key string) error
+	URL(key string) string
+}
+
+var defaultStorage Storage
+var initDefaultStorage sync.Once
+
+// Default returns storage used by the application, files are kept on local disk in configured directory unless it is replaced by SetDefault.
+func Default() Storage {
+	initDefaultStorage.Do(func() {
+		if defaultStorage != nil {
+			return
+		}
+		directory := config.Config.Storage.AvatarDirectory
+		if directory == constant.EMPTY_STRING {
+			directory = constant.AVATAR_STORAGE_DIRECTORY
+		}
+		baseURL := config.Config.Storage.AvatarBaseURL
+		if baseURL == constant.EMPTY_STRING {
+			baseURL = constant.AVATAR_BASE_URL
+		}
+		defaultStorage = NewLocalStorage(directory, baseURL)
+	})
+	return defaultStorage
+}
+
+// SetDefault replaces storage returned by Default, it should be called at start before any file is stored.
+func SetDefault(storage Storage) {
+	defaultStorage = storage
+}
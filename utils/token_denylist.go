@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/chirag1807/task-management-system/constant"
+	"github.com/go-redis/redis/v8"
+)
+
+// DenyAccessToken adds jti of access token to redis denylist till the token expires, so that token can't be used anymore.
+func DenyAccessToken(redisClient *redis.Client, accessToken JWTTokenClaims) error {
+	if accessToken.TokenID == constant.EMPTY_STRING {
+		return nil
+	}
+	ttl := time.Until(accessToken.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return redisClient.Set(context.Background(), "denied_access_tokens:"+accessToken.TokenID, true, ttl).Err()
+}
+
+// DenyAllAccessTokensOfUser stores the time of revocation in redis, all access tokens of user issued before this time will be rejected.
+// key expires after access token lifetime because no token issued before that time can still be valid.
+func DenyAllAccessTokensOfUser(redisClient *redis.Client, userId int64, revokedAt time.Time) error {
+	return redisClient.Set(context.Background(), "user:"+strconv.FormatInt(userId, 10)+":tokens_revoked_at", revokedAt.Unix(), constant.ACCESS_TOKEN_LIFETIME).Err()
+}
+
+// DenySession marks session as revoked in redis, so that access tokens issued to this session will be rejected.
+// key expires after access token lifetime because no access token of the session can be valid after that.
+func DenySession(redisClient *redis.Client, sessionId int64) error {
+	return redisClient.Set(context.Background(), "revoked_sessions:"+strconv.FormatInt(sessionId, 10), true, constant.ACCESS_TOKEN_LIFETIME).Err()
+}
+
+// IsAccessTokenDenied checks whether given access token is revoked either individually, via revocation of its session or via revocation of all tokens of user.
+func IsAccessTokenDenied(redisClient *redis.Client, accessToken JWTTokenClaims) (bool, error) {
+	ctx := context.Background()
+	if accessToken.TokenID != constant.EMPTY_STRING {
+		denied, err := redisClient.Exists(ctx, "denied_access_tokens:"+accessToken.TokenID).Result()
+		if err != nil {
+			return false, err
+		}
+		if denied > 0 {
+			return true, nil
+		}
+	}
+
+	if accessToken.SessionID != 0 {
+		denied, err := redisClient.Exists(ctx, "revoked_sessions:"+strconv.FormatInt(accessToken.SessionID, 10)).Result()
+		if err != nil {
+			return false, err
+		}
+		if denied > 0 {
+			return true, nil
+		}
+	}
+
+	revokedAt, err := redisClient.Get(ctx, "user:"+strconv.FormatInt(accessToken.UserID, 10)+":tokens_revoked_at").Int64()
+	if err != nil {
+		if err == redis.Nil {
+			return false, nil
+		}
+		return false, err
+	}
+	return accessToken.IssuedAt.Unix() < revokedAt, nil
+}
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chirag1807/task-management-system/constant"
+)
+
+var totpSecretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret generates random base32 encoded secret which is shared with authenticator app of the user.
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return constant.EMPTY_STRING, err
+	}
+	return totpSecretEncoding.EncodeToString(secret), nil
+}
+
+// CreateTOTPURI creates otpauth:// uri of the secret, authenticator apps can scan it as qr code to add the account.
+func CreateTOTPURI(secret string, accountName string) string {
+	label := url.PathEscape(constant.TOTP_ISSUER + ":" + accountName)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", constant.TOTP_ISSUER)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(constant.TOTP_DIGITS))
+	params.Set("period", fmt.Sprint(constant.TOTP_PERIOD))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// GenerateTOTPCode generates code of given time step as per RFC 6238 (HMAC-SHA1 based one time password of RFC 4226).
+func GenerateTOTPCode(secret string, timeStep int64) (string, error) {
+	key, err := totpSecretEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return constant.EMPTY_STRING, err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(timeStep))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	binaryCode := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulo := uint32(1)
+	for i := 0; i < constant.TOTP_DIGITS; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", constant.TOTP_DIGITS, binaryCode%modulo), nil
+}
+
+// TOTPTimeStep returns time step of given time.
+func TOTPTimeStep(t time.Time) int64 {
+	return t.Unix() / constant.TOTP_PERIOD
+}
+
+// VerifyTOTPCode verifies code against current time step and one step before and after it to allow clock drift.
+// steps which are not greater than lastUsedStep are rejected, so that same code can't be replayed.
+// it returns matched time step and true if code is valid.
+func VerifyTOTPCode(secret string, code string, now time.Time, lastUsedStep int64) (int64, bool) {
+	currentStep := TOTPTimeStep(now)
+	for _, timeStep := range []int64{currentStep - 1, currentStep, currentStep + 1} {
+		if timeStep <= lastUsedStep {
+			continue
+		}
+		expectedCode, err := GenerateTOTPCode(secret, timeStep)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expectedCode), []byte(code)) == 1 {
+			return timeStep, true
+		}
+	}
+	return 0, false
+}
+
+// GenerateRecoveryCodes generates given number of one time recovery codes in xxxxx-xxxxx format.
+func GenerateRecoveryCodes(count int) ([]string, error) {
+	recoveryCodes := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		randomBytes := make([]byte, 5)
+		if _, err := rand.Read(randomBytes); err != nil {
+			return nil, err
+		}
+		code := hex.EncodeToString(randomBytes)
+		recoveryCodes = append(recoveryCodes, code[:5]+"-"+code[5:])
+	}
+	return recoveryCodes, nil
+}
+
+// HashRecoveryCode returns sha256 hash of recovery code, only this hash is stored in database.
+// recovery codes are random so fast hash is enough here, unlike passwords.
+func HashRecoveryCode(code string) string {
+	normalizedCode := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", constant.EMPTY_STRING))
+	sum := sha256.Sum256([]byte(normalizedCode))
+	return hex.EncodeToString(sum[:])
+}